@@ -1,6 +1,7 @@
 package article
 
 import (
+	"encoding/json"
 	"errors"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -15,17 +16,21 @@ var validate *validator.Validate
 
 func init() {
 	validate = validator.New()
+	validate.RegisterValidationCtx("limit", validateLimit)
+	validate.RegisterValidation("language", validateLanguage)
 }
 
 // NewArticle creates a new Article with the provided data and returns a pointer to the Article.
 func NewArticle() *Article {
 	return &Article{
-		ID:      uuid.New().String(),
-		Tags:    NewTags(),
-		Images:  NewImages(),
-		Videos:  NewVideos(),
-		Quotes:  NewQuotes(),
-		Socials: NewSocials(),
+		ID:           uuid.New().String(),
+		Tags:         NewTags(),
+		Images:       NewImages(),
+		Videos:       NewVideos(),
+		Medias:       NewMedias(),
+		Quotes:       NewQuotes(),
+		Socials:      NewSocials(),
+		Contributors: NewPersons(),
 	}
 }
 
@@ -35,92 +40,188 @@ func NewArticle() *Article {
 type Article struct {
 	ID string `json:"id" validate:"required,uuid"`
 	// Genre of the article, e.g. news, opinion, review.
-	Genre    string `json:"genre" validate:"max=500"`
-	Category string `json:"category" validate:"max=255"`
-	Author   string `json:"author" validate:"max=255"`
+	Genre    string `json:"genre" validate:"limit=Caption"`
+	Category string `json:"category" validate:"limit=Name"`
+	// Author is the byline of the article.
+	// Derived from the Contributors with the author role if empty.
+	Author string `json:"author" validate:"limit=Name"`
+	// Contributors are the persons who contributed to the article, e.g. authors, editors, photographers.
+	Contributors *Persons `json:"contributors"`
 	// Title of the article.
-	Title string `json:"title" validate:"required,max=255"`
+	Title string `json:"title" validate:"required,limit=Title"`
 	// Summary is a short description of the article.
-	Summary string `json:"summary" validate:"max=500"`
+	Summary string `json:"summary" validate:"limit=Summary"`
 	// Markup is the raw HTML or Markdown content of the article.
-	Markup string `json:"markup" validate:"required,max=65000"`
+	Markup string `json:"markup" validate:"required,limit=Markup"`
 	// Text plain text content of the article.
-	Text string `json:"text" validate:"required,max=65000"`
+	Text string `json:"text" validate:"required,limit=Text"`
 	// SourceURL is the URL of the article.
-	SourceURL string `json:"source_url" validate:"omitempty,url,max=4096"`
+	SourceURL string `json:"source_url" validate:"omitempty,url,limit=URL"`
 	// SourceName is the web resource name of the source, e.g. Washington Post.
-	SourceName string `json:"source_name" validate:"max=255"`
-	Language   string `json:"language" validate:"max=255"`
+	SourceName string `json:"source_name" validate:"limit=Name"`
+	// Language is the BCP 47 tag of the article language, e.g. en or pt-BR, see NormalizeLanguage.
+	// The language names and codes normalized by NormalizeLanguage are valid too, e.g. English.
+	Language string `json:"language" validate:"omitempty,language,limit=Name"`
 	// Published is the date and time when the article was published.
 	Published time.Time `json:"published" validate:"required"`
 	Modified  time.Time `json:"modified"`
 	Images    *Images   `json:"images"`
 	Videos    *Videos   `json:"videos"`
-	Quotes    *Quotes   `json:"quotes"`
-	Tags      *Tags     `json:"tags"`
-	Socials   *Socials  `json:"socials"`
+	// Medias are the other media files of the article, e.g. audio or documents.
+	Medias *Medias `json:"medias"`
+	Quotes *Quotes `json:"quotes"`
+	Tags   *Tags   `json:"tags"`
+	// TagScores are the relevance scores of the Tags proposed by ExtractTags, from 0 to 1.
+	// This field is optional, the scores of the removed tags are dropped by Normalize.
+	TagScores map[string]float64 `json:"tag_scores,omitempty"`
+	Socials   *Socials           `json:"socials"`
+	// ReadingStats are the reading statistics of the Text, e.g. the word count and the reading time.
+	// This field is optional, assigned by AssignStats and refreshed by Normalize.
+	ReadingStats *Stats `json:"stats,omitempty"`
 }
 
 // Normalize validates the Article and its nested structures, logs any validation errors, and clears invalid fields.
 func (a *Article) Normalize() error {
+	_, err := a.NormalizeWithReport()
+	return err
+}
+
+// NormalizeWithReport normalizes the Article like Normalize and returns the report
+// of every trimmed, reset, removed or defaulted field.
+// The report is returned even if normalization fails.
+func (a *Article) NormalizeWithReport() (*NormalizeReport, error) {
+	return a.NormalizeWithLimits(nil)
+}
+
+// NormalizeWithLimits normalizes the Article like NormalizeWithReport, but trims and validates
+// the Article and its nested structures with the limits, DefaultLimits if nil.
+// Use it to prepare the Article for the destination with own limits, e.g. short titles of push notifications.
+func (a *Article) NormalizeWithLimits(limits *Limits) (*NormalizeReport, error) {
 
-	a.trimFields()
-	a.fallbackFields()
+	report := NewNormalizeReport()
+	report.profile = limits
+
+	a.legacyFields()
+	a.trimFields(report)
+	if isHTML(a.Markup) {
+		a.Markup = MarkupPolicy.sanitize(report, "Article.Markup", a.Markup)
+	}
+	a.canonicalURLs(report)
+	a.fallbackFields(report)
 
 	// clear invalid fields
-	if err := a.normalizeFields(); err != nil {
+	if err := a.normalizeFields(report); err != nil {
+		return report, err
+	}
+
+	// Normalize nested structures, the item URLs are canonicalized once by the items
+	// and the relative ones are resolved against the SourceURL
+	report.base = a.SourceURL
+	a.Images.normalize(report, "Article.Images")
+	a.Videos.normalize(report, "Article.Videos")
+	a.Medias.normalize(report, "Article.Medias")
+	a.Quotes.normalize(report, "Article.Quotes")
+	a.Socials.normalize(report, "Article.Socials")
+	a.Contributors.normalize(report, "Article.Contributors")
+	a.Tags.normalize(report, "Article.Tags")
+	a.tagScoresField()
+
+	a.bylineField(report)
+
+	if a.ReadingStats != nil {
+		a.AssignStats()
+	}
+
+	return report, nil
+}
+
+// UnmarshalJSON decodes the Article using encoding/json,
+// the collections missing from the JSON stored before they were added are empty, see legacyFields.
+func (a *Article) UnmarshalJSON(data []byte) error {
+
+	type plain Article
+	if err := json.Unmarshal(data, (*plain)(a)); err != nil {
 		return err
 	}
 
-	// Normalize nested structures
-	a.Images.Normalize()
-	a.Videos.Normalize()
-	a.Quotes.Normalize()
-	a.Socials.Normalize()
+	a.legacyFields()
 
 	return nil
 }
 
-func (a *Article) trimFields() {
-	a.ID = TrimToMaxLen(a.ID, 36)
-	a.Genre = TrimToMaxLen(a.Genre, 500)
-	a.Category = TrimToMaxLen(a.Category, 255)
-	a.Author = TrimToMaxLen(a.Author, 255)
-	a.Title = TrimToMaxLen(a.Title, 255)
-	a.Summary = TrimToMaxLen(a.Summary, 500)
-	a.Markup = TrimToMaxLen(a.Markup, 65000)
-	a.Text = TrimToMaxLen(a.Text, 65000)
-	a.SourceURL = TrimToMaxLen(a.SourceURL, 4096)
-	a.SourceName = TrimToMaxLen(a.SourceName, 255)
-	a.Language = TrimToMaxLen(a.Language, 255)
+// legacyFields initializes the Medias and the Contributors of the articles created before they were added,
+// so the items added to them are kept by the Article.
+func (a *Article) legacyFields() {
+	if a.Medias == nil {
+		a.Medias = NewMedias()
+	}
+	if a.Contributors == nil {
+		a.Contributors = NewPersons()
+	}
 }
 
-func (a *Article) fallbackFields() {
+func (a *Article) trimFields(report *NormalizeReport) {
+	limits := report.limits()
+	a.ID = report.trim("Article.ID", a.ID, 36)
+	a.Genre = report.trim("Article.Genre", a.Genre, limits.Caption)
+	a.Category = report.trim("Article.Category", a.Category, limits.Name)
+	a.Author = report.trim("Article.Author", a.Author, limits.Name)
+	a.Title = report.trim("Article.Title", a.Title, limits.Title)
+	a.Summary = report.trim("Article.Summary", a.Summary, limits.Summary)
+	a.Markup = report.trim("Article.Markup", a.Markup, limits.Markup)
+	a.Text = report.trim("Article.Text", a.Text, limits.Text)
+	a.SourceURL = report.trim("Article.SourceURL", a.SourceURL, limits.URL)
+	a.SourceName = report.trim("Article.SourceName", a.SourceName, limits.Name)
+	a.Language = report.trim("Article.Language", a.Language, limits.Name)
+}
 
-	// language: english
-	if a.Language == "" {
-		a.Language = "en"
-	}
+// canonicalURLs canonicalizes the SourceURL. The items canonicalize their URLs once during normalization
+// and resolve the relative ones against the SourceURL, see Canonicalizer.Assets.
+func (a *Article) canonicalURLs(report *NormalizeReport) {
+	a.SourceURL = URLCanonicalizer.canonical(report, "Article.SourceURL", a.SourceURL, "")
+}
+
+func (a *Article) fallbackFields(report *NormalizeReport) {
+
+	// language: detected or english
+	a.languageField(report)
 
-	// category: general
+	// category: canonical path or general
+	a.categoryField(report)
 	if a.Category == "" {
 		a.Category = "General"
+		report.fallback("Article.Category", "")
 	}
 
 	// genre: article
 	if a.Genre == "" {
 		a.Genre = "Article"
+		report.fallback("Article.Genre", "")
 	}
 
 	// published: now
 	if a.Published.IsZero() {
+		report.fallback("Article.Published", a.Published)
 		a.Published = time.Now()
 	}
 }
 
-func (a *Article) normalizeFields() (err error) {
+// bylineField derives the Author from the Contributors if empty.
+func (a *Article) bylineField(report *NormalizeReport) {
 
-	if err = a.Validate(); err == nil {
+	if a.Author != "" {
+		return
+	}
+
+	if byline := a.Contributors.Byline(); byline != "" {
+		a.Author = TrimToMaxLen(byline, report.limits().Name)
+		report.fallback("Article.Author", "")
+	}
+}
+
+func (a *Article) normalizeFields(report *NormalizeReport) (err error) {
+
+	if err = report.validate(a); err == nil {
 		// no errors
 		return nil
 	}
@@ -131,18 +232,28 @@ func (a *Article) normalizeFields() (err error) {
 		return err
 	}
 
+	// every invalid field is reported before the missing required field fails the Article
+	rejected := false
+
 	for _, invalid := range invalids {
 
 		slog.Debug("Validation error", slog.String("field", invalid.Namespace()), slog.String("error", invalid.Tag()))
 
 		if invalid.Tag() == "required" {
-			return err
+			report.Add(invalid.Namespace(), invalid.Tag(), invalid.Value(), ActionRejected)
+			rejected = true
+			continue
 		}
 
 		// Clear invalid fields
+		report.Add(invalid.Namespace(), invalid.Tag(), invalid.Value(), ActionReset)
 		a.resetField(invalid.Namespace())
 	}
 
+	if rejected {
+		return err
+	}
+
 	return nil
 }
 
@@ -177,147 +288,175 @@ func (a *Article) resetField(name string) {
 	}
 }
 
+// Validate validates the Article with DefaultLimits.
 func (a *Article) Validate() error {
 	return validate.Struct(a)
 }
 
+// ValidateWithLimits validates the Article with the limits, DefaultLimits if nil.
+func (a *Article) ValidateWithLimits(limits *Limits) error {
+	return validateWithLimits(a, limits)
+}
+
 // Map converts the Article struct to a map[string]any, including nested structures.
 func (a *Article) Map() map[string]any {
 
-	images := make([]map[string]any, a.Images.Len())
-	for i, image := range a.Images.Slice() {
-		images[i] = image.Map()
-	}
-
-	videos := make([]map[string]any, a.Videos.Len())
-	for i, video := range a.Videos.Slice() {
-		videos[i] = video.Map()
+	m := map[string]any{
+		"id":           a.ID,
+		"title":        a.Title,
+		"summary":      a.Summary,
+		"markup":       a.Markup,
+		"text":         a.Text,
+		"genre":        a.Genre,
+		"images":       a.Images.Maps(),
+		"videos":       a.Videos.Maps(),
+		"medias":       a.Medias.Maps(),
+		"quotes":       a.Quotes.Maps(),
+		"author":       a.Author,
+		"published":    a.Published,
+		"modified":     a.Modified,
+		"tags":         a.Tags.Slice(),
+		"source_url":   a.SourceURL,
+		"language":     a.Language,
+		"category":     a.Category,
+		"source_name":  a.SourceName,
+		"socials":      a.Socials.Maps(),
+		"contributors": a.Contributors.Maps(),
 	}
 
-	quotes := make([]map[string]any, a.Quotes.Len())
-	for i, quote := range a.Quotes.Slice() {
-		quotes[i] = quote.Map()
+	if len(a.TagScores) > 0 {
+		scores := make(map[string]any, len(a.TagScores))
+		for tag, score := range a.TagScores {
+			scores[tag] = score
+		}
+		m["tag_scores"] = scores
 	}
 
-	socialProfiles := make([]map[string]any, a.Socials.Len())
-	for i, profile := range a.Socials.Slice() {
-		socialProfiles[i] = profile.Map()
+	if a.ReadingStats != nil {
+		m["stats"] = a.ReadingStats.Map()
 	}
 
-	return map[string]any{
-		"id":          a.ID,
-		"title":       a.Title,
-		"summary":     a.Summary,
-		"markup":      a.Markup,
-		"text":        a.Text,
-		"genre":       a.Genre,
-		"images":      images,
-		"videos":      videos,
-		"quotes":      quotes,
-		"author":      a.Author,
-		"published":   a.Published,
-		"modified":    a.Modified,
-		"tags":        a.Tags.Slice(),
-		"source_url":  a.SourceURL,
-		"language":    a.Language,
-		"category":    a.Category,
-		"source_name": a.SourceName,
-		"socials":     socialProfiles,
-	}
+	return m
 }
 
 // NewArticleFromMap creates an Article from a map[string]any, validates it, and returns a pointer to the Article or an error.
+// The map could be produced by Map or by json.Unmarshal, nested items of unexpected types or invalid items are skipped.
 func NewArticleFromMap(m map[string]any) (*Article, error) {
+	return articleFromMap(newMapReader(m))
+}
+
+// NewArticleFromMapWithLimits creates an Article from a map[string]any like NewArticleFromMap,
+// but validates the Article and its nested structures with the limits, DefaultLimits if nil.
+// Use it to load the articles normalized with own limits, e.g. the long texts of NormalizeWithLimits.
+func NewArticleFromMapWithLimits(m map[string]any, limits *Limits) (*Article, error) {
+	r := newMapReader(m)
+	r.limits = limits
+	return articleFromMap(r)
+}
+
+// NewArticleFromMapStrict creates an Article from a map[string]any like NewArticleFromMap,
+// but fails with MapErrors listing every key holding a value of unexpected type, e.g. images[0].width
+func NewArticleFromMapStrict(m map[string]any) (*Article, error) {
+	return articleFromMap(newStrictMapReader(m))
+}
+
+// articleFromMap reads the Article with the map reader, validates it, and returns a pointer to the Article or an error.
+func articleFromMap(r *mapReader) (*Article, error) {
 
 	images := NewImages()
-	if imgMaps, ok := m["images"].([]map[string]any); ok {
-		for _, imgMap := range imgMaps {
-			if img, err := NewImageFromMap(imgMap); err == nil {
-				images.Add(img)
-			}
+	for _, sub := range r.maps("images") {
+		if img, err := imageFromMap(sub); err == nil {
+			images.addWithLimits(r.limits, img)
 		}
 	}
 
 	videos := NewVideos()
-	if vidMaps, ok := m["videos"].([]map[string]any); ok {
-		for _, vidMap := range vidMaps {
-			if vid, err := NewVideoFromMap(vidMap); err == nil {
-				videos.Add(vid)
-			}
+	for _, sub := range r.maps("videos") {
+		if vid, err := videoFromMap(sub); err == nil {
+			videos.addWithLimits(r.limits, vid)
+		}
+	}
+
+	medias := NewMedias()
+	for _, sub := range r.maps("medias") {
+		if media, err := mediaFromMap(sub); err == nil {
+			medias.addWithLimits(r.limits, media)
 		}
 	}
 
 	quotes := NewQuotes()
-	if quoteMaps, ok := m["quotes"].([]map[string]any); ok {
-		for _, quoteMap := range quoteMaps {
-			if quote, err := NewQuoteFromMap(quoteMap); err == nil {
-				quotes.Add(quote)
-			}
+	for _, sub := range r.maps("quotes") {
+		if quote, err := quoteFromMap(sub); err == nil {
+			quotes.addWithLimits(r.limits, quote)
 		}
 	}
 
 	social := NewSocials()
-	if profileMaps, ok := m["socials"].([]map[string]any); ok {
-		for _, profileMap := range profileMaps {
-			if profile, err := NewSocialProfileFromMap(profileMap); err == nil {
-				social.Add(profile)
-			}
+	for _, sub := range r.maps("socials") {
+		if profile, err := socialProfileFromMap(sub); err == nil {
+			social.addWithLimits(r.limits, profile)
 		}
 	}
 
-	publishDate, _ := m["published"].(time.Time)
-	modifiedDate, _ := m["modified"].(time.Time)
+	contributors := NewPersons()
+	for _, sub := range r.maps("contributors") {
+		if person, err := personFromMap(sub); err == nil {
+			contributors.addWithLimits(r.limits, person)
+		}
+	}
 
 	article := &Article{
-		ID:         StringFromMap(m, "id"),
-		Title:      StringFromMap(m, "title"),
-		Summary:    StringFromMap(m, "summary"),
-		Markup:     StringFromMap(m, "markup"),
-		Text:       StringFromMap(m, "text"),
-		Genre:      StringFromMap(m, "genre"),
-		Images:     images,
-		Videos:     videos,
-		Quotes:     quotes,
-		Published:  publishDate,
-		Modified:   modifiedDate,
-		Tags:       NewTags(GetStringSlice(m, "tags")...),
-		SourceURL:  StringFromMap(m, "source_url"),
-		Language:   StringFromMap(m, "language"),
-		Category:   StringFromMap(m, "category"),
-		SourceName: StringFromMap(m, "source_name"),
-		Socials:    social,
-	}
-
-	err := validate.Struct(article)
-	if err != nil {
-		return nil, err
+		ID:           r.string("id"),
+		Title:        r.string("title"),
+		Summary:      r.string("summary"),
+		Markup:       r.string("markup"),
+		Text:         r.string("text"),
+		Genre:        r.string("genre"),
+		Author:       r.string("author"),
+		Contributors: contributors,
+		Images:       images,
+		Videos:       videos,
+		Medias:       medias,
+		Quotes:       quotes,
+		Published:    r.time("published"),
+		Modified:     r.time("modified"),
+		Tags:         NewTags().addWithLimits(r.limits, r.strings("tags")...),
+		SourceURL:    r.string("source_url"),
+		Language:     mapLanguage(r.string("language")),
+		Category:     r.string("category"),
+		SourceName:   r.string("source_name"),
+		Socials:      social,
 	}
 
-	return article, nil
-}
+	if scores := r.sub("tag_scores"); scores != nil {
+		article.TagScores = map[string]float64{}
+		for tag := range scores.m {
+			article.TagScores[tag] = scores.float(tag)
+		}
+	}
 
-// ReplaceURLs replaces the URLs in the Article and related structs with the URLs from the provided map.
-func (a *Article) ReplaceURLs(m map[string]string) []string {
+	if stats := r.sub("stats"); stats != nil {
+		article.ReadingStats = statsFromMap(stats)
+	}
 
-	remove := a.Images.ReplaceURLs(m)
+	if err := r.failed(); err != nil {
+		return nil, err
+	}
 
-	return remove
-}
+	err := r.validate(article)
+	if err != nil {
+		return nil, err
+	}
 
-// ReplaceURLs replaces the URLs in the Article and related structs with the URLs from the provided map.
-func (a *Article) ReplaceOrRemoveURLs(m map[string]string) []string {
-	remove := a.Images.ReplaceOrRemoveURLs(m)
-	return remove
+	return article, nil
 }
 
-// GetStringSlice safely extracts a slice of strings from the map or returns a zero value.
-func GetStringSlice(m map[string]any, key string) []string {
-	if value, ok := m[key]; ok {
-		if slice, ok := value.([]string); ok {
-			return slice
-		}
+// mapLanguage returns the BCP 47 tag of the stored language, e.g. english is en, or the value as is
+func mapLanguage(value string) string {
+	if tag := NormalizeLanguage(value); tag != "" {
+		return tag
 	}
-	return []string{}
+	return value
 }
 
 // TrimToMaxLen trims the input string to the specified maximum length, ensuring that it doesn't exceed the length in runes.
@@ -66,6 +66,15 @@ func TestFullInvariantArticle(t *testing.T) {
 		Title: gofakeit.Sentence(10),
 	})
 
+	expected.Medias = article.NewMedias(&article.Media{
+		ID:          gofakeit.UUID(),
+		URL:         gofakeit.URL(),
+		Author:      gofakeit.Name(),
+		Title:       gofakeit.Sentence(5),
+		Description: gofakeit.Sentence(10),
+		Size:        gofakeit.Number(1024, 4096),
+	})
+
 	expected.Quotes = article.NewQuotes(&article.Quote{
 		ID:        gofakeit.UUID(),
 		Text:      gofakeit.Sentence(15),
@@ -234,11 +243,49 @@ func TestArticleNormalizeFieldClearing(t *testing.T) {
 	assert.Equal(t, invalid.Summary, valid.Summary) // should not be cleared since it's not required
 	assert.Equal(t, invalid.Genre, valid.Genre)     // should not be cleared since it's not required
 	assert.Equal(t, "", valid.SourceURL)
-	assert.Equal(t, "english", valid.Language)
+	assert.Equal(t, "en", valid.Language)
 	assert.Equal(t, invalid.Category, valid.Category)
 	assert.Equal(t, invalid.SourceName, valid.SourceName)
 }
 
+func TestArticleContributors(t *testing.T) {
+
+	expected := article.NewArticle()
+	expected.Title = gofakeit.Sentence(3)
+	expected.Markup = gofakeit.Paragraph(1, 5, 10, " ")
+	expected.Text = gofakeit.Paragraph(1, 5, 10, " ")
+	expected.Published = time.Now()
+
+	author := article.NewPerson(gofakeit.Name())
+	photographer := article.NewPerson(gofakeit.Name())
+	photographer.Role = article.RolePhotographer
+	expected.Contributors.Add(author, photographer)
+
+	// byline derived from authors
+	require.NoError(t, expected.Normalize())
+	assert.Equal(t, author.Name, expected.Author)
+
+	// map round-trip
+	got, err := article.NewArticleFromMap(expected.Map())
+	require.NoError(t, err)
+	assert.Equal(t, expected.Author, got.Author)
+	assert.Equal(t, expected.Contributors, got.Contributors)
+
+	// json round-trip
+	data, err := json.Marshal(expected)
+	require.NoError(t, err)
+
+	decoded := article.Article{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, expected.Contributors.IDs(), decoded.Contributors.IDs())
+	assert.Equal(t, article.RolePhotographer, decoded.Contributors.Slice()[1].Role)
+
+	// explicit byline is kept
+	expected.Author = "Editorial Board"
+	require.NoError(t, expected.Normalize())
+	assert.Equal(t, "Editorial Board", expected.Author)
+}
+
 // TestGetStringSlice tests the GetStringSlice function in case of empty map and missing key:
 func TestGetStringSlice(t *testing.T) {
 	m := map[string]interface{}{}
@@ -285,6 +332,14 @@ func TestUnmarshal(t *testing.T) {
 				"title": "A video explaining AI."
 			  }
 		  ],
+		  "medias": [
+			  {
+				"id": "media-001",
+				"url": "https://example.com/podcast.mp3",
+				"title": "AI podcast",
+				"size": 1048576
+			  }
+		  ],
 		  "quotes": [
 			  {
 				"id": "quote-001",
@@ -326,11 +381,105 @@ func TestUnmarshal(t *testing.T) {
 	// check the values of the nested structures
 	assert.Equal(t, 1, art.Images.Len())
 	assert.Equal(t, 1, art.Videos.Len())
+	assert.Equal(t, 1, art.Medias.Len())
+	assert.Equal(t, 1048576, art.Medias.Slice()[0].Size)
 	assert.Equal(t, 1, art.Quotes.Len())
 	assert.Equal(t, 1, art.Socials.Len())
 	assert.Equal(t, 3, art.Tags.Len())
 }
 
+// TestUnmarshal_Legacy normalizes the JSON stored before the Contributors and the Medias were added
+func TestUnmarshal_Legacy(t *testing.T) {
+
+	js := `{
+		  "id": "123e4567-e89b-12d3-a456-426614174000",
+		  "title": "The Rise of AI",
+		  "markup": "<p>Artificial Intelligence is transforming the world.</p>",
+		  "text": "Artificial Intelligence is transforming the world.",
+		  "author": "John Doe",
+		  "published": "2024-05-27T10:00:00Z",
+		  "images": [{"id": "img-001", "url": "https://example.com/image1.jpg"}],
+		  "videos": [],
+		  "quotes": [],
+		  "tags": ["AI"],
+		  "socials": []
+	}`
+
+	art := article.Article{}
+	require.NoError(t, json.Unmarshal([]byte(js), &art))
+	require.NotNil(t, art.Contributors)
+	require.NotNil(t, art.Medias)
+
+	require.NotPanics(t, func() {
+		require.NoError(t, art.Normalize())
+		require.NoError(t, art.Validate())
+	})
+
+	assert.Equal(t, "John Doe", art.Author)
+	assert.Equal(t, 0, art.Contributors.Len())
+	assert.Equal(t, 0, art.Medias.Len())
+
+	// the items added to the legacy article are kept
+	art.Medias.Add(article.NewMedia("https://example.com/podcast.mp3"))
+	art.Contributors.Add(article.NewPerson("Jane Roe"))
+	assert.Equal(t, 1, art.Medias.Len())
+	assert.Equal(t, 1, art.Contributors.Len())
+	art.Medias = nil
+	art.Contributors = nil
+
+	// the legacy article built in code is normalized
+	require.NoError(t, art.Normalize())
+	assert.NotNil(t, art.Medias)
+	assert.NotNil(t, art.Contributors)
+
+	// the map round-trip of the legacy article
+	got, err := article.NewArticleFromMap(art.Map())
+	require.NoError(t, err)
+	assert.Equal(t, 0, got.Contributors.Len())
+	assert.Equal(t, 0, got.Medias.Len())
+
+	// the legacy articles are aggregated
+	list := article.NewArticles(&art)
+	assert.Equal(t, 0, list.Medias().Len())
+	assert.Equal(t, 0, list.Contributors().Len())
+}
+
+// TestCollections_Nil calls the methods of the nil collections of the legacy articles
+func TestCollections_Nil(t *testing.T) {
+
+	var persons *article.Persons
+	var medias *article.Medias
+
+	require.NotPanics(t, func() {
+		assert.Equal(t, 0, persons.Len())
+		assert.Empty(t, persons.Slice())
+		assert.Empty(t, persons.IDs())
+		assert.Empty(t, persons.Maps())
+		assert.Empty(t, persons.Byline())
+		assert.Nil(t, persons.Sort(func(a, b *article.Person) int { return 0 }))
+		assert.Nil(t, persons.Dedupe())
+		assert.Nil(t, persons.DedupeFunc(func(p *article.Person) string { return p.Name }))
+		assert.Nil(t, persons.Remove("id"))
+		_, found := persons.Get("id")
+		assert.False(t, found)
+		persons.Normalize()
+
+		assert.Equal(t, 0, medias.Len())
+		assert.Empty(t, medias.Slice())
+		assert.Empty(t, medias.IDs())
+		assert.Empty(t, medias.Maps())
+		assert.Empty(t, medias.Filter().Slice())
+		assert.Nil(t, medias.Sort(func(a, b *article.Media) int { return 0 }))
+		assert.Nil(t, medias.Dedupe())
+		_, found = medias.Get("id")
+		assert.False(t, found)
+		medias.Normalize()
+	})
+
+	assert.Equal(t, 1, persons.Add(article.NewPerson("Jane Roe")).Len())
+	assert.Equal(t, 1, medias.Add(article.NewMedia("https://example.com/podcast.mp3")).Len())
+}
+
 func TestArticle_ReplaceURLs_Empty(t *testing.T) {
 
 	a := article.NewArticle()
@@ -143,6 +143,14 @@ func (list *Articles) Videos() *Videos {
 	return videos
 }
 
+func (list *Articles) Medias() *Medias {
+	medias := NewMedias()
+	for _, article := range list.items {
+		medias.Add(article.Medias.Slice()...)
+	}
+	return medias
+}
+
 func (list *Articles) Quotes() *Quotes {
 	quotes := NewQuotes()
 	for _, article := range list.items {
@@ -159,6 +167,14 @@ func (list *Articles) Socials() *Socials {
 	return socials
 }
 
+func (list *Articles) Contributors() *Persons {
+	persons := NewPersons()
+	for _, article := range list.items {
+		persons.Add(article.Contributors.Slice()...)
+	}
+	return persons
+}
+
 func (list *Articles) Tags() *Tags {
 	tags := NewTags()
 	for _, article := range list.items {
@@ -386,3 +386,15 @@ func TestArticles_FilterFn(t *testing.T) {
 
 	assert.NotNil(t, articles)
 }
+
+func TestArticles_Medias(t *testing.T) {
+
+	article1 := article.NewArticle()
+	article1.Medias.Add(article.NewMedia("https://example.com/podcast.mp3"))
+
+	article2 := article.NewArticle()
+	article2.Medias.Add(article.NewMedia("https://example.com/report.pdf"), article.NewMedia("https://example.com/video.mp4"))
+
+	medias := article.NewArticles(article1, article2).Medias()
+	assert.Equal(t, 3, medias.Len())
+}
@@ -0,0 +1,301 @@
+package article
+
+import (
+	"encoding/xml"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type atomFeed struct {
+	XMLName   xml.Name     `xml:"feed"`
+	NS        string       `xml:"xmlns,attr"`
+	Lang      string       `xml:"xml:lang,attr,omitempty"`
+	ID        string       `xml:"id"`
+	Title     string       `xml:"title"`
+	Subtitle  string       `xml:"subtitle,omitempty"`
+	Updated   string       `xml:"updated"`
+	Links     []atomLink   `xml:"link"`
+	Authors   []atomPerson `xml:"author"`
+	Rights    string       `xml:"rights,omitempty"`
+	Logo      string       `xml:"logo,omitempty"`
+	Generator string       `xml:"generator,omitempty"`
+	Entries   []atomEntry  `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published,omitempty"`
+	Updated    string         `xml:"updated"`
+	Authors    []atomPerson   `xml:"author"`
+	Summary    *atomText      `xml:"summary,omitempty"`
+	Content    *atomText      `xml:"content,omitempty"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Title  string `xml:"title,attr,omitempty"`
+	Length string `xml:"length,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// WriteAtom writes the Articles as Atom 1.0 feed.
+// Images and videos are written as enclosure links.
+func (list *Articles) WriteAtom(w io.Writer, feed Feed) error {
+
+	doc := atomFeed{
+		NS:        nsAtom,
+		Lang:      feed.Language,
+		ID:        feed.id(),
+		Title:     feed.Title,
+		Subtitle:  feed.Description,
+		Updated:   atomTime(feed.updated(list)),
+		Rights:    feed.Copyright,
+		Logo:      feed.ImageURL,
+		Generator: feed.Generator,
+	}
+
+	if feed.Link != "" {
+		doc.Links = append(doc.Links, atomLink{Href: feed.Link, Rel: "alternate"})
+	}
+
+	if feed.FeedURL != "" {
+		doc.Links = append(doc.Links, atomLink{Href: feed.FeedURL, Rel: "self", Type: "application/atom+xml"})
+	}
+
+	if feed.Author != "" {
+		doc.Authors = append(doc.Authors, atomPerson{Name: feed.Author})
+	}
+
+	for _, a := range list.Slice() {
+		doc.Entries = append(doc.Entries, atomArticle(a))
+	}
+
+	return writeXML(w, doc)
+}
+
+func atomArticle(a *Article) atomEntry {
+
+	entry := atomEntry{
+		ID:        "urn:uuid:" + a.ID,
+		Title:     a.Title,
+		Published: atomTime(a.Published),
+		Updated:   atomTime(a.updated()),
+	}
+
+	if a.SourceURL != "" {
+		entry.Links = append(entry.Links, atomLink{Href: a.SourceURL, Rel: "alternate"})
+	}
+
+	for _, img := range a.Images.Slice() {
+		entry.Links = append(entry.Links, atomLink{Href: img.URL, Rel: "enclosure", Type: mimeType(img.URL), Title: img.Alt})
+	}
+
+	for _, video := range a.Videos.Slice() {
+		entry.Links = append(entry.Links, atomLink{Href: video.URL, Rel: "enclosure", Type: mimeType(video.URL), Title: video.Title})
+	}
+
+	for _, name := range a.authors() {
+		entry.Authors = append(entry.Authors, atomPerson{Name: name})
+	}
+
+	if a.Summary != "" {
+		entry.Summary = &atomText{Type: "text", Value: a.Summary}
+	}
+
+	if a.Markup != "" {
+		entry.Content = &atomText{Type: "html", Value: a.Markup}
+	}
+
+	for _, category := range a.categories() {
+		entry.Categories = append(entry.Categories, atomCategory{Term: category})
+	}
+
+	return entry
+}
+
+func atomTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// atomSpaces are the namespaces of Atom 1.0 and 0.3
+var atomSpaces = []string{nsAtom, "http://purl.org/atom/ns#"}
+
+type atomParseFeed struct {
+	Lang      string           `xml:"lang,attr"`
+	Titles    []atomParseText  `xml:"title"`
+	Subtitles []atomParseText  `xml:"subtitle"`
+	Links     []atomParseLink  `xml:"link"`
+	Rights    string           `xml:"rights"`
+	Generator string           `xml:"generator"`
+	Logo      string           `xml:"logo"`
+	Authors   []atomPerson     `xml:"author"`
+	Entries   []atomParseEntry `xml:"entry"`
+}
+
+type atomParseEntry struct {
+	Lang       string          `xml:"lang,attr"`
+	Titles     []atomParseText `xml:"title"`
+	Links      []atomParseLink `xml:"link"`
+	Published  []xmlText       `xml:"published"`
+	Issued     []xmlText       `xml:"issued"`
+	Updated    []xmlText       `xml:"updated"`
+	Authors    []atomPerson    `xml:"author"`
+	Summaries  []atomParseText `xml:"summary"`
+	Contents   []atomParseText `xml:"content"`
+	Categories []atomCategory  `xml:"category"`
+	Groups     []rssParseGroup `xml:"group"`
+	Media      []rssParseMedia `xml:"thumbnail"`
+}
+
+type atomParseLink struct {
+	XMLName xml.Name
+	Href    string `xml:"href,attr"`
+	Rel     string `xml:"rel,attr"`
+	Type    string `xml:"type,attr"`
+	Title   string `xml:"title,attr"`
+	Length  string `xml:"length,attr"`
+}
+
+// atomParseText is the Atom text construct, the value is HTML for type html and xhtml
+type atomParseText struct {
+	XMLName xml.Name
+	Type    string `xml:"type,attr"`
+	Value   string `xml:",chardata"`
+	Inner   string `xml:",innerxml"`
+}
+
+// html returns the text construct as HTML
+func (t atomParseText) html() string {
+	switch t.Type {
+	case "xhtml":
+		return strings.TrimSpace(t.Inner)
+	case "html", "text/html":
+		return strings.TrimSpace(t.Value)
+	}
+	return html.EscapeString(strings.TrimSpace(t.Value))
+}
+
+// pickAtomText returns the first non-empty Atom text construct as HTML
+func pickAtomText(elems []atomParseText) string {
+	for _, elem := range elems {
+		for _, space := range atomSpaces {
+			if elem.XMLName.Space == space {
+				if value := elem.html(); value != "" {
+					return value
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// pickAtomLink returns the href of the first link with the relation, rel defaults to alternate
+func pickAtomLink(links []atomParseLink, rel string) string {
+	for _, link := range links {
+		linkRel := link.Rel
+		if linkRel == "" {
+			linkRel = "alternate"
+		}
+		if linkRel == rel && link.Href != "" {
+			return strings.TrimSpace(link.Href)
+		}
+	}
+	return ""
+}
+
+// parseAtom parses Atom 1.0 and 0.3 documents
+func parseAtom(data []byte) (Feed, []feedItem, error) {
+
+	var doc atomParseFeed
+	if err := newXMLDecoder(data).Decode(&doc); err != nil {
+		return Feed{}, nil, err
+	}
+
+	feed := Feed{
+		Title:       HTMLToText(pickAtomText(doc.Titles)),
+		Description: HTMLToText(pickAtomText(doc.Subtitles)),
+		Link:        pickAtomLink(doc.Links, "alternate"),
+		FeedURL:     pickAtomLink(doc.Links, "self"),
+		Language:    doc.Lang,
+		Copyright:   doc.Rights,
+		Generator:   strings.TrimSpace(doc.Generator),
+		ImageURL:    strings.TrimSpace(doc.Logo),
+	}
+
+	if len(doc.Authors) > 0 {
+		feed.Author = strings.TrimSpace(doc.Authors[0].Name)
+	}
+
+	items := make([]feedItem, 0, len(doc.Entries))
+	for _, entry := range doc.Entries {
+		items = append(items, entry.item())
+	}
+
+	return feed, items, nil
+}
+
+func (entry atomParseEntry) item() feedItem {
+
+	item := feedItem{
+		title:     pickAtomText(entry.Titles),
+		link:      pickAtomLink(entry.Links, "alternate"),
+		summary:   pickAtomText(entry.Summaries),
+		markup:    pickAtomText(entry.Contents),
+		language:  entry.Lang,
+		published: feedTime(pickText(entry.Published, atomSpaces...), pickText(entry.Issued, atomSpaces...)),
+		modified:  feedTime(pickText(entry.Updated, atomSpaces...)),
+	}
+
+	if item.published.IsZero() {
+		item.published = item.modified
+	}
+
+	for _, author := range entry.Authors {
+		item.authors = append(item.authors, author.Name)
+	}
+
+	for _, category := range entry.Categories {
+		item.tags = append(item.tags, category.Term)
+	}
+
+	for _, link := range entry.Links {
+		if link.Rel == "enclosure" {
+			size, _ := strconv.Atoi(link.Length)
+			item.media = append(item.media, feedMedia{url: strings.TrimSpace(link.Href), typ: link.Type, title: link.Title, size: size})
+		}
+	}
+
+	for _, group := range entry.Groups {
+		if isMediaRSS(group.XMLName) {
+			item.media = append(item.media, rssMedia(group.Media, "")...)
+			item.media = append(item.media, rssMedia(group.Thumbnails, "image")...)
+		}
+	}
+
+	item.media = append(item.media, rssMedia(entry.Media, "image")...)
+
+	return item
+}
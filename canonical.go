@@ -0,0 +1,215 @@
+package article
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// Canonicalizer brings the http(s) URLs to the canonical form, so the same resource has the same URL:
+// the relative URL is resolved against the base, the scheme and host are lowercased,
+// the internationalized host is converted to punycode, the default port, fragment, tracking parameters
+// and trailing slash are removed, the query parameters are sorted.
+type Canonicalizer struct {
+	// StripParams are the query parameters removed from the URL, case-insensitive.
+	// The trailing asterisk matches the prefix, e.g. utm_*.
+	StripParams []string
+	// KeepTrailingSlash keeps the trailing slash of the path, e.g. /news/.
+	KeepTrailingSlash bool
+	// KeepFragment keeps the fragment, e.g. #section.
+	// The fragments starting with ! used by the single page applications are always kept.
+	KeepFragment bool
+	// Assets canonicalizes the stored URLs of the files too: Image.URL, Rendition.URL, Video.URL,
+	// Video.Thumbnail, Media.URL and PostMedia.URL. Off by default, so the signed CDN URLs keep
+	// the case and the query, only the relative URLs are resolved. The files are compared and
+	// identified by the canonical URL regardless, see ContentIDs.
+	Assets bool
+}
+
+// TrackingParams are the query parameters of the analytics and advertising platforms.
+var TrackingParams = []string{
+	"utm_*", "fbclid", "gclid", "gclsrc", "dclid", "gbraid", "wbraid", "msclkid", "yclid", "twclid", "ttclid",
+	"li_fat_id", "igshid", "mc_cid", "mc_eid", "_ga", "_gl", "_hsenc", "_hsmi", "mkt_tok", "s_cid",
+	"oly_anon_id", "oly_enc_id", "vero_id", "wickedid", "rb_clickid", "ref_src", "ref_url",
+}
+
+// DefaultCanonicalizer strips the TrackingParams.
+func DefaultCanonicalizer() *Canonicalizer {
+	return &Canonicalizer{
+		StripParams: append([]string(nil), TrackingParams...),
+	}
+}
+
+// URLCanonicalizer canonicalizes the URLs during Normalize:
+// Article.SourceURL, Quote.SourceURL and Social.URL, and the URLs of the files if Canonicalizer.Assets is set.
+// Set to nil to keep the URLs as is.
+var URLCanonicalizer = DefaultCanonicalizer()
+
+// Canonical returns the canonical form of the URL, the relative URL is resolved against the base URL.
+// Protocol-relative URLs get the https scheme. Non-http(s) and invalid URLs are returned trimmed.
+func (c *Canonicalizer) Canonical(raw, base string) string {
+
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+
+	if b, err := url.Parse(strings.TrimSpace(base)); err == nil && b.IsAbs() && !u.IsAbs() {
+		u = b.ResolveReference(u)
+	}
+
+	if u.Scheme == "" && strings.HasPrefix(raw, "//") {
+		u.Scheme = "https"
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return raw
+	}
+
+	host, err := canonicalHost(u.Hostname())
+	if err != nil {
+		return raw
+	}
+
+	if port := u.Port(); port != "" && !(u.Scheme == "http" && port == "80") && !(u.Scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+
+	u.Host = host
+	u.RawQuery = c.query(u.Query())
+
+	if !c.KeepFragment && !strings.HasPrefix(u.Fragment, "!") {
+		u.Fragment = ""
+		u.RawFragment = ""
+	}
+
+	if u.Path == "" {
+		u.Path = "/"
+	}
+
+	if !c.KeepTrailingSlash && u.Path != "/" {
+		u.Path = strings.TrimRight(u.Path, "/")
+		u.RawPath = strings.TrimRight(u.RawPath, "/")
+	}
+
+	// the empty query and fragment markers are dropped by String
+	u.ForceQuery = false
+
+	return u.String()
+}
+
+// canonicalHost lowercases the host and converts the internationalized domain to punycode, e.g. xn--e1afmkfd.xn--p1ai
+func canonicalHost(host string) (string, error) {
+
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+
+	// IPv6 address
+	if strings.Contains(host, ":") {
+		return "[" + host + "]", nil
+	}
+
+	return idna.Lookup.ToASCII(host)
+}
+
+// query removes the stripped parameters and sorts the rest by the key, the order of values is kept
+func (c *Canonicalizer) query(values url.Values) string {
+
+	for key := range values {
+		if c.stripped(key) {
+			delete(values, key)
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		for _, value := range values[key] {
+			if b.Len() > 0 {
+				b.WriteByte('&')
+			}
+			b.WriteString(url.QueryEscape(key))
+			if value != "" {
+				b.WriteByte('=')
+				b.WriteString(url.QueryEscape(value))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// stripped is true if the parameter matches the StripParams
+func (c *Canonicalizer) stripped(key string) bool {
+
+	key = strings.ToLower(key)
+
+	for _, param := range c.StripParams {
+		param = strings.ToLower(param)
+		if prefix, ok := strings.CutSuffix(param, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == param {
+			return true
+		}
+	}
+
+	return false
+}
+
+// canonical canonicalizes the URL against the base and records the change to the report.
+// The nil canonicalizer keeps the URL as is.
+func (c *Canonicalizer) canonical(report *NormalizeReport, field, raw, base string) string {
+
+	if c == nil || raw == "" {
+		return raw
+	}
+
+	canonical := c.Canonical(raw, base)
+	if canonical != raw {
+		report.Add(field, "canonical", raw, ActionCanonicalized)
+	}
+
+	return canonical
+}
+
+// asset canonicalizes the URL of the file if the Assets is set, otherwise only resolves the relative URL
+// against the base, and records the change to the report. The nil canonicalizer keeps the URL as is.
+func (c *Canonicalizer) asset(report *NormalizeReport, field, raw, base string) string {
+
+	if c == nil || raw == "" {
+		return raw
+	}
+
+	if c.Assets {
+		return c.canonical(report, field, raw, base)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || u.IsAbs() {
+		return raw
+	}
+
+	b, err := url.Parse(base)
+	if err != nil || !b.IsAbs() {
+		return raw
+	}
+
+	resolved := b.ResolveReference(u).String()
+	report.Add(field, "canonical", raw, ActionCanonicalized)
+
+	return resolved
+}
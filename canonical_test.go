@@ -0,0 +1,138 @@
+package article_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+func TestCanonicalizer_Canonical(t *testing.T) {
+
+	c := article.DefaultCanonicalizer()
+
+	tests := []struct {
+		name string
+		raw  string
+		base string
+		want string
+	}{
+		{"unchanged", "https://example.com/news/1", "", "https://example.com/news/1"},
+		{"scheme and host case", "HTTPS://Example.COM/News", "", "https://example.com/News"},
+		{"default port", "http://example.com:80/a", "", "http://example.com/a"},
+		{"custom port", "https://example.com:8443/a", "", "https://example.com:8443/a"},
+		{"fragment", "https://example.com/a#comments", "", "https://example.com/a"},
+		{"hashbang", "https://example.com/#!/news/1", "", "https://example.com/#!/news/1"},
+		{"trailing slash", "https://example.com/news/", "", "https://example.com/news"},
+		{"root", "https://example.com", "", "https://example.com/"},
+		{"tracking", "https://example.com/a?utm_source=x&UTM_Medium=y&fbclid=1&id=5&gclid=2", "", "https://example.com/a?id=5"},
+		{"sorted query", "https://example.com/a?b=2&a=1&a=0", "", "https://example.com/a?a=1&a=0&b=2"},
+		{"empty query", "https://example.com/a?", "", "https://example.com/a"},
+		{"idn", "https://пример.рф/новости", "", "https://xn--e1afmkfd.xn--p1ai/%D0%BD%D0%BE%D0%B2%D0%BE%D1%81%D1%82%D0%B8"},
+		{"relative", "../images/a.jpg", "https://example.com/news/1/", "https://example.com/news/images/a.jpg"},
+		{"root relative", "/a.jpg", "https://example.com/news/1", "https://example.com/a.jpg"},
+		{"protocol relative", "//cdn.example.com/a.jpg", "", "https://cdn.example.com/a.jpg"},
+		{"relative without base", "a.jpg", "", "a.jpg"},
+		{"mailto", "mailto:john@example.com", "", "mailto:john@example.com"},
+		{"ipv6", "http://[::1]:80/a", "", "http://[::1]/a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, c.Canonical(tt.raw, tt.base))
+		})
+	}
+}
+
+func TestCanonicalizer_Options(t *testing.T) {
+
+	c := &article.Canonicalizer{StripParams: []string{"ref"}, KeepTrailingSlash: true, KeepFragment: true}
+	assert.Equal(t, "https://example.com/news/?utm_source=x#top", c.Canonical("https://example.com/news/?ref=home&utm_source=x#top", ""))
+}
+
+func TestArticle_NormalizeCanonicalURLs(t *testing.T) {
+
+	a := article.NewArticle()
+	a.Title = "Title"
+	a.Markup = "<p>Text</p>"
+	a.Text = "Text"
+	a.SourceURL = "HTTPS://Example.com/news/1/?utm_source=rss#top"
+
+	img := article.NewImage("https://example.com/a.jpg")
+	a.Images.Add(img)
+	img.URL = "/images/a.jpg?utm_campaign=x"
+
+	quote := article.NewQuote("To be or not to be")
+	quote.SourceURL = "https://example.org/speech?fbclid=1"
+	a.Quotes.Add(quote)
+
+	a.Socials.Add(article.NewSocial("Twitter", "https://Twitter.com/example/"))
+
+	report, err := a.NormalizeWithReport()
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://example.com/news/1", a.SourceURL)
+	require.Equal(t, 1, a.Images.Len())
+	assert.Equal(t, "https://example.com/images/a.jpg?utm_campaign=x", a.Images.Slice()[0].URL, "the relative file URL is resolved only")
+	assert.Equal(t, "https://example.org/speech", a.Quotes.Slice()[0].SourceURL)
+	assert.Equal(t, "https://x.com/example", a.Socials.Slice()[0].URL)
+	assert.Equal(t, "x", a.Socials.Slice()[0].Platform)
+	// the platform and the profile URL of the social are canonicalized too
+	assert.Len(t, report.Filter(article.ActionCanonicalized), 6)
+
+	// standalone item, the signed URL of the file is kept
+	signed := "https://CDN.example.com/v.mp4?X-Amz-Signature=AbC&X-Amz-Expires=300&utm_source=x"
+	video := article.NewVideo(signed)
+	video.Normalize()
+	assert.Equal(t, signed, video.URL)
+}
+
+func TestArticle_NormalizeCanonicalAssets(t *testing.T) {
+
+	defer func(c *article.Canonicalizer) { article.URLCanonicalizer = c }(article.URLCanonicalizer)
+	article.URLCanonicalizer = article.DefaultCanonicalizer()
+	article.URLCanonicalizer.Assets = true
+
+	a := article.NewArticle()
+	a.Title = "Title"
+	a.Markup = "<p>Text</p>"
+	a.Text = "Text"
+	a.SourceURL = "https://example.com/news/1"
+
+	img := article.NewImage("https://example.com/a.jpg")
+	a.Images.Add(img)
+	img.URL = "/images/a.jpg?utm_campaign=x"
+	img.Renditions = []*article.Rendition{{URL: "/images/a-320.jpg", Width: 320}}
+	a.Medias.Add(article.NewMedia("https://Example.com/a.mp3#t=10"))
+
+	report, err := a.NormalizeWithReport()
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/images/a.jpg", a.Images.Slice()[0].URL)
+	assert.Equal(t, "https://example.com/a.mp3", a.Medias.Slice()[0].URL)
+	assert.Equal(t, "https://example.com/images/a-320.jpg", a.Images.Slice()[0].Renditions[0].URL)
+
+	// the item URL is canonicalized once
+	assert.Len(t, report.Field("Article.Images[0].URL"), 1)
+	assert.Len(t, report.Field("Article.Medias[0].URL"), 1)
+
+	video := article.NewVideo("https://Example.com/v.mp4?gclid=1")
+	video.Normalize()
+	assert.Equal(t, "https://example.com/v.mp4", video.URL)
+}
+
+func TestArticle_NormalizeCanonicalDisabled(t *testing.T) {
+
+	defer func(c *article.Canonicalizer) { article.URLCanonicalizer = c }(article.URLCanonicalizer)
+	article.URLCanonicalizer = nil
+
+	a := article.NewArticle()
+	a.Title = "Title"
+	a.Markup = "<p>Text</p>"
+	a.Text = "Text"
+	a.SourceURL = "https://example.com/news/?utm_source=rss"
+
+	require.NoError(t, a.Normalize())
+	assert.Equal(t, "https://example.com/news/?utm_source=rss", a.SourceURL)
+}
@@ -0,0 +1,229 @@
+package article
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+// Identifiable is an item with the unique ID.
+type Identifiable interface {
+	GetID() string
+}
+
+// Normalizable is an item normalized with the report.
+// The method is unexported, so only the items of this package are Normalizable.
+type Normalizable interface {
+	normalize(report *NormalizeReport, path string) error
+}
+
+// Item is the constraint of the Collection items: Image, Video, Media, Quote, Social and Person.
+type Item interface {
+	Identifiable
+	Normalizable
+	Map() map[string]any
+}
+
+// Collection is the ordered list of items with the unique IDs.
+// Items are validated when added: NewCollection and Add skip and log invalid items,
+// NewCollectionStrict fails on the first invalid item.
+// The zero value is an empty collection ready to use.
+type Collection[T Item] struct {
+	items []T
+}
+
+// NewCollection creates a collection, skips invalid items, and logs errors
+func NewCollection[T Item](items ...T) *Collection[T] {
+	list := &Collection[T]{}
+	list.Add(items...)
+	return list
+}
+
+// NewCollectionStrict creates a collection and validates every item
+func NewCollectionStrict[T Item](items ...T) (*Collection[T], error) {
+
+	var valid []T
+
+	for _, item := range items {
+		if err := validate.Struct(item); err != nil {
+			return nil, err
+		}
+		valid = append(valid, item)
+	}
+
+	return &Collection[T]{items: valid}, nil
+}
+
+// Get returns the item by ID
+func (list *Collection[T]) Get(id string) (T, bool) {
+	for _, item := range list.items {
+		if item.GetID() == id {
+			return item, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Slice returns a slice of all items
+func (list *Collection[T]) Slice() []T {
+	return list.items
+}
+
+// Add adds valid items to the collection, invalid items are skipped and logged
+func (list *Collection[T]) Add(items ...T) *Collection[T] {
+	return list.addWithLimits(nil, items...)
+}
+
+// addWithLimits adds the items valid with the limits, DefaultLimits if nil, invalid items are skipped and logged
+func (list *Collection[T]) addWithLimits(limits *Limits, items ...T) *Collection[T] {
+	for _, item := range items {
+		if err := validateWithLimits(item, limits); err != nil {
+			slog.Debug("Invalid item skipped", slog.String("type", fmt.Sprintf("%T", item)), slog.String("error", err.Error()))
+			continue
+		}
+		list.items = append(list.items, item)
+	}
+	return list
+}
+
+// Remove removes items by ID
+func (list *Collection[T]) Remove(ids ...string) *Collection[T] {
+
+	if len(ids) == 0 {
+		return list
+	}
+
+	idSet := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		idSet[id] = struct{}{}
+	}
+
+	var filtered []T
+	for _, item := range list.items {
+		if _, found := idSet[item.GetID()]; !found {
+			filtered = append(filtered, item)
+		}
+	}
+
+	list.items = filtered
+	return list
+}
+
+// IDs returns a slice of all item IDs
+func (list *Collection[T]) IDs() []string {
+	ids := make([]string, len(list.items))
+	for idx, item := range list.items {
+		ids[idx] = item.GetID()
+	}
+	return ids
+}
+
+// Len returns the number of items
+func (list *Collection[T]) Len() int {
+	return len(list.items)
+}
+
+// Filter returns a new collection of the items matching all the provided functions
+func (list *Collection[T]) Filter(fns ...func(T) bool) *Collection[T] {
+
+	var filtered []T
+
+	for _, item := range list.items {
+		include := true
+		for _, fn := range fns {
+			if !fn(item) {
+				include = false
+				break
+			}
+		}
+		if include {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return &Collection[T]{items: filtered}
+}
+
+// Sort sorts the items in place with the comparison function, the order of equal items is kept.
+// The cmp returns a negative number if a < b, zero if a == b and a positive number if a > b, see slices.SortStableFunc.
+func (list *Collection[T]) Sort(cmp func(a, b T) int) *Collection[T] {
+	slices.SortStableFunc(list.items, cmp)
+	return list
+}
+
+// Dedupe removes the items with the duplicate ID, the first item is kept
+func (list *Collection[T]) Dedupe() *Collection[T] {
+	return list.DedupeFunc(func(item T) string {
+		return item.GetID()
+	})
+}
+
+// DedupeFunc removes the items with the duplicate key, the first item is kept.
+// Items with the empty key are never removed.
+func (list *Collection[T]) DedupeFunc(key func(T) string) *Collection[T] {
+
+	seen := make(map[string]struct{}, len(list.items))
+
+	var unique []T
+	for _, item := range list.items {
+		k := key(item)
+		if _, found := seen[k]; found && k != "" {
+			continue
+		}
+		seen[k] = struct{}{}
+		unique = append(unique, item)
+	}
+
+	list.items = unique
+	return list
+}
+
+// Maps converts the items to a slice of map[string]any, see Map of the item
+func (list *Collection[T]) Maps() []map[string]any {
+	maps := make([]map[string]any, len(list.items))
+	for idx, item := range list.items {
+		maps[idx] = item.Map()
+	}
+	return maps
+}
+
+// Normalize validates and trims the fields of all items, invalid items are removed
+func (list *Collection[T]) Normalize() {
+	list.normalize(nil, "")
+}
+
+// normalize removes invalid items and records the changes to the report
+func (list *Collection[T]) normalize(report *NormalizeReport, path string) {
+
+	var valid []T
+
+	for idx, item := range list.items {
+		if err := item.normalize(report, itemPath(path, idx)); err != nil {
+			report.invalid(itemPath(path, idx), err, ActionRemoved)
+			continue
+		}
+		valid = append(valid, item)
+	}
+
+	list.items = valid
+}
+
+// UnmarshalJSON to array of items using encoding/json, invalid items are skipped
+func (list *Collection[T]) UnmarshalJSON(data []byte) error {
+
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	*list = *NewCollection(items...)
+
+	return nil
+}
+
+// MarshalJSON from array of items using encoding/json
+func (list *Collection[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(list.items)
+}
@@ -0,0 +1,123 @@
+package article_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/editorpost/article"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollection_Add(t *testing.T) {
+
+	// every collection validates the added items the same way
+	assert.Equal(t, 1, article.NewImages().Add(NewImageValid(), NewImageInvalid(), nil).Len())
+	assert.Equal(t, 1, article.NewMedias().Add(article.NewMedia(gofakeit.URL()), article.NewMedia("invalid-url")).Len())
+	assert.Equal(t, 1, article.NewSocials().Add(article.NewSocial("Twitter", gofakeit.URL()), &article.Social{}).Len())
+
+	var list article.Collection[*article.Image]
+	list.Add(NewImageValid())
+	assert.Equal(t, 1, list.Len())
+}
+
+func TestCollection_Remove(t *testing.T) {
+
+	img1, img2 := NewImageValid(), NewImageValid()
+	list := article.NewCollection(img1, img2)
+
+	assert.Equal(t, 2, list.Remove().Len())
+	assert.Equal(t, []string{img2.ID}, list.Remove(img1.ID).IDs())
+}
+
+func TestCollection_Sort(t *testing.T) {
+
+	list := article.NewCollection(
+		&article.Image{ID: "1", URL: "https://example.com/b.jpg", Width: 200},
+		&article.Image{ID: "2", URL: "https://example.com/a.jpg", Width: 100},
+		&article.Image{ID: "3", URL: "https://example.com/c.jpg", Width: 200},
+	)
+
+	list.Sort(func(a, b *article.Image) int {
+		return a.Width - b.Width
+	})
+	assert.Equal(t, []string{"2", "1", "3"}, list.IDs())
+
+	list.Sort(func(a, b *article.Image) int {
+		return strings.Compare(a.URL, b.URL)
+	})
+	assert.Equal(t, []string{"2", "1", "3"}, list.IDs())
+}
+
+func TestCollection_Dedupe(t *testing.T) {
+
+	list := article.NewCollection(
+		&article.Image{ID: "1", URL: "https://example.com/a.jpg"},
+		&article.Image{ID: "1", URL: "https://example.com/b.jpg"},
+		&article.Image{ID: "2", URL: "https://example.com/a.jpg"},
+		&article.Image{ID: "3", URL: "https://example.com/c.jpg"},
+	)
+
+	list.Dedupe()
+	assert.Equal(t, []string{"1", "2", "3"}, list.IDs())
+
+	list.DedupeFunc(func(img *article.Image) string {
+		return img.URL
+	})
+	assert.Equal(t, []string{"1", "3"}, list.IDs())
+}
+
+func TestImages_Chain(t *testing.T) {
+
+	list := article.NewImages(
+		&article.Image{ID: "1", URL: "https://example.com/b.jpg", Width: 200},
+		&article.Image{ID: "2", URL: "https://example.com/a.jpg", Width: 100},
+		&article.Image{ID: "2", URL: "https://example.com/c.jpg", Width: 300},
+	)
+
+	// the wrapper methods return the wrapper, e.g. Images.ReplaceURLs after Sort
+	failed := list.Dedupe().Sort(func(a, b *article.Image) int {
+		return a.Width - b.Width
+	}).ReplaceURLs(map[string]string{"https://example.com/a.jpg": "https://cdn.example.com/a.jpg"})
+
+	assert.Equal(t, []string{"2", "1"}, list.IDs())
+	assert.Equal(t, []string{"1"}, failed)
+}
+
+func TestCollection_Maps(t *testing.T) {
+
+	img := NewImageValid()
+	maps := article.NewImages(img).Maps()
+
+	require.Len(t, maps, 1)
+	assert.Equal(t, img.Map(), maps[0])
+	assert.Empty(t, article.NewImages().Maps())
+}
+
+func TestCollection_JSON(t *testing.T) {
+
+	img := NewImageValid()
+
+	data, err := json.Marshal(article.NewImages(img))
+	require.NoError(t, err)
+
+	// invalid items are skipped
+	data = []byte(strings.Replace(string(data), "]", `,{"id":"x","url":"invalid-url"}]`, 1))
+
+	images := article.NewImages()
+	require.NoError(t, json.Unmarshal(data, images))
+	require.Equal(t, 1, images.Len())
+	assert.Equal(t, img, images.Slice()[0])
+}
+
+func TestNewCollectionStrict(t *testing.T) {
+
+	_, err := article.NewCollectionStrict(NewImageValid(), NewImageInvalid())
+	assert.Error(t, err)
+
+	list, err := article.NewCollectionStrict(NewImageValid())
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Len())
+}
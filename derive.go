@@ -0,0 +1,592 @@
+package article
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Derive fills the Article from its Markup, so the sources providing only HTML need no extra processing:
+//   - empty Text is generated from the Markup preserving paragraph breaks, see HTMLToText;
+//   - empty Summary becomes the excerpt of the Text ending at a sentence boundary, see Excerpt;
+//   - <img> and <figure> with <figcaption> are added to the Images, the caption becomes the Title;
+//   - <iframe> and <video> are added to the Videos, the iframe code becomes the Embed;
+//   - <blockquote> is added to the Quotes, the <cite> or <footer> becomes the Author;
+//   - embedded social posts are added to the Quotes with the post fields and the Embed, see Quote.Detect:
+//     blockquote.twitter-tweet, blockquote.instagram-media, blockquote.mastodon-embed,
+//     the Telegram widget script and the iframes of X and Telegram posts.
+//
+// Relative URLs are resolved against the SourceURL, items already present (by URL or text) are skipped.
+// Call Derive before Normalize, which validates the derived fields.
+func (a *Article) Derive() {
+
+	if a.Text == "" {
+		a.Text = HTMLToText(a.Markup)
+	}
+
+	if a.Summary == "" {
+		a.Summary = Excerpt(a.Text, DefaultLimits.resolved().Summary)
+	}
+
+	if a.Markup == "" {
+		return
+	}
+
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+
+	nodes, err := html.ParseFragment(strings.NewReader(a.Markup), body)
+	if err != nil {
+		return
+	}
+
+	d := &deriver{article: a, limits: DefaultLimits.resolved(), seen: map[string]bool{}}
+	d.init()
+
+	for _, node := range nodes {
+		d.walk(node)
+	}
+}
+
+// deriver harvests the media of the Article markup
+type deriver struct {
+	article *Article
+	base    *url.URL
+	// limits of the derived fields, see DefaultLimits
+	limits Limits
+	// seen are the URLs of the media, the texts of the quotes and the platform posts already in the Article
+	seen map[string]bool
+}
+
+func (d *deriver) init() {
+
+	a := d.article
+
+	if a.SourceURL != "" {
+		d.base, _ = url.Parse(a.SourceURL)
+	}
+
+	if a.Images == nil {
+		a.Images = NewImages()
+	}
+	if a.Videos == nil {
+		a.Videos = NewVideos()
+	}
+	if a.Quotes == nil {
+		a.Quotes = NewQuotes()
+	}
+
+	for _, img := range a.Images.Slice() {
+		d.seen[img.URL] = true
+	}
+	for _, video := range a.Videos.Slice() {
+		d.seen[video.URL] = true
+	}
+	for _, quote := range a.Quotes.Slice() {
+		d.seen[quote.Text] = true
+		if quote.PostID != "" {
+			d.seen[quote.Platform+"\n"+quote.PostID] = true
+		}
+	}
+}
+
+func (d *deriver) walk(n *html.Node) {
+
+	if n.Type == html.ElementNode {
+		switch n.DataAtom {
+		case atom.Img:
+			d.image(n, "")
+			return
+		case atom.Figure:
+			d.figure(n)
+			return
+		case atom.Iframe:
+			if !d.post(n) {
+				d.iframe(n)
+			}
+			return
+		case atom.Video:
+			d.video(n)
+			return
+		case atom.Blockquote:
+			if !d.post(n) {
+				d.blockquote(n)
+			}
+			return
+		case atom.Script:
+			d.post(n)
+			return
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		d.walk(c)
+	}
+}
+
+// figure adds the images of the figure with the caption as the title
+func (d *deriver) figure(n *html.Node) {
+
+	caption := ""
+	if figcaption := findNode(n, atom.Figcaption); figcaption != nil {
+		caption = nodeText(figcaption)
+	}
+
+	for _, img := range findNodes(n, atom.Img) {
+		d.image(img, caption)
+	}
+
+	for _, iframe := range findNodes(n, atom.Iframe) {
+		d.iframe(iframe)
+	}
+
+	for _, video := range findNodes(n, atom.Video) {
+		d.video(video)
+	}
+}
+
+func (d *deriver) image(n *html.Node, caption string) {
+
+	src := attr(n, "src")
+
+	// lazy loading placeholders keep the real source in data-src or srcset
+	if src == "" || strings.HasPrefix(src, "data:") {
+		src = attr(n, "data-src")
+	}
+	if src == "" || strings.HasPrefix(src, "data:") {
+		if fields := strings.Fields(strings.Split(attr(n, "srcset"), ",")[0]); len(fields) > 0 {
+			src = fields[0]
+		}
+	}
+
+	src = d.resolve(src)
+	if src == "" || d.seen[src] {
+		return
+	}
+	d.seen[src] = true
+
+	img := NewImage(src)
+	img.Alt = TrimToMaxLen(attr(n, "alt"), d.limits.Name)
+	img.Title = TrimToMaxLen(attr(n, "title"), d.limits.Caption)
+	img.Width, _ = strconv.Atoi(attr(n, "width"))
+	img.Height, _ = strconv.Atoi(attr(n, "height"))
+
+	if caption != "" {
+		img.Title = TrimToMaxLen(caption, d.limits.Caption)
+	}
+
+	img.Renditions = d.renditions(attr(n, "srcset"), src)
+
+	d.article.Images.Add(img)
+}
+
+// renditions converts the srcset candidates with the width descriptor, e.g. "a-320.jpg 320w", to the renditions,
+// the candidate of the src is skipped
+func (d *deriver) renditions(srcset, src string) []*Rendition {
+
+	var renditions []*Rendition
+
+	for _, candidate := range strings.Split(srcset, ",") {
+		fields := strings.Fields(candidate)
+		if len(fields) != 2 || !strings.HasSuffix(fields[1], "w") {
+			continue
+		}
+
+		width, err := strconv.Atoi(strings.TrimSuffix(fields[1], "w"))
+		u := d.resolve(fields[0])
+		if err != nil || width <= 0 || u == "" || u == src {
+			continue
+		}
+
+		renditions = append(renditions, &Rendition{URL: u, Width: width})
+	}
+
+	return renditions
+}
+
+func (d *deriver) iframe(n *html.Node) {
+
+	src := d.resolve(attr(n, "src"))
+	if src == "" || d.seen[src] {
+		return
+	}
+	d.seen[src] = true
+
+	video := NewVideo(src)
+	video.Title = TrimToMaxLen(attr(n, "title"), d.limits.Caption)
+	video.Embed = renderNode(n)
+
+	d.article.Videos.Add(video)
+}
+
+func (d *deriver) video(n *html.Node) {
+
+	src := attr(n, "src")
+	if src == "" {
+		if source := findNode(n, atom.Source); source != nil {
+			src = attr(source, "src")
+		}
+	}
+
+	src = d.resolve(src)
+	if src == "" || d.seen[src] {
+		return
+	}
+	d.seen[src] = true
+
+	video := NewVideo(src)
+	video.Title = TrimToMaxLen(attr(n, "title"), d.limits.Caption)
+
+	d.article.Videos.Add(video)
+}
+
+// blockquote adds the quote, the source is the cite attribute, the first link or the article itself
+func (d *deriver) blockquote(n *html.Node) {
+
+	author := ""
+	if cite := findNode(n, atom.Cite); cite != nil {
+		author = nodeText(cite)
+	} else if footer := findNode(n, atom.Footer); footer != nil {
+		author = nodeText(footer)
+	}
+	author = strings.TrimLeft(author, "—–- ")
+
+	text := strings.TrimSpace(strings.TrimSuffix(nodeText(n), author))
+	text = strings.TrimRight(text, "—–- \n")
+	if text == "" || d.seen[text] {
+		return
+	}
+	d.seen[text] = true
+
+	source := d.resolve(attr(n, "cite"))
+	if source == "" {
+		if link := findNode(n, atom.A); link != nil {
+			source = d.resolve(attr(link, "href"))
+		}
+	}
+	if source == "" {
+		source = d.article.SourceURL
+	}
+
+	quote := NewQuote(text)
+	quote.Author = TrimToMaxLen(author, d.limits.Name)
+	quote.SourceURL = source
+
+	d.article.Quotes.Add(quote)
+}
+
+// postClasses are the classes of the blockquotes rendered by the social widget scripts
+var postClasses = []string{"twitter-tweet", "twitter-video", "instagram-media", "mastodon-embed"}
+
+// postCredit matches the author of the post embed, e.g. "— Jack (@jack)" or "A post shared by NatGeo (@natgeo)"
+var postCredit = regexp.MustCompile(`(?:[—–]|A post shared by)\s*([^—–\n(]*?)\s*\(@([A-Za-z0-9_.]{1,30})\)`)
+
+// post adds the quote of the embedded social post, returns false if the node is not a post embed
+func (d *deriver) post(n *html.Node) bool {
+
+	var source string
+	switch n.DataAtom {
+	case atom.Blockquote:
+		if !hasClass(n, postClasses...) {
+			return false
+		}
+		source = d.postLink(n)
+	case atom.Script:
+		// Telegram widget, e.g. data-telegram-post="durov/43"
+		if post := attr(n, "data-telegram-post"); post != "" {
+			source = "https://t.me/" + strings.Trim(post, "/")
+		}
+	case atom.Iframe:
+		source = d.resolve(attr(n, "src"))
+		if u, err := url.Parse(source); err == nil && strings.HasSuffix(u.Path, "/embed/Tweet.html") {
+			// X widget iframe, e.g. platform.twitter.com/embed/Tweet.html?id=20
+			source = "https://x.com/i/status/" + u.Query().Get("id")
+		} else if err == nil {
+			u.RawQuery = ""
+			source = u.String()
+		}
+	}
+
+	platform, handle, id := DetectPost(source)
+	if id == "" {
+		return false
+	}
+
+	if d.seen[platform+"\n"+id] {
+		return true
+	}
+	d.seen[platform+"\n"+id] = true
+
+	quote := NewQuote("")
+	quote.SourceURL = source
+	quote.Platform, quote.Handle, quote.PostID = platform, handle, id
+
+	if n.DataAtom == atom.Blockquote {
+		d.postContent(quote, n)
+	}
+
+	// the widget script is replaced with the post embed, the markup of the others is kept
+	if n.DataAtom == atom.Script {
+		quote.Embed = quote.EmbedHTML()
+	} else {
+		quote.Embed = renderNode(n)
+	}
+
+	d.article.Quotes.Add(quote)
+
+	return true
+}
+
+// postLink returns the URL of the blockquote post: the permalink attributes or the last link to the post
+func (d *deriver) postLink(n *html.Node) string {
+
+	for _, key := range []string{"data-instgrm-permalink", "data-embed-url", "cite"} {
+		if link := d.resolve(attr(n, key)); link != "" {
+			if _, _, id := DetectPost(link); id != "" {
+				return strings.TrimSuffix(link, "/embed")
+			}
+		}
+	}
+
+	links := findNodes(n, atom.A)
+	for idx := len(links) - 1; idx >= 0; idx-- {
+		link := d.resolve(attr(links[idx], "href"))
+		if _, _, id := DetectPost(link); id != "" {
+			return link
+		}
+	}
+
+	return ""
+}
+
+// postContent fills the text, the author, the posted time and the media of the post blockquote
+func (d *deriver) postContent(quote *Quote, n *html.Node) {
+
+	// the first paragraph is the post text, the credit and the widget captions are not
+	for _, p := range findNodes(n, atom.P) {
+		text := nodeText(p)
+		if text == "" || postCredit.MatchString(text) || strings.HasPrefix(text, "View this post on") {
+			continue
+		}
+		quote.Text = TrimToMaxLen(text, d.limits.Text)
+		break
+	}
+
+	if m := postCredit.FindStringSubmatch(nodeText(n)); m != nil {
+		quote.Author = TrimToMaxLen(strings.TrimSpace(m[1]), d.limits.Name)
+		if quote.Handle == "" {
+			quote.Handle = m[2]
+		}
+	}
+
+	if t := findNode(n, atom.Time); t != nil {
+		if posted, err := ParseTime(attr(t, "datetime")); err == nil {
+			quote.Posted = posted
+		}
+	}
+	if quote.Posted.IsZero() && quote.Platform == PlatformX {
+		// the link text of the tweet is the date, e.g. March 21, 2006
+		links := findNodes(n, atom.A)
+		if len(links) > 0 {
+			if posted, err := time.Parse("January 2, 2006", nodeText(links[len(links)-1])); err == nil {
+				quote.Posted = posted
+			}
+		}
+	}
+
+	for _, link := range findNodes(n, atom.A) {
+		// the tweet photos are the t.co links with the pic.twitter.com text
+		text := nodeText(link)
+		if strings.HasPrefix(text, "pic.twitter.com/") || strings.HasPrefix(text, "pic.x.com/") {
+			quote.Media = append(quote.Media, &PostMedia{URL: "https://" + text})
+		}
+	}
+	for _, img := range findNodes(n, atom.Img) {
+		if src := d.resolve(attr(img, "src")); src != "" {
+			quote.Media = append(quote.Media, &PostMedia{URL: src, Type: PostMediaImage})
+		}
+	}
+	for _, video := range findNodes(n, atom.Video) {
+		if src := d.resolve(attr(video, "src")); src != "" {
+			quote.Media = append(quote.Media, &PostMedia{URL: src, Type: PostMediaVideo})
+		}
+	}
+}
+
+// hasClass is true if the element has one of the classes
+func hasClass(n *html.Node, classes ...string) bool {
+	for _, class := range strings.Fields(attr(n, "class")) {
+		for _, c := range classes {
+			if class == c {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolve returns the absolute http(s) URL or empty string
+func (d *deriver) resolve(ref string) string {
+	return resolveURL(d.base, ref)
+}
+
+// resolveURL resolves the reference against the base URL,
+// returns empty string for the invalid, data: or javascript: URLs
+func resolveURL(base *url.URL, ref string) string {
+
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+
+	if base != nil {
+		u = base.ResolveReference(u)
+	} else if strings.HasPrefix(ref, "//") {
+		u.Scheme = "https"
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+
+	return u.String()
+}
+
+// attr returns the trimmed value of the attribute
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+	return ""
+}
+
+// findNode returns the first descendant element
+func findNode(n *html.Node, tag atom.Atom) *html.Node {
+	if nodes := findNodes(n, tag); len(nodes) > 0 {
+		return nodes[0]
+	}
+	return nil
+}
+
+// findNodes returns the descendant elements in document order
+func findNodes(n *html.Node, tag atom.Atom) []*html.Node {
+	var nodes []*html.Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.DataAtom == tag {
+			nodes = append(nodes, c)
+		}
+		nodes = append(nodes, findNodes(c, tag)...)
+	}
+	return nodes
+}
+
+// nodeText returns the plain text of the node
+func nodeText(n *html.Node) string {
+	return HTMLToText(renderNode(n))
+}
+
+// renderNode returns the HTML of the node
+func renderNode(n *html.Node) string {
+	var b strings.Builder
+	if err := html.Render(&b, n); err != nil {
+		return ""
+	}
+	return b.String()
+}
+
+// Excerpt returns the leading sentences of the text not longer than maxLen runes.
+// Whitespace and line breaks are collapsed. If the first sentence is too long,
+// it is cut at the word boundary and ends with an ellipsis.
+func Excerpt(text string, maxLen int) string {
+
+	text = strings.Join(strings.Fields(text), " ")
+	if maxLen <= 0 || text == "" {
+		return ""
+	}
+
+	if utf8.RuneCountInString(text) <= maxLen {
+		return text
+	}
+
+	excerpt := ""
+	for _, sentence := range sentences(text) {
+		candidate := strings.TrimSpace(excerpt + sentence)
+		if utf8.RuneCountInString(candidate) > maxLen {
+			break
+		}
+		excerpt = candidate + " "
+	}
+
+	if excerpt = strings.TrimSpace(excerpt); excerpt != "" {
+		return excerpt
+	}
+
+	// the first sentence is too long, cut it at the last space
+	runes := []rune(text)[:maxLen-1]
+	if idx := strings.LastIndexFunc(string(runes), unicode.IsSpace); idx > 0 {
+		runes = []rune(string(runes)[:idx])
+	}
+
+	return strings.TrimRightFunc(string(runes), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
+
+// sentenceEnds are the punctuation marks ending a sentence
+const sentenceEnds = ".!?…。！？"
+
+// sentenceClosers may follow the sentence end, e.g. closing quotes and brackets
+const sentenceClosers = `"'”’»)]`
+
+// sentences splits the text at the sentence boundaries, the separating space is kept with the sentence
+func sentences(text string) []string {
+
+	var result []string
+	runes := []rune(text)
+	start := 0
+
+	for i := 0; i < len(runes); i++ {
+
+		if !strings.ContainsRune(sentenceEnds, runes[i]) {
+			continue
+		}
+
+		end := i + 1
+		for end < len(runes) && (strings.ContainsRune(sentenceEnds, runes[end]) || strings.ContainsRune(sentenceClosers, runes[end])) {
+			end++
+		}
+
+		// CJK full stops end the sentence without space
+		cjk := strings.ContainsRune("。！？", runes[i])
+		if end < len(runes) && !cjk && !unicode.IsSpace(runes[end]) {
+			i = end - 1
+			continue
+		}
+
+		for end < len(runes) && unicode.IsSpace(runes[end]) {
+			end++
+		}
+
+		result = append(result, string(runes[start:end]))
+		start, i = end, end-1
+	}
+
+	if start < len(runes) {
+		result = append(result, string(runes[start:]))
+	}
+
+	return result
+}
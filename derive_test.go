@@ -0,0 +1,102 @@
+package article_test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/editorpost/article"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArticle_Derive(t *testing.T) {
+
+	a := article.NewArticle()
+	a.Title = "Title"
+	a.SourceURL = "https://example.com/news/article"
+	a.Markup = `
+<h1>Heading</h1>
+<p>First paragraph. Second sentence!</p>
+<figure>
+	<img src="/images/photo.jpg" alt="Photo" width="800" height="600">
+	<figcaption>The <b>caption</b></figcaption>
+</figure>
+<p><img src="data:image/gif;base64,R0lGOD" data-src="lazy.jpg" alt="Lazy"></p>
+<iframe src="https://www.youtube.com/embed/abc" title="Video"></iframe>
+<video><source src="https://example.com/clip.mp4" type="video/mp4"></video>
+<blockquote cite="https://example.org/speech"><p>To be or not to be.</p><footer>— William Shakespeare</footer></blockquote>
+<blockquote><p>Inline quote</p></blockquote>
+<img src="/images/photo.jpg" alt="Duplicate">`
+
+	a.Derive()
+
+	assert.Equal(t, "Heading\n\nFirst paragraph. Second sentence!", strings.SplitN(a.Text, "\n\nThe caption", 2)[0])
+	assert.True(t, strings.HasPrefix(a.Summary, "Heading First paragraph. Second sentence!"))
+
+	require.Equal(t, 2, a.Images.Len())
+	photo := a.Images.Slice()[0]
+	assert.Equal(t, "https://example.com/images/photo.jpg", photo.URL)
+	assert.Equal(t, "Photo", photo.Alt)
+	assert.Equal(t, "The caption", photo.Title)
+	assert.Equal(t, 800, photo.Width)
+	assert.Equal(t, 600, photo.Height)
+	assert.Equal(t, "https://example.com/news/lazy.jpg", a.Images.Slice()[1].URL)
+
+	require.Equal(t, 2, a.Videos.Len())
+	assert.Equal(t, "https://www.youtube.com/embed/abc", a.Videos.Slice()[0].URL)
+	assert.Equal(t, "Video", a.Videos.Slice()[0].Title)
+	assert.Contains(t, a.Videos.Slice()[0].Embed, "<iframe")
+	assert.Equal(t, "https://example.com/clip.mp4", a.Videos.Slice()[1].URL)
+
+	require.Equal(t, 2, a.Quotes.Len())
+	quote := a.Quotes.Slice()[0]
+	assert.Equal(t, "To be or not to be.", quote.Text)
+	assert.Equal(t, "William Shakespeare", quote.Author)
+	assert.Equal(t, "https://example.org/speech", quote.SourceURL)
+	assert.Equal(t, a.SourceURL, a.Quotes.Slice()[1].SourceURL)
+
+	require.NoError(t, a.Normalize())
+
+	// derive is idempotent
+	a.Derive()
+	assert.Equal(t, 2, a.Images.Len())
+	assert.Equal(t, 2, a.Videos.Len())
+	assert.Equal(t, 2, a.Quotes.Len())
+}
+
+func TestArticle_DeriveKeepsFields(t *testing.T) {
+
+	a := article.NewArticle()
+	a.Markup = "<p>Markup text.</p>"
+	a.Text = "Own text."
+	a.Summary = "Own summary."
+
+	a.Derive()
+
+	assert.Equal(t, "Own text.", a.Text)
+	assert.Equal(t, "Own summary.", a.Summary)
+}
+
+func TestExcerpt(t *testing.T) {
+
+	assert.Equal(t, "Short text", article.Excerpt("Short   text", 500))
+	assert.Equal(t, "", article.Excerpt("Text", 0))
+
+	text := "First sentence. Second sentence is longer! Third one?"
+	assert.Equal(t, "First sentence.", article.Excerpt(text, 30))
+	assert.Equal(t, "First sentence. Second sentence is longer!", article.Excerpt(text, 50))
+
+	// quotes and abbreviations inside words
+	assert.Equal(t, `He said "Stop." Then left.`, article.Excerpt(`He said "Stop." Then left. And more text here.`, 30))
+	assert.Equal(t, "Version 1.2 released.", article.Excerpt("Version 1.2 released. Other news follow later.", 30))
+
+	// CJK sentence ends without space
+	assert.Equal(t, "今日は晴れです。", article.Excerpt("今日は晴れです。明日は雨が降るでしょう。", 12))
+
+	// long sentence is cut at word boundary
+	long := strings.Repeat("word ", 200)
+	excerpt := article.Excerpt(long, 500)
+	assert.LessOrEqual(t, utf8.RuneCountInString(excerpt), 500)
+	assert.True(t, strings.HasSuffix(excerpt, "word…"))
+}
@@ -0,0 +1,455 @@
+package article
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ShingleSize is the number of the words of the shingles compared by SimHash and MinHash,
+// every CJK character is a word.
+var ShingleSize = 3
+
+// MinHashSize is the length of the MinHash signature, the error of the estimated similarity is about 1/sqrt(size).
+const MinHashSize = 128
+
+// minHashBands are the bands of the MinHash signature of the DuplicateIndex,
+// the articles sharing a band are compared, about 0.4 similarity has even odds to share one
+const minHashBands = 32
+
+// Fingerprint is the near-duplicate fingerprint of the Article.Text.
+type Fingerprint struct {
+	// SimHash is the 64-bit SimHash of the shingles, see SimHashDistance.
+	SimHash uint64 `json:"simhash"`
+	// MinHash is the MinHash signature of the shingles, see MinHashSimilarity.
+	MinHash []uint32 `json:"minhash"`
+}
+
+// Fingerprint returns the SimHash and the MinHash of the Text.
+func (a *Article) Fingerprint() *Fingerprint {
+	shingles := textShingles(a.Text)
+	return &Fingerprint{SimHash: simHash(shingles), MinHash: minHash(shingles)}
+}
+
+// Similarity returns the estimated Jaccard similarity of the shingles of the texts from 0 to 1, see MinHashSimilarity.
+func (f *Fingerprint) Similarity(other *Fingerprint) float64 {
+	return MinHashSimilarity(f.MinHash, other.MinHash)
+}
+
+// SimHash returns the 64-bit SimHash of the word shingles of the text: the similar texts have the close hashes,
+// see SimHashDistance. Returns 0 for the text without words.
+func SimHash(text string) uint64 {
+	return simHash(textShingles(text))
+}
+
+// SimHashDistance returns the Hamming distance of the SimHashes: up to 3 for the copy with small edits
+// of the long text and about 32 for the unrelated texts.
+func SimHashDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// MinHash returns the MinHash signature of the word shingles of the text of MinHashSize,
+// see MinHashSimilarity. Returns nil for the text without words.
+func MinHash(text string) []uint32 {
+	return minHash(textShingles(text))
+}
+
+// MinHashSimilarity returns the estimated Jaccard similarity of the shingles from 0 to 1:
+// the share of the equal values of the signatures, 0 for the signatures of different length or nil.
+func MinHashSimilarity(a, b []uint32) float64 {
+
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+
+	return float64(equal) / float64(len(a))
+}
+
+// Duplicate is the near-duplicate found by the DuplicateIndex.
+type Duplicate struct {
+	Article *Article `json:"article"`
+	// Similarity is the estimated Jaccard similarity of the shingles of the texts from 0 to 1.
+	Similarity float64 `json:"similarity"`
+}
+
+// DuplicateIndex finds the articles with the text similarity above the threshold,
+// the candidates sharing a band of the MinHash signature are compared, so the search does not scan the index.
+type DuplicateIndex struct {
+	threshold    float64
+	articles     []*Article
+	fingerprints []*Fingerprint
+	bands        map[uint64][]int
+}
+
+// NewDuplicateIndex returns the empty index of the similarity threshold from 0 to 1, e.g. 0.8 for the wire stories
+// with small edits. The threshold below 0.4 misses the part of the duplicates, see MinHashSimilarity.
+func NewDuplicateIndex(threshold float64) *DuplicateIndex {
+	return &DuplicateIndex{threshold: threshold, bands: map[uint64][]int{}}
+}
+
+// Add adds the articles to the index, the articles without the text are skipped.
+func (idx *DuplicateIndex) Add(articles ...*Article) *DuplicateIndex {
+
+	for _, a := range articles {
+		if a == nil {
+			continue
+		}
+		fp := a.Fingerprint()
+		if fp.MinHash == nil {
+			continue
+		}
+		pos := len(idx.articles)
+		idx.articles = append(idx.articles, a)
+		idx.fingerprints = append(idx.fingerprints, fp)
+		for _, band := range minHashBandKeys(fp.MinHash) {
+			idx.bands[band] = append(idx.bands[band], pos)
+		}
+	}
+
+	return idx
+}
+
+// Len returns the number of the indexed articles.
+func (idx *DuplicateIndex) Len() int {
+	return len(idx.articles)
+}
+
+// Find returns the indexed articles similar to the article, the most similar first, the article itself is skipped.
+func (idx *DuplicateIndex) Find(a *Article) []Duplicate {
+	return idx.find(a, a.Fingerprint())
+}
+
+func (idx *DuplicateIndex) find(a *Article, fp *Fingerprint) []Duplicate {
+
+	if fp.MinHash == nil {
+		return nil
+	}
+
+	seen := map[int]bool{}
+	var duplicates []Duplicate
+
+	for _, band := range minHashBandKeys(fp.MinHash) {
+		for _, pos := range idx.bands[band] {
+			if seen[pos] || idx.articles[pos] == a {
+				continue
+			}
+			seen[pos] = true
+			if similarity := fp.Similarity(idx.fingerprints[pos]); similarity >= idx.threshold {
+				duplicates = append(duplicates, Duplicate{Article: idx.articles[pos], Similarity: similarity})
+			}
+		}
+	}
+
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Similarity > duplicates[j].Similarity
+	})
+
+	return duplicates
+}
+
+// CanonicalPolicy chooses the canonical article of the cluster of the near-duplicates.
+type CanonicalPolicy struct {
+	// PreferredSources are the source names or the hosts of the SourceURL preferred in the order,
+	// e.g. Reuters or apnews.com, the subdomains match the host.
+	PreferredSources []string
+	// Longest prefers the longest Text over the earliest Published.
+	Longest bool
+}
+
+// DefaultCanonicalPolicy prefers the earliest Published, then the longest Text.
+var DefaultCanonicalPolicy = &CanonicalPolicy{}
+
+// Choose returns the canonical article: from the preferred source, then the earliest Published
+// and the longest Text (or the reverse if Longest), then the first one. Returns nil for no articles.
+func (p *CanonicalPolicy) Choose(articles []*Article) *Article {
+
+	var best *Article
+	for _, a := range articles {
+		if a != nil && (best == nil || p.less(a, best)) {
+			best = a
+		}
+	}
+
+	return best
+}
+
+// less is true if the article is preferred over the other one
+func (p *CanonicalPolicy) less(a, b *Article) bool {
+
+	if ra, rb := p.sourceRank(a), p.sourceRank(b); ra != rb {
+		return ra < rb
+	}
+
+	published := func() (bool, bool) {
+		switch {
+		case a.Published.Equal(b.Published):
+			return false, false
+		case a.Published.IsZero():
+			return false, true
+		case b.Published.IsZero():
+			return true, true
+		}
+		return a.Published.Before(b.Published), true
+	}
+
+	longer := func() (bool, bool) {
+		la, lb := utf8.RuneCountInString(a.Text), utf8.RuneCountInString(b.Text)
+		return la > lb, la != lb
+	}
+
+	rules := []func() (bool, bool){published, longer}
+	if p.Longest {
+		rules = []func() (bool, bool){longer, published}
+	}
+
+	for _, rule := range rules {
+		if less, decided := rule(); decided {
+			return less
+		}
+	}
+
+	return false
+}
+
+// sourceRank returns the position of the source of the article in the PreferredSources, the length if not found
+func (p *CanonicalPolicy) sourceRank(a *Article) int {
+
+	host := ""
+	if u, err := url.Parse(a.SourceURL); err == nil {
+		host = strings.ToLower(strings.TrimPrefix(u.Hostname(), "www."))
+	}
+
+	for rank, source := range p.PreferredSources {
+		source = strings.ToLower(strings.TrimPrefix(source, "www."))
+		if source == "" {
+			continue
+		}
+		if strings.EqualFold(a.SourceName, source) || host == source || strings.HasSuffix(host, "."+source) {
+			return rank
+		}
+	}
+
+	return len(p.PreferredSources)
+}
+
+// Cluster is the story group of the near-duplicate articles.
+type Cluster struct {
+	// Canonical is the article chosen by the CanonicalPolicy.
+	Canonical *Article `json:"canonical"`
+	// Articles are the articles of the cluster in the order of the collection, including the Canonical.
+	Articles []*Article `json:"articles"`
+}
+
+// Clusters groups the articles with the text similarity above the threshold into the stories,
+// the similarity is transitive, e.g. the updates of the story chained by the small edits are one cluster.
+// Every article is in one cluster, the clusters are in the order of their first article.
+// The policy chooses the canonical articles, DefaultCanonicalPolicy if nil.
+func (list *Articles) Clusters(threshold float64, policy *CanonicalPolicy) []*Cluster {
+
+	if policy == nil {
+		policy = DefaultCanonicalPolicy
+	}
+
+	// union-find of the positions of the articles
+	parent := make([]int, len(list.items))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var root func(int) int
+	root = func(i int) int {
+		if parent[i] != i {
+			parent[i] = root(parent[i])
+		}
+		return parent[i]
+	}
+
+	index := NewDuplicateIndex(threshold)
+	positions := map[*Article]int{}
+
+	for i, a := range list.items {
+		fp := a.Fingerprint()
+		for _, duplicate := range index.find(a, fp) {
+			if ri, rj := root(i), root(positions[duplicate.Article]); ri != rj {
+				// the earlier article is the root to keep the order
+				parent[max(ri, rj)] = min(ri, rj)
+			}
+		}
+		if _, found := positions[a]; !found {
+			positions[a] = i
+			index.Add(a)
+		}
+	}
+
+	groups := map[int]*Cluster{}
+	var clusters []*Cluster
+
+	for i, a := range list.items {
+		r := root(i)
+		if groups[r] == nil {
+			groups[r] = &Cluster{}
+			clusters = append(clusters, groups[r])
+		}
+		groups[r].Articles = append(groups[r].Articles, a)
+	}
+
+	for _, cluster := range clusters {
+		cluster.Canonical = policy.Choose(cluster.Articles)
+	}
+
+	return clusters
+}
+
+// DedupeNear returns the collection of the canonical articles of the Clusters.
+func (list *Articles) DedupeNear(threshold float64, policy *CanonicalPolicy) *Articles {
+
+	unique := NewArticles()
+	for _, cluster := range list.Clusters(threshold, policy) {
+		unique.Add(cluster.Canonical)
+	}
+
+	return unique
+}
+
+// textShingles returns the hashes of the unique shingles of ShingleSize words of the lowercase text,
+// the shorter text is one shingle
+func textShingles(text string) []uint64 {
+
+	var words []string
+	var word []rune
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+
+	for _, r := range norm.NFKC.String(strings.ToLower(text)) {
+		switch {
+		case isCJK(r):
+			flush()
+			words = append(words, string(r))
+		case unicode.IsLetter(r), unicode.IsDigit(r), unicode.Is(unicode.Mn, r):
+			word = append(word, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	if len(words) == 0 {
+		return nil
+	}
+
+	size := min(max(ShingleSize, 1), len(words))
+	seen := map[uint64]bool{}
+	var shingles []uint64
+
+	for i := 0; i+size <= len(words); i++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(words[i:i+size], " ")))
+		if sum := h.Sum64(); !seen[sum] {
+			seen[sum] = true
+			shingles = append(shingles, sum)
+		}
+	}
+
+	return shingles
+}
+
+// simHash sums the bits of the shingle hashes, the bit of the hash is set if set in most of the shingles
+func simHash(shingles []uint64) uint64 {
+
+	var weights [64]int
+	for _, shingle := range shingles {
+		for bit := 0; bit < 64; bit++ {
+			if shingle&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var hash uint64
+	if len(shingles) == 0 {
+		return hash
+	}
+
+	for bit, weight := range weights {
+		if weight > 0 {
+			hash |= 1 << bit
+		}
+	}
+
+	return hash
+}
+
+// minHash returns the minimums of the shingle hashes permuted by MinHashSize seeds
+func minHash(shingles []uint64) []uint32 {
+
+	if len(shingles) == 0 {
+		return nil
+	}
+
+	signature := make([]uint32, MinHashSize)
+	for i := range signature {
+		signature[i] = ^uint32(0)
+	}
+
+	for _, shingle := range shingles {
+		for i := range signature {
+			if h := uint32(mix64(shingle^minHashSeed(i)) >> 32); h < signature[i] {
+				signature[i] = h
+			}
+		}
+	}
+
+	return signature
+}
+
+// minHashBandKeys returns the hashes of the bands of the signature, the band position is a part of the key
+func minHashBandKeys(signature []uint32) []uint64 {
+
+	rows := len(signature) / minHashBands
+	keys := make([]uint64, 0, minHashBands)
+
+	for band := 0; band < minHashBands && rows > 0; band++ {
+		key := minHashSeed(band)
+		for _, value := range signature[band*rows : (band+1)*rows] {
+			key = mix64(key ^ uint64(value))
+		}
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// minHashSeed returns the seed of the permutation
+func minHashSeed(i int) uint64 {
+	return mix64(uint64(i+1) * 0x9e3779b97f4a7c15)
+}
+
+// mix64 is the finalizer of SplitMix64, the bits of the value are evenly distributed
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
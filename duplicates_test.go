@@ -0,0 +1,115 @@
+package article_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+const wireStory = "The central bank raised its benchmark interest rate by a quarter of a percentage point on Wednesday, " +
+	"the third increase this year, as policymakers sought to cool inflation that has remained stubbornly high. " +
+	"The decision was widely expected by economists, who said the bank was likely to pause at its next meeting. " +
+	"Stock markets rose slightly after the announcement, while the currency weakened against the dollar. " +
+	"The governor told reporters that the bank would watch wage growth and energy prices closely in the coming months."
+
+const otherStory = "Heavy rain flooded several neighbourhoods in the north of the city overnight, forcing hundreds of " +
+	"residents to leave their homes. Emergency services said no one was injured, but several roads remain closed " +
+	"and the railway line to the airport was suspended until further notice."
+
+func wireArticle(source, text string, published time.Time) *article.Article {
+	a := article.NewArticle()
+	a.Title = "Central bank raises rates"
+	a.SourceName = source
+	a.SourceURL = "https://" + strings.ToLower(source) + ".com/news/1"
+	a.Text = text
+	a.Published = published
+	return a
+}
+
+func TestFingerprint(t *testing.T) {
+
+	edited := strings.Replace(wireStory, "on Wednesday", "on Wednesday afternoon", 1)
+
+	assert.Equal(t, article.SimHash(wireStory), article.SimHash(strings.ToUpper(wireStory)), "the case is ignored")
+	assert.LessOrEqual(t, article.SimHashDistance(article.SimHash(wireStory), article.SimHash(edited)), 10)
+	assert.Greater(t, article.SimHashDistance(article.SimHash(wireStory), article.SimHash(otherStory)), 15)
+
+	assert.Len(t, article.MinHash(wireStory), article.MinHashSize)
+	assert.Greater(t, article.MinHashSimilarity(article.MinHash(wireStory), article.MinHash(edited)), 0.8)
+	assert.Less(t, article.MinHashSimilarity(article.MinHash(wireStory), article.MinHash(otherStory)), 0.1)
+
+	assert.Zero(t, article.SimHash(" , "))
+	assert.Nil(t, article.MinHash(""))
+	assert.Zero(t, article.MinHashSimilarity(nil, nil))
+
+	a := wireArticle("Reuters", wireStory, time.Now())
+	b := wireArticle("Daily", edited, time.Now())
+	assert.Equal(t, article.MinHash(wireStory), a.Fingerprint().MinHash)
+	assert.Greater(t, a.Fingerprint().Similarity(b.Fingerprint()), 0.8)
+}
+
+func TestDuplicateIndex(t *testing.T) {
+
+	now := time.Now()
+	original := wireArticle("Reuters", wireStory, now)
+	copied := wireArticle("Daily", wireStory+" Additional reporting by Jane Doe.", now)
+	other := wireArticle("Local", otherStory, now)
+	empty := wireArticle("Empty", "", now)
+
+	index := article.NewDuplicateIndex(0.8).Add(original, other, empty, nil)
+	assert.Equal(t, 2, index.Len())
+
+	duplicates := index.Find(copied)
+	require.Len(t, duplicates, 1)
+	assert.Same(t, original, duplicates[0].Article)
+	assert.Greater(t, duplicates[0].Similarity, 0.8)
+
+	assert.Empty(t, index.Find(original), "the article itself is skipped")
+	assert.Empty(t, index.Find(empty))
+}
+
+func TestArticles_Clusters(t *testing.T) {
+
+	now := time.Now()
+	early := wireArticle("Daily", wireStory, now.Add(-time.Hour))
+	long := wireArticle("Herald", wireStory+" The bank will publish the minutes of the meeting in two weeks.", now)
+	wire := wireArticle("Reuters", strings.Replace(wireStory, "stubbornly", "persistently", 1), now)
+	other := wireArticle("Local", otherStory, now)
+
+	list := article.NewArticles(early, other, long, wire)
+
+	clusters := list.Clusters(0.7, nil)
+	require.Len(t, clusters, 2)
+	assert.Equal(t, []*article.Article{early, long, wire}, clusters[0].Articles)
+	assert.Same(t, early, clusters[0].Canonical, "the earliest by default")
+	assert.Equal(t, []*article.Article{other}, clusters[1].Articles)
+	assert.Same(t, other, clusters[1].Canonical)
+
+	clusters = list.Clusters(0.7, &article.CanonicalPolicy{Longest: true})
+	assert.Same(t, long, clusters[0].Canonical)
+
+	clusters = list.Clusters(0.7, &article.CanonicalPolicy{PreferredSources: []string{"www.reuters.com"}})
+	assert.Same(t, wire, clusters[0].Canonical)
+
+	deduped := list.DedupeNear(0.7, nil)
+	assert.Equal(t, []string{early.ID, other.ID}, deduped.IDs())
+
+	assert.Empty(t, article.NewArticles().Clusters(0.7, nil))
+}
+
+func TestCanonicalPolicy_Choose(t *testing.T) {
+
+	now := time.Now()
+	unpublished := wireArticle("Daily", wireStory, time.Time{})
+	published := wireArticle("Herald", "Short", now)
+
+	assert.Same(t, published, article.DefaultCanonicalPolicy.Choose([]*article.Article{unpublished, published}))
+	assert.Same(t, unpublished, (&article.CanonicalPolicy{Longest: true}).Choose([]*article.Article{published, unpublished}))
+	assert.Same(t, unpublished, (&article.CanonicalPolicy{PreferredSources: []string{"daily"}}).Choose([]*article.Article{published, unpublished}))
+	assert.Nil(t, article.DefaultCanonicalPolicy.Choose(nil))
+}
@@ -0,0 +1,415 @@
+package article
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+// Feed is the channel-level metadata of the RSS, Atom or JSON Feed document.
+type Feed struct {
+	// Title is the name of the feed, e.g. Tech News.
+	Title string
+	// Link is the URL of the website.
+	Link string
+	// FeedURL is the URL of the feed itself.
+	FeedURL string
+	// Description is a short description of the feed.
+	Description string
+	// Language of the feed, e.g. en.
+	Language string
+	// Author is the name of the feed author or publisher.
+	Author string
+	// Copyright notice of the feed.
+	Copyright string
+	// ImageURL is the URL of the feed logo.
+	ImageURL string
+	// Generator is the name of the software generating the feed.
+	Generator string
+	// ID is the unique identifier of the Atom feed.
+	// Defaults to FeedURL or Link.
+	ID string
+	// Updated is the time of the last feed update.
+	// Defaults to the latest Published or Modified time of the articles, so the output is deterministic.
+	Updated time.Time
+}
+
+// id returns the feed ID or its fallback
+func (f Feed) id() string {
+	if f.ID != "" {
+		return f.ID
+	}
+	if f.FeedURL != "" {
+		return f.FeedURL
+	}
+	return f.Link
+}
+
+// updated returns the feed update time or the latest time of the articles
+func (f Feed) updated(list *Articles) time.Time {
+
+	if !f.Updated.IsZero() {
+		return f.Updated
+	}
+
+	var latest time.Time
+	for _, a := range list.Slice() {
+		if t := a.updated(); t.After(latest) {
+			latest = t
+		}
+	}
+
+	return latest
+}
+
+// updated returns the Modified time or the Published time if not modified
+func (a *Article) updated() time.Time {
+	if a.Modified.After(a.Published) {
+		return a.Modified
+	}
+	return a.Published
+}
+
+// authors returns the names of the article authors or the byline
+func (a *Article) authors() []string {
+
+	if a.Contributors != nil {
+		if names := a.Contributors.Role(RoleAuthor).Names(); len(names) > 0 {
+			return names
+		}
+	}
+
+	if a.Author != "" {
+		return []string{a.Author}
+	}
+
+	return nil
+}
+
+// categories returns the category followed by the tags
+func (a *Article) categories() []string {
+
+	var categories []string
+
+	if a.Category != "" {
+		categories = append(categories, a.Category)
+	}
+
+	if a.Tags != nil {
+		categories = append(categories, a.Tags.Slice()...)
+	}
+
+	return categories
+}
+
+// mimeTypes are the MIME types of common media extensions.
+// The table is used instead of mime.TypeByExtension to keep the output independent of the system.
+var mimeTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".avif": "image/avif",
+	".svg":  "image/svg+xml",
+	".mp4":  "video/mp4",
+	".m4v":  "video/mp4",
+	".webm": "video/webm",
+	".mov":  "video/quicktime",
+	".mp3":  "audio/mpeg",
+	".m4a":  "audio/mp4",
+	".ogg":  "audio/ogg",
+	".wav":  "audio/wav",
+	".pdf":  "application/pdf",
+}
+
+// mimeType guesses the MIME type of the URL by the file extension, e.g. image/jpeg
+func mimeType(rawURL string) string {
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return mimeTypes[strings.ToLower(path.Ext(u.Path))]
+}
+
+// FeedItemError describes a feed item that failed to become an Article.
+type FeedItemError struct {
+	// Index is the position of the item in the feed.
+	Index int
+	// Title of the item.
+	Title string
+	// Link of the item.
+	Link string
+	// Err is the normalization error.
+	Err error
+	// Report lists the changes made by normalization before it failed.
+	Report *NormalizeReport
+}
+
+func (e FeedItemError) Error() string {
+	return fmt.Sprintf("feed item %d %q: %v", e.Index, e.Title, e.Err)
+}
+
+func (e FeedItemError) Unwrap() error {
+	return e.Err
+}
+
+// ParsedFeed is the result of ParseFeed.
+type ParsedFeed struct {
+	// Feed is the channel-level metadata.
+	Feed Feed
+	// Articles are the normalized feed items.
+	Articles *Articles
+	// Failed are the items failed normalization, e.g. without title or text.
+	Failed []FeedItemError
+}
+
+// ErrUnknownFeed is returned when the document is not RSS, Atom or JSON Feed.
+var ErrUnknownFeed = errors.New("unknown feed format")
+
+// ParseFeed parses RSS 0.9x, 1.0, 2.0, Atom or JSON Feed document into normalized Articles.
+// Enclosures and media extensions become Images, Videos and Medias, content:encoded becomes Markup,
+// categories become Tags, Text and the embedded media are derived from the Markup, see Article.Derive.
+// Items failed normalization are skipped and listed in ParsedFeed.Failed.
+func ParseFeed(r io.Reader) (*ParsedFeed, error) {
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	data = bytes.TrimLeft(data, "\ufeff \t\r\n")
+
+	var feed Feed
+	var items []feedItem
+
+	switch {
+	case len(data) > 0 && data[0] == '{':
+		feed, items, err = parseJSONFeed(data)
+	default:
+		feed, items, err = parseXMLFeed(data)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := &ParsedFeed{Feed: feed, Articles: NewArticles()}
+
+	for idx, item := range items {
+
+		a := item.article(feed)
+
+		report, err := a.NormalizeWithReport()
+		if err != nil {
+			parsed.Failed = append(parsed.Failed, FeedItemError{Index: idx, Title: item.title, Link: item.link, Err: err, Report: report})
+			continue
+		}
+
+		parsed.Articles.Add(a)
+	}
+
+	return parsed, nil
+}
+
+// parseXMLFeed detects RSS or Atom by the root element
+func parseXMLFeed(data []byte) (Feed, []feedItem, error) {
+
+	dec := newXMLDecoder(data)
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return Feed{}, nil, ErrUnknownFeed
+		}
+
+		root, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch strings.ToLower(root.Name.Local) {
+		case "rss", "rdf":
+			return parseRSS(data)
+		case "feed":
+			return parseAtom(data)
+		}
+
+		return Feed{}, nil, ErrUnknownFeed
+	}
+}
+
+// newXMLDecoder creates a lenient decoder accepting HTML entities and non UTF-8 charsets.
+// The documents are parsed by encoding/xml, x/net/html/charset only decodes the legacy charsets
+// declared by the feeds, e.g. windows-1251, which encoding/xml rejects without the CharsetReader.
+func newXMLDecoder(data []byte) *xml.Decoder {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+	dec.CharsetReader = charset.NewReaderLabel
+	return dec
+}
+
+// feedItem is the format-independent feed item
+type feedItem struct {
+	title     string
+	link      string
+	summary   string
+	markup    string
+	text      string
+	image     string
+	language  string
+	published time.Time
+	modified  time.Time
+	authors   []string
+	tags      []string
+	media     []feedMedia
+}
+
+// feedMedia is the enclosure, media:content or attachment of the feed item
+type feedMedia struct {
+	url         string
+	typ         string
+	medium      string
+	title       string
+	description string
+	width       int
+	height      int
+	size        int
+	duration    int
+}
+
+// kind returns image, video or other medium of the media
+func (m feedMedia) kind() string {
+
+	switch m.medium {
+	case "image", "video":
+		return m.medium
+	case "":
+	default:
+		return "other"
+	}
+
+	typ := m.typ
+	if typ == "" {
+		typ = mimeType(m.url)
+	}
+
+	switch {
+	case strings.HasPrefix(typ, "image/"):
+		return "image"
+	case strings.HasPrefix(typ, "video/"):
+		return "video"
+	}
+
+	return "other"
+}
+
+// article converts the item to the Article, the Article is not normalized
+func (item feedItem) article(feed Feed) *Article {
+
+	a := NewArticle()
+	a.Title = HTMLToText(item.title)
+	a.SourceURL = item.link
+	a.Summary = HTMLToText(item.summary)
+	a.Markup = item.markup
+	a.Text = item.text
+	a.Published = item.published
+	a.Modified = item.modified
+	a.SourceName = feed.Title
+	a.Language = item.language
+	a.Tags.Add(item.tags...)
+
+	if a.Language == "" {
+		a.Language = feed.Language
+	}
+
+	// the summary is the only content
+	if a.Markup == "" && a.Text == "" {
+		a.Markup, a.Summary = item.summary, ""
+	}
+
+	if a.Markup == "" {
+		a.Markup = a.Text
+	}
+
+	for _, name := range item.authors {
+		if name = strings.TrimSpace(name); name != "" {
+			a.Contributors.Add(NewPerson(name))
+		}
+	}
+
+	if item.image != "" {
+		a.Images.Add(NewImage(item.image))
+	}
+
+	seen := map[string]bool{item.image: true}
+
+	for _, m := range item.media {
+
+		if m.url == "" || seen[m.url] {
+			continue
+		}
+		seen[m.url] = true
+
+		switch m.kind() {
+		case "image":
+			img := NewImage(m.url)
+			img.Title = m.title
+			img.Alt = m.description
+			img.Width = m.width
+			img.Height = m.height
+			a.Images.Add(img)
+		case "video":
+			video := NewVideo(m.url)
+			video.Title = m.title
+			video.Duration = m.duration
+			a.Videos.Add(video)
+		default:
+			media := NewMedia(m.url)
+			media.Title = m.title
+			media.Description = m.description
+			media.Size = m.size
+			a.Medias.Add(media)
+		}
+	}
+
+	// text, summary and media embedded in the content
+	a.Derive()
+
+	return a
+}
+
+// feedAuthor extracts the name from RSS author, e.g. "jo@example.com (John Doe)"
+func feedAuthor(author string) string {
+
+	author = strings.TrimSpace(author)
+
+	if open := strings.Index(author, "("); open >= 0 {
+		if end := strings.LastIndex(author, ")"); end > open {
+			return strings.TrimSpace(author[open+1 : end])
+		}
+	}
+
+	return author
+}
+
+// feedTime parses the feed date or returns zero time
+func feedTime(values ...string) time.Time {
+	for _, value := range values {
+		if t, err := ParseTime(value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,346 @@
+package article_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/editorpost/article"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// goldenFeedArticles returns a fixed collection for the golden-file tests
+func goldenFeedArticles() *article.Articles {
+
+	a := article.NewArticle()
+	a.ID = "123e4567-e89b-12d3-a456-426614174000"
+	a.Title = "The Rise of AI & Robots"
+	a.Summary = "How AI is changing industries."
+	a.Markup = "<p>Artificial Intelligence is transforming the world.</p>"
+	a.Text = "Artificial Intelligence is transforming the world."
+	a.Published = time.Date(2024, 5, 27, 10, 0, 0, 0, time.UTC)
+	a.Modified = time.Date(2024, 5, 28, 12, 0, 0, 0, time.UTC)
+	a.SourceURL = "https://example.com/news/ai"
+	a.Language = "en"
+	a.Category = "Technology"
+	a.Tags = article.NewTags("AI", "Robots")
+	a.Contributors.Add(article.NewPerson("John Doe"))
+	a.Images.Add(&article.Image{ID: "img-001", URL: "https://example.com/ai.jpg", Alt: "AI illustration", Width: 800, Height: 600})
+	a.Videos.Add(&article.Video{ID: "vid-001", URL: "https://example.com/ai.mp4", Title: "AI explained"})
+	a.Medias.Add(&article.Media{ID: "media-001", URL: "https://example.com/ai.mp3", Title: "AI podcast", Size: 1024})
+
+	b := article.NewArticle()
+	b.ID = "223e4567-e89b-12d3-a456-426614174000"
+	b.Title = "Quiet Day"
+	b.Markup = "<p>Nothing happened.</p>"
+	b.Text = "Nothing happened."
+	b.Published = time.Date(2024, 5, 26, 8, 30, 0, 0, time.UTC)
+	b.Author = "Editorial Board"
+
+	return article.NewArticles(a, b)
+}
+
+func goldenFeed() article.Feed {
+	return article.Feed{
+		Title:       "Tech News",
+		Link:        "https://example.com",
+		FeedURL:     "https://example.com/feed",
+		Description: "Latest technology news",
+		Language:    "en",
+		Author:      "Tech News",
+		Generator:   "editorpost/article",
+	}
+}
+
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	golden := filepath.Join("testdata", name)
+
+	if *update {
+		require.NoError(t, os.WriteFile(golden, got, 0o600))
+	}
+
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(got))
+}
+
+func TestArticles_WriteRSS(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, goldenFeedArticles().WriteRSS(&buf, goldenFeed()))
+	assertGolden(t, "feed.rss", buf.Bytes())
+}
+
+func TestArticles_WriteAtom(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, goldenFeedArticles().WriteAtom(&buf, goldenFeed()))
+	assertGolden(t, "feed.atom", buf.Bytes())
+}
+
+func TestArticles_WriteJSONFeed(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, goldenFeedArticles().WriteJSONFeed(&buf, goldenFeed()))
+	assertGolden(t, "feed.json", buf.Bytes())
+}
+
+func TestArticles_WriteRSS_Deterministic(t *testing.T) {
+
+	var first, second bytes.Buffer
+	require.NoError(t, goldenFeedArticles().WriteRSS(&first, goldenFeed()))
+	require.NoError(t, goldenFeedArticles().WriteRSS(&second, goldenFeed()))
+
+	assert.Equal(t, first.String(), second.String())
+}
+
+func TestParseFeed_RSS(t *testing.T) {
+
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/" xmlns:dc="http://purl.org/dc/elements/1.1/"
+	xmlns:content="http://purl.org/rss/1.0/modules/content/" xmlns:atom="http://www.w3.org/2005/Atom">
+<channel>
+	<title>Tech News</title>
+	<link>https://example.com</link>
+	<atom:link href="https://example.com/rss" rel="self" type="application/rss+xml"/>
+	<description>Latest technology news</description>
+	<language>en</language>
+	<item>
+		<title>The Rise of AI &amp; Robots</title>
+		<link>https://example.com/news/ai</link>
+		<guid isPermaLink="false">ai-001</guid>
+		<description>How AI is changing &lt;b&gt;industries&lt;/b&gt;.</description>
+		<content:encoded><![CDATA[<p>Artificial Intelligence is transforming the world.</p><p>Robots&nbsp;too.</p>]]></content:encoded>
+		<dc:creator>John Doe</dc:creator>
+		<pubDate>Mon, 27 May 2024 10:00:00 +0000</pubDate>
+		<category>AI</category>
+		<category>Robots</category>
+		<enclosure url="https://example.com/podcast.mp3" type="audio/mpeg" length="1024"/>
+		<media:content url="https://example.com/ai.jpg" medium="image" width="800" height="600">
+			<media:title>AI</media:title>
+			<media:description>AI illustration</media:description>
+		</media:content>
+		<media:group>
+			<media:title>AI explained</media:title>
+			<media:content url="https://example.com/ai.mp4" type="video/mp4"/>
+		</media:group>
+	</item>
+	<item>
+		<guid>https://example.com/news/no-title</guid>
+		<description>Item without title</description>
+	</item>
+	<item>
+		<title>Permalink guid</title>
+		<guid>https://example.com/news/guid</guid>
+		<author>jane@example.com (Jane Smith)</author>
+		<description>Only description</description>
+	</item>
+</channel>
+</rss>`
+
+	parsed, err := article.ParseFeed(strings.NewReader(doc))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Tech News", parsed.Feed.Title)
+	assert.Equal(t, "https://example.com", parsed.Feed.Link)
+	assert.Equal(t, "en", parsed.Feed.Language)
+
+	require.Equal(t, 2, parsed.Articles.Len())
+	require.Len(t, parsed.Failed, 1)
+	assert.Equal(t, 1, parsed.Failed[0].Index)
+	assert.NotEmpty(t, parsed.Failed[0].Report.Filter(article.ActionRejected))
+
+	a := parsed.Articles.Slice()[0]
+	assert.Equal(t, "The Rise of AI & Robots", a.Title)
+	assert.Equal(t, "https://example.com/news/ai", a.SourceURL)
+	assert.Equal(t, "How AI is changing industries.", a.Summary)
+	assert.Equal(t, "<p>Artificial Intelligence is transforming the world.</p><p>Robots\u00a0too.</p>", a.Markup)
+	assert.Equal(t, "Artificial Intelligence is transforming the world.\n\nRobots too.", a.Text)
+	assert.Equal(t, "John Doe", a.Author)
+	assert.Equal(t, "Tech News", a.SourceName)
+	assert.Equal(t, "en", a.Language)
+	assert.Equal(t, "2024-05-27T10:00:00Z", a.Published.UTC().Format(time.RFC3339))
+	assert.Equal(t, []string{"AI", "Robots"}, a.Tags.Slice())
+
+	require.Equal(t, 1, a.Images.Len())
+	img := a.Images.Slice()[0]
+	assert.Equal(t, "https://example.com/ai.jpg", img.URL)
+	assert.Equal(t, "AI illustration", img.Alt)
+	assert.Equal(t, 800, img.Width)
+
+	require.Equal(t, 1, a.Videos.Len())
+	assert.Equal(t, "https://example.com/ai.mp4", a.Videos.Slice()[0].URL)
+	assert.Equal(t, "AI explained", a.Videos.Slice()[0].Title, "the title of the group")
+
+	require.Equal(t, 1, a.Medias.Len())
+	assert.Equal(t, 1024, a.Medias.Slice()[0].Size)
+
+	b := parsed.Articles.Slice()[1]
+	assert.Equal(t, "https://example.com/news/guid", b.SourceURL)
+	assert.Equal(t, "Jane Smith", b.Author)
+	assert.Equal(t, "Only description", b.Text)
+}
+
+func TestParseFeed_RDF(t *testing.T) {
+
+	doc := `<?xml version="1.0"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/">
+	<channel rdf:about="https://example.com/rss">
+		<title>RDF News</title>
+		<link>https://example.com</link>
+		<dc:language>de</dc:language>
+	</channel>
+	<item rdf:about="https://example.com/news/1">
+		<title>First</title>
+		<link>https://example.com/news/1</link>
+		<description>First item text</description>
+		<dc:date>2024-05-27T10:00:00Z</dc:date>
+		<dc:subject>Politics</dc:subject>
+	</item>
+</rdf:RDF>`
+
+	parsed, err := article.ParseFeed(strings.NewReader(doc))
+	require.NoError(t, err)
+	require.Equal(t, 1, parsed.Articles.Len())
+
+	a := parsed.Articles.Slice()[0]
+	assert.Equal(t, "First", a.Title)
+	assert.Equal(t, "de", a.Language)
+	assert.Equal(t, []string{"Politics"}, a.Tags.Slice())
+	assert.Equal(t, "2024-05-27T10:00:00Z", a.Published.UTC().Format(time.RFC3339))
+}
+
+func TestParseFeed_Charset(t *testing.T) {
+
+	doc := `<?xml version="1.0" encoding="windows-1251"?>
+<rss version="2.0">
+	<channel>
+		<title>Новости</title>
+		<item>
+			<title>Городской совет одобрил продление трамвая</title>
+			<link>https://example.com/news/1</link>
+			<description>Трамвайная линия будет продлена до конца года.</description>
+			<pubDate>Mon, 27 May 2024 10:00:00 +0000</pubDate>
+		</item>
+	</channel>
+</rss>`
+
+	encoded, err := charmap.Windows1251.NewEncoder().String(doc)
+	require.NoError(t, err)
+
+	parsed, err := article.ParseFeed(strings.NewReader(encoded))
+	require.NoError(t, err)
+	assert.Equal(t, "Новости", parsed.Feed.Title)
+	require.Equal(t, 1, parsed.Articles.Len())
+	assert.Equal(t, "Городской совет одобрил продление трамвая", parsed.Articles.Slice()[0].Title)
+}
+
+func TestParseFeed_Atom(t *testing.T) {
+
+	doc := `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/" xml:lang="en">
+	<title>Atom News</title>
+	<link href="https://example.com/"/>
+	<link rel="self" href="https://example.com/atom"/>
+	<updated>2024-05-28T12:00:00Z</updated>
+	<entry>
+		<id>urn:uuid:1</id>
+		<title type="html">Tom &amp;amp; Jerry</title>
+		<link href="https://example.com/news/tom"/>
+		<link rel="enclosure" href="https://example.com/tom.jpg" type="image/jpeg"/>
+		<published>2024-05-27T10:00:00Z</published>
+		<updated>2024-05-28T12:00:00Z</updated>
+		<author><name>Jane Smith</name></author>
+		<summary>Cat &amp; mouse</summary>
+		<content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml"><p>Chase</p></div></content>
+		<category term="cartoons"/>
+		<media:group>
+			<media:thumbnail url="https://example.com/thumb.jpg" width="120" height="90"/>
+		</media:group>
+	</entry>
+</feed>`
+
+	parsed, err := article.ParseFeed(strings.NewReader(doc))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Atom News", parsed.Feed.Title)
+	assert.Equal(t, "https://example.com/atom", parsed.Feed.FeedURL)
+	require.Equal(t, 1, parsed.Articles.Len())
+
+	a := parsed.Articles.Slice()[0]
+	assert.Equal(t, "Tom & Jerry", a.Title)
+	assert.Equal(t, "https://example.com/news/tom", a.SourceURL)
+	assert.Equal(t, "Cat & mouse", a.Summary)
+	assert.Equal(t, "Chase", a.Text)
+	assert.Equal(t, "Jane Smith", a.Author)
+	assert.Equal(t, "en", a.Language)
+	assert.Equal(t, []string{"cartoons"}, a.Tags.Slice())
+	assert.Equal(t, 2, a.Images.Len())
+	assert.Equal(t, "2024-05-28T12:00:00Z", a.Modified.UTC().Format(time.RFC3339))
+}
+
+func TestParseFeed_JSONFeed(t *testing.T) {
+
+	var buf bytes.Buffer
+	require.NoError(t, goldenFeedArticles().WriteJSONFeed(&buf, goldenFeed()))
+
+	parsed, err := article.ParseFeed(&buf)
+	require.NoError(t, err)
+	require.Equal(t, 2, parsed.Articles.Len())
+	assert.Equal(t, "Tech News", parsed.Feed.Title)
+
+	a := parsed.Articles.Slice()[0]
+	assert.Equal(t, "The Rise of AI & Robots", a.Title)
+	assert.Equal(t, "Artificial Intelligence is transforming the world.", a.Text)
+	assert.Equal(t, "John Doe", a.Author)
+	assert.Equal(t, 1, a.Images.Len())
+	assert.Equal(t, 1, a.Videos.Len())
+	assert.Equal(t, 1, a.Medias.Len())
+}
+
+func TestParseFeed_RoundTrip(t *testing.T) {
+
+	for _, write := range []func(*article.Articles, *bytes.Buffer) error{
+		func(list *article.Articles, buf *bytes.Buffer) error { return list.WriteRSS(buf, goldenFeed()) },
+		func(list *article.Articles, buf *bytes.Buffer) error { return list.WriteAtom(buf, goldenFeed()) },
+	} {
+		var buf bytes.Buffer
+		expected := goldenFeedArticles()
+		require.NoError(t, write(expected, &buf))
+
+		parsed, err := article.ParseFeed(&buf)
+		require.NoError(t, err)
+		require.Empty(t, parsed.Failed)
+		require.Equal(t, expected.Len(), parsed.Articles.Len())
+
+		for i, got := range parsed.Articles.Slice() {
+			want := expected.Slice()[i]
+			require.NoError(t, want.Normalize())
+			assert.Equal(t, want.Title, got.Title)
+			assert.Equal(t, want.Markup, got.Markup)
+			assert.Equal(t, want.Text, got.Text)
+			assert.Equal(t, want.Author, got.Author)
+			assert.True(t, want.Published.Equal(got.Published))
+			assert.Equal(t, want.Images.Len(), got.Images.Len())
+			assert.Equal(t, want.Videos.Len(), got.Videos.Len())
+		}
+	}
+}
+
+func TestParseFeed_Unknown(t *testing.T) {
+
+	_, err := article.ParseFeed(strings.NewReader(`<html><body>Not a feed</body></html>`))
+	assert.ErrorIs(t, err, article.ErrUnknownFeed)
+
+	_, err = article.ParseFeed(strings.NewReader(`{"title": "Not a feed"}`))
+	assert.ErrorIs(t, err, article.ErrUnknownFeed)
+}
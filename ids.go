@@ -0,0 +1,187 @@
+package article
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of the identified items passed to the IDStrategy.
+const (
+	KindArticle = "article"
+	KindImage   = "image"
+	KindVideo   = "video"
+	KindMedia   = "media"
+	KindQuote   = "quote"
+	KindSocial  = "social"
+	KindPerson  = "person"
+)
+
+// IDStrategy generates the IDs of the Article and its items.
+type IDStrategy interface {
+	// ID returns the ID of the item of the kind, e.g. image, with the content key, e.g. canonical URL.
+	// The key is never empty.
+	ID(kind, key string) string
+}
+
+// RandomIDs generates random UUID v4, the same content yields a new ID every time.
+// It is the strategy of the constructors, e.g. NewArticle.
+type RandomIDs struct{}
+
+// ID returns the random UUID.
+func (RandomIDs) ID(string, string) string {
+	return uuid.New().String()
+}
+
+// ContentIDs generates name-based UUID v5 from the kind and the content key,
+// so repeated ingestion of the same content yields the same IDs.
+type ContentIDs struct {
+	// Namespace of the generated UUIDs, defaults to uuid.NameSpaceURL.
+	// Use own namespace to separate the IDs of different stores.
+	Namespace uuid.UUID
+}
+
+// ID returns the UUID v5 of the kind and the key.
+func (s ContentIDs) ID(kind, key string) string {
+
+	namespace := s.Namespace
+	if namespace == uuid.Nil {
+		namespace = uuid.NameSpaceURL
+	}
+
+	return uuid.NewSHA1(namespace, []byte(kind+":"+key)).String()
+}
+
+// AssignIDs replaces the IDs of the Article and all nested items with the IDs of the strategy:
+// the Article is keyed on the SourceURL, images, videos and medias on their URL,
+// quotes on the source URL and the text, socials on the platform and the URL,
+// persons on the first social profile URL or the name.
+// The items without the key keep their ID, the empty ID is replaced with a random one.
+func (a *Article) AssignIDs(ids IDStrategy) {
+
+	a.ID = assignID(ids, KindArticle, urlKey(a.SourceURL), a.ID)
+
+	for _, img := range a.Images.Slice() {
+		img.AssignID(ids)
+	}
+
+	for _, video := range a.Videos.Slice() {
+		video.AssignID(ids)
+	}
+
+	if a.Medias != nil {
+		for _, media := range a.Medias.Slice() {
+			media.AssignID(ids)
+		}
+	}
+
+	for _, quote := range a.Quotes.Slice() {
+		quote.AssignID(ids)
+	}
+
+	for _, social := range a.Socials.Slice() {
+		social.AssignID(ids)
+	}
+
+	if a.Contributors != nil {
+		for _, person := range a.Contributors.Slice() {
+			person.AssignID(ids)
+		}
+	}
+}
+
+// AssignID replaces the ID of the Image with the ID of the strategy keyed on the URL.
+func (img *Image) AssignID(ids IDStrategy) {
+	img.ID = assignID(ids, KindImage, urlKey(img.URL), img.ID)
+}
+
+// AssignID replaces the ID of the Video with the ID of the strategy keyed on the URL.
+func (v *Video) AssignID(ids IDStrategy) {
+	v.ID = assignID(ids, KindVideo, urlKey(v.URL), v.ID)
+}
+
+// AssignID replaces the ID of the Media with the ID of the strategy keyed on the URL.
+func (i *Media) AssignID(ids IDStrategy) {
+	i.ID = assignID(ids, KindMedia, urlKey(i.URL), i.ID)
+}
+
+// AssignID replaces the ID of the Quote with the ID of the strategy keyed on the source URL and the text.
+func (q *Quote) AssignID(ids IDStrategy) {
+
+	key := ""
+	if text := strings.Join(strings.Fields(q.Text), " "); text != "" {
+		key = urlKey(q.SourceURL) + "\n" + text
+	}
+
+	q.ID = assignID(ids, KindQuote, key, q.ID)
+}
+
+// AssignID replaces the ID of the Social with the ID of the strategy keyed on the platform and the URL.
+func (s *Social) AssignID(ids IDStrategy) {
+
+	key := ""
+	if u := urlKey(s.URL); u != "" {
+		key = strings.ToLower(strings.TrimSpace(s.Platform)) + "\n" + u
+	}
+
+	s.ID = assignID(ids, KindSocial, key, s.ID)
+}
+
+// AssignID replaces the ID of the Person and the nested items with the ID of the strategy
+// keyed on the first social profile URL or the name.
+func (p *Person) AssignID(ids IDStrategy) {
+
+	key := strings.ToLower(strings.Join(strings.Fields(p.Name), " "))
+
+	if p.Socials != nil {
+		for _, social := range p.Socials.Slice() {
+			social.AssignID(ids)
+		}
+		for _, social := range p.Socials.Slice() {
+			if u := urlKey(social.URL); u != "" {
+				key = u
+				break
+			}
+		}
+	}
+
+	if p.Images != nil {
+		for _, img := range p.Images.Slice() {
+			img.AssignID(ids)
+		}
+	}
+
+	p.ID = assignID(ids, KindPerson, key, p.ID)
+}
+
+// assignID returns the ID of the strategy, the current ID if the key is empty
+// or the random ID if both are empty
+func assignID(ids IDStrategy, kind, key, current string) string {
+
+	switch {
+	case key != "" && ids != nil:
+		return ids.ID(kind, key)
+	case current != "":
+		return current
+	}
+
+	return RandomIDs{}.ID(kind, key)
+}
+
+// urlKey returns the canonical URL, see URLCanonicalizer,
+// or the empty string for the empty or relative URL
+func urlKey(raw string) string {
+
+	c := URLCanonicalizer
+	if c == nil {
+		c = DefaultCanonicalizer()
+	}
+
+	canonical := c.Canonical(raw, "")
+	if u, err := url.Parse(canonical); err != nil || u.Host == "" {
+		return ""
+	}
+
+	return canonical
+}
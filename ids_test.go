@@ -0,0 +1,99 @@
+package article_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+func newIdentifiedArticle() *article.Article {
+
+	a := article.NewArticle()
+	a.Title = "Title"
+	a.Markup = "<p>Text</p>"
+	a.Text = "Text"
+	a.SourceURL = "https://Example.com/news/1/?b=2&a=1#comments"
+	a.Images.Add(article.NewImage("https://example.com/a.jpg"))
+	a.Videos.Add(article.NewVideo("https://example.com/a.mp4"))
+	a.Medias.Add(article.NewMedia("https://example.com/a.mp3"))
+	a.Quotes.Add(&article.Quote{ID: uuid.NewString(), Text: "To be or not to be", SourceURL: "https://example.org/"})
+	a.Socials.Add(article.NewSocial("Twitter", "https://twitter.com/example"))
+	a.Contributors.Add(article.NewPerson("John Doe"))
+
+	return a
+}
+
+func allIDs(a *article.Article) []string {
+	ids := []string{a.ID}
+	ids = append(ids, a.Images.IDs()...)
+	ids = append(ids, a.Videos.IDs()...)
+	ids = append(ids, a.Medias.IDs()...)
+	ids = append(ids, a.Quotes.IDs()...)
+	ids = append(ids, a.Socials.IDs()...)
+	ids = append(ids, a.Contributors.IDs()...)
+	return ids
+}
+
+func TestArticle_AssignIDs(t *testing.T) {
+
+	first, second := newIdentifiedArticle(), newIdentifiedArticle()
+	assert.NotEqual(t, allIDs(first), allIDs(second))
+
+	first.AssignIDs(article.ContentIDs{})
+	second.AssignIDs(article.ContentIDs{})
+	assert.Equal(t, allIDs(first), allIDs(second))
+
+	// equivalent URLs yield the same ID
+	second.SourceURL = "https://example.com/news/1?a=1&b=2"
+	second.AssignIDs(article.ContentIDs{})
+	assert.Equal(t, first.ID, second.ID)
+
+	// the IDs are valid UUID v5
+	parsed, err := uuid.Parse(first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(5), parsed.Version())
+	require.NoError(t, first.Normalize())
+
+	// the stored URL of the file is kept, the ID is keyed on its canonical form
+	tracked := article.NewImage("https://Example.com/a.jpg?utm_source=rss")
+	tracked.AssignID(article.ContentIDs{})
+	assert.Equal(t, first.Images.Slice()[0].ID, tracked.ID)
+	assert.Equal(t, "https://Example.com/a.jpg?utm_source=rss", tracked.URL)
+
+	// the same URL of different kinds yields different IDs
+	img := article.NewImage("https://example.com/a")
+	video := article.NewVideo("https://example.com/a")
+	img.AssignID(article.ContentIDs{})
+	video.AssignID(article.ContentIDs{})
+	assert.NotEqual(t, img.ID, video.ID)
+
+	// the namespace separates the IDs
+	second.AssignIDs(article.ContentIDs{Namespace: uuid.NameSpaceOID})
+	assert.NotEqual(t, first.ID, second.ID)
+}
+
+func TestArticle_AssignIDsWithoutKey(t *testing.T) {
+
+	a := article.NewArticle()
+	id := a.ID
+
+	a.AssignIDs(article.ContentIDs{})
+	assert.Equal(t, id, a.ID)
+
+	a.ID = ""
+	a.AssignIDs(article.ContentIDs{})
+	assert.NotEmpty(t, a.ID)
+}
+
+func TestRandomIDs(t *testing.T) {
+
+	a := newIdentifiedArticle()
+	id := a.ID
+
+	a.AssignIDs(article.RandomIDs{})
+	assert.NotEqual(t, id, a.ID)
+}
@@ -1,7 +1,6 @@
 package article
 
 import (
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"log/slog"
 )
@@ -15,15 +14,15 @@ type Image struct {
 
 	// URL is the URL of the image.
 	// This field is required and should be a valid URL.
-	URL string `json:"url" validate:"url,max=4096"`
+	URL string `json:"url" validate:"url,limit=URL"`
 
 	// Title is the title for the image.
 	// This field is optional.
-	Title string `json:"title,omitempty" validate:"max=500"`
+	Title string `json:"title,omitempty" validate:"limit=Caption"`
 
 	// Alt is the alternative text for the image.
 	// This field is required and should be between 1 and 255 characters long.
-	Alt string `json:"alt" validate:"max=255"`
+	Alt string `json:"alt" validate:"limit=Name"`
 
 	// Width is the width of the image in pixels.
 	// This field is optional.
@@ -32,6 +31,26 @@ type Image struct {
 	// Height is the height of the image in pixels.
 	// This field is optional.
 	Height int `json:"height,omitempty" validate:"min=0"`
+
+	// Size is the file size of the image in bytes.
+	// This field is optional and populated by the Prober.
+	Size int `json:"size,omitempty" validate:"min=0"`
+
+	// Hash is the perceptual hash of the image content, e.g. phash:c3d0e0f0f8f0e0c0.
+	// This field is optional and populated by ComputeHash or the Prober, see HashDistance.
+	Hash string `json:"hash,omitempty" validate:"max=64"`
+
+	// Renditions are the resized or converted variants of the image, see SrcSet.
+	// This field is optional, invalid renditions are removed during normalization.
+	Renditions []*Rendition `json:"renditions,omitempty" validate:"omitempty,dive"`
+
+	// Focal is the point of interest kept visible when cropping.
+	// This field is optional.
+	Focal *FocalPoint `json:"focal,omitempty"`
+
+	// Crop is the area of the image shown instead of the whole image.
+	// This field is optional and should be within the Width and Height if known.
+	Crop *CropBox `json:"crop,omitempty"`
 }
 
 // NewImage creates a new Image with a random UUID.
@@ -44,74 +63,167 @@ func NewImage(url string) *Image {
 	}
 }
 
+// GetID returns the ID of the Image.
+func (i *Image) GetID() string {
+	return i.ID
+}
+
 // Normalize validates and trims the fields of the Image.
+// The Image is reset to the zero value if it is invalid.
 func (i *Image) Normalize() {
+	if err := i.normalize(nil, "Image"); err != nil {
+		*i = Image{}
+	}
+}
+
+// normalize trims the fields, records the changes to the report and returns the validation error.
+func (i *Image) normalize(report *NormalizeReport, path string) error {
 
 	if i.ID == "" {
 		i.ID = uuid.New().String()
+		report.fallback(path+".ID", "")
 	}
 
-	i.ID = TrimToMaxLen(i.ID, 36)
-	i.URL = TrimToMaxLen(i.URL, 4096)
-	i.Alt = TrimToMaxLen(i.Alt, 255)
-	i.Title = TrimToMaxLen(i.Title, 500)
+	i.ID = report.trim(path+".ID", i.ID, 36)
+	i.URL = URLCanonicalizer.asset(report, path+".URL", report.trim(path+".URL", i.URL, report.limits().URL), report.baseURL())
+	i.Alt = report.trim(path+".Alt", i.Alt, report.limits().Name)
+	i.Title = report.trim(path+".Title", i.Title, report.limits().Caption)
+	i.Hash = report.trim(path+".Hash", i.Hash, 64)
+
+	i.normalizeRenditions(report, path)
+	i.normalizeFocal(report, path)
 
-	err := validate.Struct(i)
+	err := report.validate(i)
 	if err != nil {
-		for _, fieldErr := range err.(validator.ValidationErrors) {
-			slog.Debug("Validation error in Image", slog.String("field", fieldErr.Namespace()), slog.String("error", fieldErr.Tag()))
-			*i = Image{}
+		slog.Debug("Validation error in Image", slog.String("path", path), slog.String("error", err.Error()))
+	}
+
+	return err
+}
+
+// normalizeRenditions removes the invalid renditions and records the changes to the report
+func (i *Image) normalizeRenditions(report *NormalizeReport, path string) {
+
+	if len(i.Renditions) == 0 {
+		return
+	}
+
+	var valid []*Rendition
+	for idx, r := range i.Renditions {
+		if r == nil {
+			continue
+		}
+		if err := r.normalize(report, itemPath(path+".Renditions", idx)); err != nil {
+			report.invalid(itemPath(path+".Renditions", idx), err, ActionRemoved)
+			continue
+		}
+		valid = append(valid, r)
+	}
+
+	i.Renditions = valid
+}
+
+// normalizeFocal resets the focal point and the crop box out of the image
+func (i *Image) normalizeFocal(report *NormalizeReport, path string) {
+
+	if i.Focal != nil {
+		if err := validate.Struct(i.Focal); err != nil {
+			report.invalid(path+".Focal", err, ActionReset)
+			i.Focal = nil
+		}
+	}
+
+	if i.Crop != nil {
+		if err := validate.Struct(i.Crop); err != nil {
+			report.invalid(path+".Crop", err, ActionReset)
+			i.Crop = nil
+		} else if (i.Width > 0 && i.Crop.X+i.Crop.Width > i.Width) || (i.Height > 0 && i.Crop.Y+i.Crop.Height > i.Height) {
+			report.Add(path+".Crop", "bounds", *i.Crop, ActionReset)
+			i.Crop = nil
 		}
 	}
 }
 
 // Map converts the Image struct to a map[string]any.
+// The renditions, focal point and crop box are included only if set.
 func (i *Image) Map() map[string]any {
-	return map[string]any{
+
+	m := map[string]any{
 		"id":     i.ID,
 		"url":    i.URL,
 		"alt":    i.Alt,
 		"width":  i.Width,
 		"height": i.Height,
+		"size":   i.Size,
+		"hash":   i.Hash,
 		"title":  i.Title,
 	}
+
+	if len(i.Renditions) > 0 {
+		renditions := make([]map[string]any, len(i.Renditions))
+		for idx, r := range i.Renditions {
+			renditions[idx] = r.Map()
+		}
+		m["renditions"] = renditions
+	}
+
+	if i.Focal != nil {
+		m["focal"] = map[string]any{"x": i.Focal.X, "y": i.Focal.Y}
+	}
+
+	if i.Crop != nil {
+		m["crop"] = map[string]any{"x": i.Crop.X, "y": i.Crop.Y, "width": i.Crop.Width, "height": i.Crop.Height}
+	}
+
+	return m
 }
 
 // NewImageFromMap creates an Image from a map[string]any, validates it, and returns a pointer to the Image or an error.
 func NewImageFromMap(m map[string]any) (*Image, error) {
+	return imageFromMap(newMapReader(m))
+}
+
+// NewImageFromMapStrict creates an Image from a map[string]any like NewImageFromMap,
+// but fails with MapErrors listing every key holding a value of unexpected type.
+func NewImageFromMapStrict(m map[string]any) (*Image, error) {
+	return imageFromMap(newStrictMapReader(m))
+}
+
+// imageFromMap reads the Image with the map reader, validates it, and returns a pointer to the Image or an error.
+func imageFromMap(r *mapReader) (*Image, error) {
 	img := &Image{
-		ID:     StringFromMap(m, "id"),
-		URL:    StringFromMap(m, "url"),
-		Alt:    StringFromMap(m, "alt"),
-		Width:  IntFromMap(m, "width"),
-		Height: IntFromMap(m, "height"),
-		Title:  StringFromMap(m, "title"),
+		ID:     r.string("id"),
+		URL:    r.string("url"),
+		Alt:    r.string("alt"),
+		Width:  r.int("width"),
+		Height: r.int("height"),
+		Size:   r.int("size"),
+		Hash:   r.string("hash"),
+		Title:  r.string("title"),
 	}
 
-	err := validate.Struct(img)
-	if err != nil {
-		return nil, err
+	for _, sub := range r.maps("renditions") {
+		if rendition, err := renditionFromMap(sub); err == nil {
+			img.Renditions = append(img.Renditions, rendition)
+		}
 	}
 
-	return img, nil
-}
+	if focal := r.sub("focal"); focal != nil {
+		img.Focal = &FocalPoint{X: focal.float("x"), Y: focal.float("y")}
+	}
 
-// StringFromMap safely extracts a string from the map or returns a zero value.
-func StringFromMap(m map[string]any, key string) string {
-	if value, exists := m[key]; exists {
-		if str, ok := value.(string); ok {
-			return str
-		}
+	if crop := r.sub("crop"); crop != nil {
+		img.Crop = &CropBox{X: crop.int("x"), Y: crop.int("y"), Width: crop.int("width"), Height: crop.int("height")}
 	}
-	return ""
-}
 
-// IntFromMap safely extracts an int from the map or returns a zero value.
-func IntFromMap(m map[string]any, key string) int {
-	if value, exists := m[key]; exists {
-		if i, ok := value.(int); ok {
-			return i
-		}
+	if err := r.failed(); err != nil {
+		return nil, err
 	}
-	return 0
+
+	err := r.validate(img)
+	if err != nil {
+		return nil, err
+	}
+
+	return img, nil
 }
@@ -33,6 +33,8 @@ func TestImageConversions(t *testing.T) {
 				"alt":    "An example image",
 				"width":  800,
 				"height": 600,
+				"size":   1024,
+				"hash":   "phash:c3d0e0f0f8f0e0c0",
 				"title":  "An example title",
 			},
 			expectedImage: &article.Image{
@@ -41,6 +43,8 @@ func TestImageConversions(t *testing.T) {
 				Alt:    "An example image",
 				Width:  800,
 				Height: 600,
+				Size:   1024,
+				Hash:   "phash:c3d0e0f0f8f0e0c0",
 				Title:  "An example title",
 			},
 			expectError: false,
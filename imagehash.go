@@ -0,0 +1,368 @@
+package article
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// HashAlgorithm is the perceptual hash algorithm of the Image.Hash.
+type HashAlgorithm string
+
+const (
+	// HashAverage compares the pixels of 8x8 grayscale thumbnail with the mean, fast but sensitive to the gamma.
+	HashAverage HashAlgorithm = "ahash"
+	// HashDifference compares the adjacent pixels of 9x8 grayscale thumbnail, robust to the brightness.
+	HashDifference HashAlgorithm = "dhash"
+	// HashPerceptual compares the low frequencies of the DCT of 32x32 grayscale thumbnail with the median,
+	// robust to the scaling, compression and small edits.
+	HashPerceptual HashAlgorithm = "phash"
+)
+
+// ErrHashMismatch is returned for the hashes of the different algorithms or the invalid hash.
+var ErrHashMismatch = errors.New("incomparable image hashes")
+
+// ErrImageTooLarge is returned for the image with more pixels than MaxImagePixels.
+var ErrImageTooLarge = errors.New("image too large")
+
+// MaxImagePixels is the maximum width×height of the image decoded for the hash, 50 megapixels by default.
+// The dimensions are read from the header before decoding, so the small file of the huge image
+// is rejected without allocating its pixels. Zero or negative disables the limit.
+var MaxImagePixels int64 = 50_000_000
+
+// ImageHash returns the 64-bit perceptual hash of the decoded image.
+func ImageHash(img image.Image, alg HashAlgorithm) (uint64, error) {
+
+	switch alg {
+	case HashAverage:
+		return averageHash(img), nil
+	case HashDifference:
+		return differenceHash(img), nil
+	case HashPerceptual:
+		return perceptualHash(img), nil
+	}
+
+	return 0, fmt.Errorf("unknown hash algorithm %q", alg)
+}
+
+// FormatHash formats the hash as the Image.Hash string, e.g. phash:c3d0e0f0f8f0e0c0.
+func FormatHash(alg HashAlgorithm, hash uint64) string {
+	return fmt.Sprintf("%s:%016x", alg, hash)
+}
+
+// ParseHash parses the Image.Hash string.
+func ParseHash(s string) (HashAlgorithm, uint64, error) {
+
+	alg, hex, found := strings.Cut(s, ":")
+	if !found {
+		return "", 0, ErrHashMismatch
+	}
+
+	hash, err := strconv.ParseUint(hex, 16, 64)
+	if err != nil {
+		return "", 0, ErrHashMismatch
+	}
+
+	return HashAlgorithm(alg), hash, nil
+}
+
+// HashDistance returns the Hamming distance of the Image.Hash strings: 0 for the same image,
+// up to 10 for the resized or recompressed copy and about 32 for the unrelated images.
+func HashDistance(a, b string) (int, error) {
+
+	algA, hashA, err := ParseHash(a)
+	if err != nil {
+		return 0, err
+	}
+
+	algB, hashB, err := ParseHash(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if algA != algB {
+		return 0, ErrHashMismatch
+	}
+
+	return bits.OnesCount64(hashA ^ hashB), nil
+}
+
+// ComputeHash decodes the image bytes (JPEG, PNG or GIF) and sets the Hash of the Image.
+// The images larger than MaxImagePixels are rejected with ErrImageTooLarge.
+func (i *Image) ComputeHash(r io.Reader, alg HashAlgorithm) error {
+
+	decoded, err := decodeImage(r)
+	if err != nil {
+		return err
+	}
+
+	hash, err := ImageHash(decoded, alg)
+	if err != nil {
+		return err
+	}
+
+	i.Hash = FormatHash(alg, hash)
+	return nil
+}
+
+// decodeImage checks the dimensions of the image against MaxImagePixels and decodes it
+func decodeImage(r io.Reader) (image.Image, error) {
+
+	// the header read by DecodeConfig is replayed to Decode
+	var head bytes.Buffer
+	cfg, _, err := image.DecodeConfig(io.TeeReader(r, &head))
+	if err != nil {
+		return nil, err
+	}
+
+	if MaxImagePixels > 0 && int64(cfg.Width)*int64(cfg.Height) > MaxImagePixels {
+		return nil, fmt.Errorf("%w: %dx%d exceeds %d pixels", ErrImageTooLarge, cfg.Width, cfg.Height, MaxImagePixels)
+	}
+
+	decoded, _, err := image.Decode(io.MultiReader(&head, r))
+	return decoded, err
+}
+
+// DedupeByHash removes the images with the Hash within the Hamming distance threshold of the earlier image,
+// the first image is kept. Images without the hash are never removed.
+func (list *Images) DedupeByHash(threshold int) *Images {
+
+	// the groups are in the order of their first image
+	var unique []*Image
+	for _, group := range groupByHash(list.items, threshold) {
+		unique = append(unique, group[0])
+	}
+
+	list.items = unique
+	return list
+}
+
+// SharedImage is the image found in several articles, possibly under different URLs.
+type SharedImage struct {
+	// Hash of the first occurrence.
+	Hash string `json:"hash"`
+	// Images are the occurrences of the image in the order of the articles.
+	Images []*Image `json:"images"`
+	// ArticleIDs are the unique IDs of the articles with the image.
+	ArticleIDs []string `json:"article_ids"`
+}
+
+// URLs returns the unique URLs of the shared image.
+func (s SharedImage) URLs() []string {
+
+	var urls []string
+	seen := make(map[string]struct{}, len(s.Images))
+
+	for _, img := range s.Images {
+		if _, ok := seen[img.URL]; !ok {
+			seen[img.URL] = struct{}{}
+			urls = append(urls, img.URL)
+		}
+	}
+
+	return urls
+}
+
+// SharedImages reports the images with the Hash within the Hamming distance threshold
+// found in more than one article, the most shared images first.
+func (list *Articles) SharedImages(threshold int) []SharedImage {
+
+	var images []*Image
+	owners := make(map[*Image]string)
+
+	for _, a := range list.items {
+		if a.Images == nil {
+			continue
+		}
+		for _, img := range a.Images.Slice() {
+			if _, ok := owners[img]; !ok {
+				owners[img] = a.ID
+				images = append(images, img)
+			}
+		}
+	}
+
+	var shared []SharedImage
+	for _, group := range groupByHash(images, threshold) {
+
+		var ids []string
+		seen := make(map[string]struct{})
+		for _, img := range group {
+			if _, ok := seen[owners[img]]; !ok {
+				seen[owners[img]] = struct{}{}
+				ids = append(ids, owners[img])
+			}
+		}
+
+		if len(ids) > 1 {
+			shared = append(shared, SharedImage{Hash: group[0].Hash, Images: group, ArticleIDs: ids})
+		}
+	}
+
+	sort.SliceStable(shared, func(i, j int) bool {
+		return len(shared[i].ArticleIDs) > len(shared[j].ArticleIDs)
+	})
+
+	return shared
+}
+
+// groupByHash groups the images by the hash distance to the first image of the group,
+// every image without the comparable hash forms its own group
+func groupByHash(images []*Image, threshold int) [][]*Image {
+
+	var groups [][]*Image
+
+next:
+	for _, img := range images {
+		if img.Hash != "" {
+			for idx, group := range groups {
+				if distance, err := HashDistance(group[0].Hash, img.Hash); err == nil && distance <= threshold {
+					groups[idx] = append(group, img)
+					continue next
+				}
+			}
+		}
+		groups = append(groups, []*Image{img})
+	}
+
+	return groups
+}
+
+// averageHash sets the bit of the pixel brighter than the mean of 8x8 thumbnail
+func averageHash(img image.Image) uint64 {
+
+	pixels := grayscale(img, 8, 8)
+
+	var mean float64
+	for _, p := range pixels {
+		mean += p
+	}
+	mean /= float64(len(pixels))
+
+	var hash uint64
+	for idx, p := range pixels {
+		if p > mean {
+			hash |= 1 << uint(63-idx)
+		}
+	}
+
+	return hash
+}
+
+// differenceHash sets the bit of the pixel brighter than its right neighbour of 9x8 thumbnail
+func differenceHash(img image.Image) uint64 {
+
+	pixels := grayscale(img, 9, 8)
+
+	var hash uint64
+	bit := 63
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if pixels[y*9+x] > pixels[y*9+x+1] {
+				hash |= 1 << uint(bit)
+			}
+			bit--
+		}
+	}
+
+	return hash
+}
+
+// perceptualHash sets the bit of the 8x8 low frequency DCT coefficient of 32x32 thumbnail above the median,
+// the DC coefficient is excluded from the median
+func perceptualHash(img image.Image) uint64 {
+
+	const size, low = 32, 8
+
+	pixels := grayscale(img, size, size)
+
+	// cosine table of the DCT-II
+	var cos [low][size]float64
+	for u := 0; u < low; u++ {
+		for x := 0; x < size; x++ {
+			cos[u][x] = math.Cos(float64(2*x+1) * float64(u) * math.Pi / (2 * size))
+		}
+	}
+
+	// rows, then columns of the low frequencies only
+	var rows [size][low]float64
+	for y := 0; y < size; y++ {
+		for u := 0; u < low; u++ {
+			var sum float64
+			for x := 0; x < size; x++ {
+				sum += pixels[y*size+x] * cos[u][x]
+			}
+			rows[y][u] = sum
+		}
+	}
+
+	coeffs := make([]float64, 0, low*low)
+	for v := 0; v < low; v++ {
+		for u := 0; u < low; u++ {
+			var sum float64
+			for y := 0; y < size; y++ {
+				sum += rows[y][u] * cos[v][y]
+			}
+			coeffs = append(coeffs, sum)
+		}
+	}
+
+	sorted := append([]float64(nil), coeffs[1:]...)
+	sort.Float64s(sorted)
+	median := (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+
+	var hash uint64
+	for idx, c := range coeffs {
+		if c > median {
+			hash |= 1 << uint(63-idx)
+		}
+	}
+
+	return hash
+}
+
+// grayscale downsamples the image to the luminance thumbnail of the size by averaging the source pixels
+func grayscale(img image.Image, width, height int) []float64 {
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	sums := make([]float64, width*height)
+	counts := make([]float64, width*height)
+
+	if w == 0 || h == 0 {
+		return sums
+	}
+
+	for y := 0; y < h; y++ {
+		ty := y * height / h
+		for x := 0; x < w; x++ {
+			tx := x * width / w
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			sums[ty*width+tx] += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+			counts[ty*width+tx]++
+		}
+	}
+
+	// the image smaller than the thumbnail is upsampled by the nearest pixel
+	for ty := 0; ty < height; ty++ {
+		for tx := 0; tx < width; tx++ {
+			idx := ty*width + tx
+			if counts[idx] > 0 {
+				sums[idx] /= counts[idx]
+				continue
+			}
+			r, g, b, _ := img.At(bounds.Min.X+tx*w/width, bounds.Min.Y+ty*h/height).RGBA()
+			sums[idx] = 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+		}
+	}
+
+	return sums
+}
@@ -0,0 +1,246 @@
+package article_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+// pattern draws the image of the size with the shapes of the seed,
+// the same seed gives the same picture at any size
+func pattern(width, height, seed int) image.Image {
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			fx, fy := float64(x)/float64(width), float64(y)/float64(height)
+			var v float64
+			switch seed {
+			case 1:
+				v = fx * 255
+				if fx > 0.3 && fx < 0.6 && fy > 0.2 && fy < 0.5 {
+					v = 255 - v
+				}
+			case 2:
+				v = fy * 255
+				if (fx-0.5)*(fx-0.5)+(fy-0.5)*(fy-0.5) < 0.05 {
+					v = 30
+				}
+			default:
+				v = 128
+				if (int(fx*4)+int(fy*4))%2 == 0 {
+					v = 220
+				}
+			}
+			img.Set(x, y, color.Gray{Y: uint8(v)})
+		}
+	}
+
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, img image.Image, quality int) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}))
+	return buf.Bytes()
+}
+
+func TestImageHash(t *testing.T) {
+
+	algorithms := []article.HashAlgorithm{article.HashAverage, article.HashDifference, article.HashPerceptual}
+
+	for _, alg := range algorithms {
+		t.Run(string(alg), func(t *testing.T) {
+
+			hash := func(data []byte) string {
+				img := &article.Image{}
+				require.NoError(t, img.ComputeHash(bytes.NewReader(data), alg))
+				return img.Hash
+			}
+
+			original := hash(encodePNG(t, pattern(640, 480, 1)))
+			same := hash(encodePNG(t, pattern(640, 480, 1)))
+			resized := hash(encodeJPEG(t, pattern(320, 240, 1), 60))
+			other := hash(encodePNG(t, pattern(640, 480, 3)))
+
+			assert.Regexp(t, "^"+string(alg)+":[0-9a-f]{16}$", original)
+
+			distance, err := article.HashDistance(original, same)
+			require.NoError(t, err)
+			assert.Zero(t, distance)
+
+			distance, err = article.HashDistance(original, resized)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, distance, 6)
+
+			distance, err = article.HashDistance(original, other)
+			require.NoError(t, err)
+			assert.Greater(t, distance, 10)
+		})
+	}
+
+	t.Run("incomparable", func(t *testing.T) {
+		_, err := article.HashDistance("ahash:00000000000000ff", "phash:00000000000000ff")
+		assert.ErrorIs(t, err, article.ErrHashMismatch)
+
+		_, err = article.HashDistance("ahash:zz", "ahash:00")
+		assert.ErrorIs(t, err, article.ErrHashMismatch)
+	})
+
+	t.Run("not image", func(t *testing.T) {
+		img := &article.Image{}
+		assert.Error(t, img.ComputeHash(bytes.NewReader([]byte("<html></html>")), article.HashPerceptual))
+		assert.Empty(t, img.Hash)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		defer func(limit int64) { article.MaxImagePixels = limit }(article.MaxImagePixels)
+		article.MaxImagePixels = 640*480 - 1
+
+		img := &article.Image{}
+		assert.ErrorIs(t, img.ComputeHash(bytes.NewReader(encodePNG(t, pattern(640, 480, 1))), article.HashPerceptual), article.ErrImageTooLarge)
+		assert.Empty(t, img.Hash)
+
+		require.NoError(t, img.ComputeHash(bytes.NewReader(encodePNG(t, pattern(320, 240, 1))), article.HashPerceptual))
+		assert.NotEmpty(t, img.Hash)
+	})
+}
+
+func TestImages_DedupeByHash(t *testing.T) {
+
+	hashed := func(url, hash string) *article.Image {
+		img := article.NewImage(url)
+		img.Hash = hash
+		return img
+	}
+
+	images := article.NewImages(
+		hashed("https://cdn1.example.com/a.jpg", "phash:ff00ff00ff00ff00"),
+		hashed("https://cdn1.example.com/b.jpg", "phash:00ff00ff00ff00ff"),
+		hashed("https://cdn2.example.com/a.jpg", "phash:ff00ff00ff00ff01"),
+		hashed("https://cdn2.example.com/c.jpg", ""),
+		hashed("https://cdn3.example.com/c.jpg", ""),
+		hashed("https://cdn3.example.com/a.jpg", "phash:ff00ff00ff00fff0"),
+	)
+
+	images.DedupeByHash(2)
+
+	var urls []string
+	for _, img := range images.Slice() {
+		urls = append(urls, img.URL)
+	}
+
+	assert.Equal(t, []string{
+		"https://cdn1.example.com/a.jpg",
+		"https://cdn1.example.com/b.jpg",
+		"https://cdn2.example.com/c.jpg",
+		"https://cdn3.example.com/c.jpg",
+		"https://cdn3.example.com/a.jpg",
+	}, urls)
+}
+
+func TestArticles_SharedImages(t *testing.T) {
+
+	hashed := func(url, hash string) *article.Image {
+		img := article.NewImage(url)
+		img.Hash = hash
+		return img
+	}
+
+	a1 := article.NewArticle()
+	a1.Images.Add(
+		hashed("https://cdn1.example.com/a.jpg", "phash:ff00ff00ff00ff00"),
+		hashed("https://cdn1.example.com/b.jpg", "phash:00ff00ff00ff00ff"),
+	)
+
+	a2 := article.NewArticle()
+	a2.Images.Add(
+		hashed("https://cdn2.example.com/a.jpg", "phash:ff00ff00ff00ff01"),
+		hashed("https://cdn2.example.com/a-copy.jpg", "phash:ff00ff00ff00ff00"),
+		hashed("https://cdn2.example.com/b.jpg", "phash:00ff00ff00ff00ff"),
+	)
+
+	a3 := article.NewArticle()
+	a3.Images.Add(
+		hashed("https://cdn3.example.com/a.jpg", "phash:ff00ff00ff00ff00"),
+		hashed("https://cdn3.example.com/d.jpg", "phash:0f0f0f0f0f0f0f0f"),
+	)
+
+	shared := article.NewArticles(a1, a2, a3).SharedImages(2)
+	require.Len(t, shared, 2)
+
+	assert.Equal(t, "phash:ff00ff00ff00ff00", shared[0].Hash)
+	assert.Equal(t, []string{a1.ID, a2.ID, a3.ID}, shared[0].ArticleIDs)
+	assert.Len(t, shared[0].Images, 4)
+	assert.Equal(t, []string{
+		"https://cdn1.example.com/a.jpg",
+		"https://cdn2.example.com/a.jpg",
+		"https://cdn2.example.com/a-copy.jpg",
+		"https://cdn3.example.com/a.jpg",
+	}, shared[0].URLs())
+
+	assert.Equal(t, []string{a1.ID, a2.ID}, shared[1].ArticleIDs)
+}
+
+func TestProber_Hash(t *testing.T) {
+
+	files := map[string][]byte{
+		"/a.png": encodePNG(t, pattern(640, 480, 1)),
+		"/a.jpg": encodeJPEG(t, pattern(800, 600, 1), 80),
+		"/b.png": encodePNG(t, pattern(640, 480, 2)),
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(files[r.URL.Path])
+	}))
+	defer srv.Close()
+
+	images := article.NewImages(
+		article.NewImage(srv.URL+"/a.png"),
+		article.NewImage(srv.URL+"/a.jpg"),
+		article.NewImage(srv.URL+"/b.png"),
+	)
+
+	prober := article.NewProber(srv.Client())
+	prober.Hash = article.HashPerceptual
+
+	for _, result := range prober.ProbeImages(context.Background(), images) {
+		require.Equal(t, article.ProbeOK, result.Status)
+		assert.NotEmpty(t, result.Hash)
+	}
+
+	assert.Equal(t, 800, images.Slice()[1].Width)
+	assert.Equal(t, 600, images.Slice()[1].Height)
+
+	images.DedupeByHash(6)
+	require.Equal(t, 2, images.Len())
+	assert.Equal(t, srv.URL+"/b.png", images.Slice()[1].URL)
+
+	// the images over the pixel limit are not decoded
+	defer func(limit int64) { article.MaxImagePixels = limit }(article.MaxImagePixels)
+	article.MaxImagePixels = 640 * 480
+
+	results := prober.ProbeImages(context.Background(), article.NewImages(article.NewImage(srv.URL+"/a.jpg")))
+	require.Len(t, results, 1)
+	assert.Equal(t, article.ProbeBroken, results[0].Status)
+	assert.ErrorIs(t, results[0].Err, article.ErrImageTooLarge)
+	assert.Empty(t, results[0].Hash)
+}
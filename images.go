@@ -1,136 +1,59 @@
 package article
 
-import (
-	"encoding/json"
-	"log"
-	"log/slog"
-)
-
 // Images represents a collection of Image pointers
 type Images struct {
-	items []*Image
-}
-
-// NewImagesStrict creates a collection and validates every image
-func NewImagesStrict(images ...*Image) (*Images, error) {
-
-	var valid []*Image
-
-	for _, image := range images {
-
-		if err := validate.Struct(image); err != nil {
-			return nil, err
-		}
-
-		valid = append(valid, image)
-	}
-
-	return &Images{items: valid}, nil
+	Collection[*Image]
 }
 
 // NewImages creates a collection, skips invalid items, and logs errors
 func NewImages(images ...*Image) *Images {
-
-	var valid []*Image
-
-	for _, image := range images {
-		if err := validate.Struct(image); err == nil {
-			valid = append(valid, image)
-		} else {
-			slog.Debug("Invalid image skipped: %v", err)
-		}
-	}
-
-	return &Images{items: valid}
+	return &Images{Collection: *NewCollection(images...)}
 }
 
-// Normalize validates and trims the fields of all items
-func (list *Images) Normalize() {
-	for _, img := range list.items {
-		img.Normalize()
-	}
-}
+// NewImagesStrict creates a collection and validates every item
+func NewImagesStrict(images ...*Image) (*Images, error) {
 
-// Get returns the image by ID
-func (list *Images) Get(id string) (*Image, bool) {
-	for _, img := range list.items {
-		if img.ID == id {
-			return img, true
-		}
+	list, err := NewCollectionStrict(images...)
+	if err != nil {
+		return nil, err
 	}
-	return nil, false
-}
 
-// Slice returns a slice of all items
-func (list *Images) Slice() []*Image {
-	return list.items
+	return &Images{Collection: *list}, nil
 }
 
-// Add adds items to the collection
+// Add adds items to the collection, invalid items are skipped
 func (list *Images) Add(images ...*Image) *Images {
-	for _, img := range images {
-		if img != nil && img.ID != "" {
-			list.items = append(list.items, img)
-		} else {
-			log.Printf("Invalid image skipped: %+v", img)
-		}
-	}
+	list.Collection.Add(images...)
 	return list
 }
 
 // Remove removes items by ID
 func (list *Images) Remove(ids ...string) *Images {
-
-	if len(ids) == 0 {
-		return list
-	}
-
-	idSet := make(map[string]struct{}, len(ids))
-	for _, id := range ids {
-		idSet[id] = struct{}{}
-	}
-
-	var filteredImages []*Image
-	for _, img := range list.items {
-		if _, found := idSet[img.ID]; !found {
-			filteredImages = append(filteredImages, img)
-		}
-	}
-
-	list.items = filteredImages
+	list.Collection.Remove(ids...)
 	return list
 }
 
-// IDs returns a slice of all image IDs
-func (list *Images) IDs() []string {
-	ids := make([]string, len(list.items))
-	for idx, img := range list.items {
-		ids[idx] = img.ID
-	}
-	return ids
+// Filter returns a new Images collection filtered by the provided functions
+func (list *Images) Filter(fns ...func(*Image) bool) *Images {
+	return &Images{Collection: *list.Collection.Filter(fns...)}
 }
 
-// Len returns the number of items
-func (list *Images) Len() int {
-	return len(list.items)
+// Sort sorts the images in place by the comparison function, see Collection.Sort
+func (list *Images) Sort(cmp func(a, b *Image) int) *Images {
+	list.Collection.Sort(cmp)
+	return list
 }
 
-// Filter returns a new Images collection filtered by the provided functions
-func (list *Images) Filter(fns ...func(*Image) bool) *Images {
-	var filteredImages []*Image
-	for _, img := range list.items {
-		include := true
-		for _, fn := range fns {
-			if !fn(img) {
-				include = false
-				break
-			}
-		}
-		if include {
-			filteredImages = append(filteredImages, img)
-		}
-	}
-	return &Images{items: filteredImages}
+// Dedupe removes the images with the duplicate ID, the first image is kept
+func (list *Images) Dedupe() *Images {
+	list.Collection.Dedupe()
+	return list
+}
+
+// DedupeFunc removes the images with the duplicate key, the first image is kept
+func (list *Images) DedupeFunc(key func(*Image) string) *Images {
+	list.Collection.DedupeFunc(key)
+	return list
 }
 
 // ReplaceURLs replaces the URLs of the Images from the provided map.
@@ -161,23 +84,3 @@ func (list *Images) ReplaceOrRemoveURLs(m map[string]string) []string {
 	list.Remove(failed...)
 	return failed
 }
-
-// UnmarshalJSON to array of items using encoding/json
-func (list *Images) UnmarshalJSON(data []byte) error {
-
-	// Unmarshal to a slice of Image
-	var images []*Image
-	if err := json.Unmarshal(data, &images); err != nil {
-		return err
-	}
-
-	// Create a new Images collection
-	*list = *NewImages(images...)
-
-	return nil
-}
-
-// MarshalJSON from array of items using encoding/json
-func (list *Images) MarshalJSON() ([]byte, error) {
-	return json.Marshal(list.items)
-}
@@ -0,0 +1,234 @@
+package article
+
+import (
+	"encoding/json"
+	"html"
+	"io"
+	"strings"
+)
+
+// JSONFeedVersion is the version URL of the JSON Feed format.
+const JSONFeedVersion = "https://jsonfeed.org/version/1.1"
+
+type jsonFeed struct {
+	Version     string           `json:"version"`
+	Title       string           `json:"title"`
+	HomePageURL string           `json:"home_page_url,omitempty"`
+	FeedURL     string           `json:"feed_url,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Icon        string           `json:"icon,omitempty"`
+	Language    string           `json:"language,omitempty"`
+	Authors     []jsonFeedAuthor `json:"authors,omitempty"`
+	Items       []jsonFeedItem   `json:"items"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonFeedItem struct {
+	ID            string               `json:"id"`
+	URL           string               `json:"url,omitempty"`
+	Title         string               `json:"title,omitempty"`
+	ContentHTML   string               `json:"content_html,omitempty"`
+	ContentText   string               `json:"content_text,omitempty"`
+	Summary       string               `json:"summary,omitempty"`
+	Image         string               `json:"image,omitempty"`
+	DatePublished string               `json:"date_published,omitempty"`
+	DateModified  string               `json:"date_modified,omitempty"`
+	Authors       []jsonFeedAuthor     `json:"authors,omitempty"`
+	Tags          []string             `json:"tags,omitempty"`
+	Language      string               `json:"language,omitempty"`
+	Attachments   []jsonFeedAttachment `json:"attachments,omitempty"`
+}
+
+type jsonFeedAttachment struct {
+	URL         string `json:"url"`
+	MimeType    string `json:"mime_type"`
+	Title       string `json:"title,omitempty"`
+	SizeInBytes int    `json:"size_in_bytes,omitempty"`
+}
+
+// WriteJSONFeed writes the Articles as JSON Feed 1.1.
+// The first image becomes the item image, videos and medias become attachments.
+func (list *Articles) WriteJSONFeed(w io.Writer, feed Feed) error {
+
+	doc := jsonFeed{
+		Version:     JSONFeedVersion,
+		Title:       feed.Title,
+		HomePageURL: feed.Link,
+		FeedURL:     feed.FeedURL,
+		Description: feed.Description,
+		Icon:        feed.ImageURL,
+		Language:    feed.Language,
+		Items:       make([]jsonFeedItem, 0, list.Len()),
+	}
+
+	if feed.Author != "" {
+		doc.Authors = append(doc.Authors, jsonFeedAuthor{Name: feed.Author})
+	}
+
+	for _, a := range list.Slice() {
+		doc.Items = append(doc.Items, jsonFeedArticle(a))
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+
+	return enc.Encode(doc)
+}
+
+func jsonFeedArticle(a *Article) jsonFeedItem {
+
+	item := jsonFeedItem{
+		ID:            a.ID,
+		URL:           a.SourceURL,
+		Title:         a.Title,
+		ContentHTML:   a.Markup,
+		ContentText:   a.Text,
+		Summary:       a.Summary,
+		DatePublished: atomTime(a.Published),
+		DateModified:  atomTime(a.Modified),
+		Tags:          a.categories(),
+		Language:      a.Language,
+	}
+
+	if a.Images.Len() > 0 {
+		item.Image = a.Images.Slice()[0].URL
+	}
+
+	for _, name := range a.authors() {
+		item.Authors = append(item.Authors, jsonFeedAuthor{Name: name})
+	}
+
+	for _, video := range a.Videos.Slice() {
+		item.Attachments = append(item.Attachments, jsonFeedAttachment{
+			URL:      video.URL,
+			MimeType: jsonFeedMimeType(video.URL, "video/mp4"),
+			Title:    video.Title,
+		})
+	}
+
+	if a.Medias != nil {
+		for _, media := range a.Medias.Slice() {
+			item.Attachments = append(item.Attachments, jsonFeedAttachment{
+				URL:         media.URL,
+				MimeType:    jsonFeedMimeType(media.URL, "application/octet-stream"),
+				Title:       media.Title,
+				SizeInBytes: media.Size,
+			})
+		}
+	}
+
+	return item
+}
+
+// jsonFeedMimeType returns the MIME type of the URL or the fallback, the mime_type is required by JSON Feed
+func jsonFeedMimeType(rawURL, fallback string) string {
+	if typ := mimeType(rawURL); typ != "" {
+		return typ
+	}
+	return fallback
+}
+
+type jsonFeedParse struct {
+	Version     string              `json:"version"`
+	Title       string              `json:"title"`
+	HomePageURL string              `json:"home_page_url"`
+	FeedURL     string              `json:"feed_url"`
+	Description string              `json:"description"`
+	Icon        string              `json:"icon"`
+	Language    string              `json:"language"`
+	Author      *jsonFeedAuthor     `json:"author"`
+	Authors     []jsonFeedAuthor    `json:"authors"`
+	Items       []jsonFeedParseItem `json:"items"`
+}
+
+type jsonFeedParseItem struct {
+	jsonFeedItem
+	ExternalURL string          `json:"external_url"`
+	BannerImage string          `json:"banner_image"`
+	Author      *jsonFeedAuthor `json:"author"`
+}
+
+// parseJSONFeed parses JSON Feed 1.0 and 1.1 documents
+func parseJSONFeed(data []byte) (Feed, []feedItem, error) {
+
+	var doc jsonFeedParse
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return Feed{}, nil, err
+	}
+
+	if !strings.HasPrefix(doc.Version, "https://jsonfeed.org/version/") {
+		return Feed{}, nil, ErrUnknownFeed
+	}
+
+	feed := Feed{
+		Title:       doc.Title,
+		Link:        doc.HomePageURL,
+		FeedURL:     doc.FeedURL,
+		Description: doc.Description,
+		ImageURL:    doc.Icon,
+		Language:    doc.Language,
+	}
+
+	// author is deprecated in 1.1
+	if doc.Author != nil {
+		doc.Authors = append(doc.Authors, *doc.Author)
+	}
+
+	if len(doc.Authors) > 0 {
+		feed.Author = doc.Authors[0].Name
+	}
+
+	items := make([]feedItem, 0, len(doc.Items))
+	for _, entry := range doc.Items {
+		items = append(items, entry.item())
+	}
+
+	return feed, items, nil
+}
+
+func (entry jsonFeedParseItem) item() feedItem {
+
+	item := feedItem{
+		title:     html.EscapeString(entry.Title),
+		link:      entry.URL,
+		summary:   html.EscapeString(entry.Summary),
+		markup:    entry.ContentHTML,
+		text:      entry.ContentText,
+		image:     entry.Image,
+		language:  entry.Language,
+		tags:      entry.Tags,
+		published: feedTime(entry.DatePublished),
+		modified:  feedTime(entry.DateModified),
+	}
+
+	if item.link == "" {
+		item.link = entry.ExternalURL
+	}
+
+	if entry.Author != nil {
+		entry.Authors = append(entry.Authors, *entry.Author)
+	}
+
+	for _, author := range entry.Authors {
+		item.authors = append(item.authors, author.Name)
+	}
+
+	if entry.BannerImage != "" {
+		item.media = append(item.media, feedMedia{url: entry.BannerImage, medium: "image"})
+	}
+
+	for _, attachment := range entry.Attachments {
+		item.media = append(item.media, feedMedia{
+			url:   attachment.URL,
+			typ:   attachment.MimeType,
+			title: attachment.Title,
+			size:  attachment.SizeInBytes,
+		})
+	}
+
+	return item
+}
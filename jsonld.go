@@ -0,0 +1,606 @@
+package article
+
+import (
+	"encoding/json"
+	"errors"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schema.org types of the article.
+const (
+	SchemaArticle     = "Article"
+	SchemaNewsArticle = "NewsArticle"
+	SchemaBlogPosting = "BlogPosting"
+)
+
+// ErrNoJSONLDArticle is returned when the JSON-LD document has no article node.
+var ErrNoJSONLDArticle = errors.New("no article found in JSON-LD")
+
+// LinkedData is a schema.org Article, NewsArticle or BlogPosting in JSON-LD.
+type LinkedData struct {
+	Context          string           `json:"@context"`
+	Type             string           `json:"@type"`
+	URL              string           `json:"url,omitempty"`
+	MainEntityOfPage string           `json:"mainEntityOfPage,omitempty"`
+	Headline         string           `json:"headline"`
+	Description      string           `json:"description,omitempty"`
+	ArticleBody      string           `json:"articleBody,omitempty"`
+	ArticleSection   string           `json:"articleSection,omitempty"`
+	Genre            string           `json:"genre,omitempty"`
+	Keywords         string           `json:"keywords,omitempty"`
+	InLanguage       string           `json:"inLanguage,omitempty"`
+	DatePublished    string           `json:"datePublished,omitempty"`
+	DateModified     string           `json:"dateModified,omitempty"`
+	Image            []LinkedImage    `json:"image,omitempty"`
+	Video            []LinkedVideo    `json:"video,omitempty"`
+	Author           []LinkedPerson   `json:"author,omitempty"`
+	Editor           []LinkedPerson   `json:"editor,omitempty"`
+	Translator       []LinkedPerson   `json:"translator,omitempty"`
+	Contributor      []LinkedPerson   `json:"contributor,omitempty"`
+	Publisher        *LinkedPublisher `json:"publisher,omitempty"`
+}
+
+// LinkedImage is a schema.org ImageObject.
+type LinkedImage struct {
+	Type    string `json:"@type"`
+	URL     string `json:"url"`
+	Name    string `json:"name,omitempty"`
+	Caption string `json:"caption,omitempty"`
+	Width   int    `json:"width,omitempty"`
+	Height  int    `json:"height,omitempty"`
+}
+
+// LinkedVideo is a schema.org VideoObject.
+type LinkedVideo struct {
+	Type         string `json:"@type"`
+	Name         string `json:"name,omitempty"`
+	ContentURL   string `json:"contentUrl"`
+	EmbedURL     string `json:"embedUrl,omitempty"`
+	ThumbnailURL string `json:"thumbnailUrl,omitempty"`
+	Duration     string `json:"duration,omitempty"`
+}
+
+// LinkedPerson is a schema.org Person.
+type LinkedPerson struct {
+	Type   string   `json:"@type"`
+	Name   string   `json:"name"`
+	Image  string   `json:"image,omitempty"`
+	SameAs []string `json:"sameAs,omitempty"`
+}
+
+// LinkedPublisher is a schema.org Organization.
+type LinkedPublisher struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// LinkedData converts the Article to schema.org JSON-LD of the given type,
+// e.g. SchemaNewsArticle. The type defaults to SchemaArticle.
+func (a *Article) LinkedData(schemaType string) *LinkedData {
+
+	if schemaType == "" {
+		schemaType = SchemaArticle
+	}
+
+	ld := &LinkedData{
+		Context:          "https://schema.org",
+		Type:             schemaType,
+		URL:              a.SourceURL,
+		MainEntityOfPage: a.SourceURL,
+		Headline:         a.Title,
+		Description:      a.Summary,
+		ArticleBody:      a.Text,
+		ArticleSection:   a.Category,
+		Genre:            a.Genre,
+		Keywords:         strings.Join(a.Tags.Slice(), ", "),
+		InLanguage:       a.Language,
+		DatePublished:    ldTime(a.Published),
+		DateModified:     ldTime(a.Modified),
+	}
+
+	for _, img := range a.Images.Slice() {
+		ld.Image = append(ld.Image, LinkedImage{
+			Type:    "ImageObject",
+			URL:     img.URL,
+			Name:    img.Title,
+			Caption: img.Alt,
+			Width:   img.Width,
+			Height:  img.Height,
+		})
+	}
+
+	for _, video := range a.Videos.Slice() {
+		linked := LinkedVideo{
+			Type:         "VideoObject",
+			Name:         video.Title,
+			ContentURL:   video.URL,
+			ThumbnailURL: video.Thumbnail,
+			Duration:     ldDuration(video.Duration),
+		}
+		if provider := VideoProviderByName(video.Provider); provider != nil {
+			linked.EmbedURL = provider.Embed(video.ProviderID)
+		}
+		ld.Video = append(ld.Video, linked)
+	}
+
+	for _, person := range a.Contributors.Slice() {
+		switch person.Role {
+		case RoleAuthor:
+			ld.Author = append(ld.Author, ldPerson(person))
+		case RoleEditor:
+			ld.Editor = append(ld.Editor, ldPerson(person))
+		case RoleTranslator:
+			ld.Translator = append(ld.Translator, ldPerson(person))
+		default:
+			ld.Contributor = append(ld.Contributor, ldPerson(person))
+		}
+	}
+
+	// byline without contributors
+	if len(ld.Author) == 0 && a.Author != "" {
+		ld.Author = append(ld.Author, LinkedPerson{Type: "Person", Name: a.Author})
+	}
+
+	if a.SourceName != "" {
+		ld.Publisher = &LinkedPublisher{Type: "Organization", Name: a.SourceName}
+		if u, err := url.Parse(a.SourceURL); err == nil && u.Host != "" {
+			ld.Publisher.URL = u.Scheme + "://" + u.Host
+		}
+	}
+
+	return ld
+}
+
+// JSONLD returns the Article as schema.org JSON-LD of the given type, e.g. SchemaNewsArticle.
+func (a *Article) JSONLD(schemaType string) ([]byte, error) {
+	return json.Marshal(a.LinkedData(schemaType))
+}
+
+func ldPerson(p *Person) LinkedPerson {
+
+	person := LinkedPerson{Type: "Person", Name: p.Name}
+
+	if p.Images != nil && p.Images.Len() > 0 {
+		person.Image = p.Images.Slice()[0].URL
+	}
+
+	if p.Socials != nil {
+		for _, social := range p.Socials.Slice() {
+			person.SameAs = append(person.SameAs, social.URL)
+		}
+	}
+
+	return person
+}
+
+func ldTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// ldDuration formats the seconds as ISO 8601 duration, e.g. PT1H2M3S
+func ldDuration(seconds int) string {
+
+	if seconds <= 0 {
+		return ""
+	}
+
+	d := "PT"
+	if h := seconds / 3600; h > 0 {
+		d += strconv.Itoa(h) + "H"
+	}
+	if m := seconds % 3600 / 60; m > 0 {
+		d += strconv.Itoa(m) + "M"
+	}
+	if sec := seconds % 60; sec > 0 {
+		d += strconv.Itoa(sec) + "S"
+	}
+
+	return d
+}
+
+// ldDurationRe matches ISO 8601 duration of days, hours, minutes and seconds
+var ldDurationRe = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)(?:[.,]\d+)?S)?)?$`)
+
+// ldSeconds parses ISO 8601 duration to seconds, zero if invalid
+func ldSeconds(duration string) int {
+
+	match := ldDurationRe.FindStringSubmatch(strings.ToUpper(duration))
+	if match == nil {
+		return 0
+	}
+
+	seconds := 0
+	for idx, unit := range []int{86400, 3600, 60, 1} {
+		n, _ := strconv.Atoi(match[idx+1])
+		seconds += n * unit
+	}
+
+	return seconds
+}
+
+// ldScriptRe matches <script type="application/ld+json"> blocks
+var ldScriptRe = regexp.MustCompile(`(?is)<script[^>]+type\s*=\s*["']?application/ld\+json["']?[^>]*>(.*?)</script>`)
+
+// ExtractJSONLD returns the contents of every <script type="application/ld+json"> block of the HTML.
+func ExtractJSONLD(markup string) [][]byte {
+
+	matches := ldScriptRe.FindAllStringSubmatch(markup, -1)
+
+	blocks := make([][]byte, 0, len(matches))
+	for _, match := range matches {
+		if block := strings.TrimSpace(match[1]); block != "" {
+			blocks = append(blocks, []byte(block))
+		}
+	}
+
+	return blocks
+}
+
+// NewArticleFromJSONLD creates an Article from the first article node of the JSON-LD document.
+// The Article is not normalized: JSON-LD often lacks articleBody,
+// so the caller is expected to fill the missing fields and call Normalize.
+func NewArticleFromJSONLD(data []byte) (*Article, error) {
+
+	articles, err := NewArticlesFromJSONLD(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if articles.Len() == 0 {
+		return nil, ErrNoJSONLDArticle
+	}
+
+	return articles.Slice()[0], nil
+}
+
+// NewArticlesFromJSONLD creates Articles from every article node of the JSON-LD document.
+// The document could be a single node, an array of nodes or an object with @graph array.
+// References by @id, e.g. author or publisher in @graph, are resolved.
+func NewArticlesFromJSONLD(data []byte) (*Articles, error) {
+
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	g := newLDGraph(doc)
+	articles := NewArticles()
+
+	for _, node := range g.nodes {
+		if ldIsArticle(node) {
+			articles.Add(g.article(node))
+		}
+	}
+
+	return articles, nil
+}
+
+// ldGraph is the flattened list of JSON-LD nodes with @id index
+type ldGraph struct {
+	nodes []map[string]any
+	ids   map[string]map[string]any
+}
+
+func newLDGraph(doc any) *ldGraph {
+	g := &ldGraph{ids: map[string]map[string]any{}}
+	g.add(doc)
+	return g
+}
+
+func (g *ldGraph) add(value any) {
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			g.add(item)
+		}
+	case map[string]any:
+		if graph, ok := v["@graph"]; ok {
+			g.add(graph)
+			return
+		}
+		g.nodes = append(g.nodes, v)
+		if id := ldString(v["@id"]); id != "" {
+			g.ids[id] = v
+		}
+	}
+}
+
+// resolve returns the referenced node for {"@id": "..."} objects
+func (g *ldGraph) resolve(value any) any {
+	node, ok := value.(map[string]any)
+	if !ok || len(node) != 1 {
+		return value
+	}
+	if ref, found := g.ids[ldString(node["@id"])]; found {
+		return ref
+	}
+	return value
+}
+
+// list returns resolved values of the property as a slice
+func (g *ldGraph) list(value any) []any {
+
+	var items []any
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		items = v
+	default:
+		items = []any{v}
+	}
+
+	resolved := make([]any, len(items))
+	for i, item := range items {
+		resolved[i] = g.resolve(item)
+	}
+
+	return resolved
+}
+
+func (g *ldGraph) article(node map[string]any) *Article {
+
+	a := NewArticle()
+
+	a.Title = html.UnescapeString(ldString(node["headline"]))
+	if a.Title == "" {
+		a.Title = html.UnescapeString(ldString(node["name"]))
+	}
+
+	a.Summary = html.UnescapeString(ldString(node["description"]))
+	a.Text = ldString(node["articleBody"])
+	a.Markup = a.Text
+	a.Genre = ldString(node["genre"])
+	a.Published = ldParseTime(node["datePublished"])
+	a.Modified = ldParseTime(node["dateModified"])
+
+	for _, section := range g.list(node["articleSection"]) {
+		if a.Category = ldString(section); a.Category != "" {
+			break
+		}
+	}
+
+	for _, lang := range g.list(node["inLanguage"]) {
+		if a.Language = ldName(lang); a.Language != "" {
+			break
+		}
+	}
+
+	for _, keyword := range g.list(node["keywords"]) {
+		a.Tags.Add(strings.Split(ldString(keyword), ",")...)
+	}
+
+	a.SourceURL = ldString(node["url"])
+	if a.SourceURL == "" {
+		for _, page := range g.list(node["mainEntityOfPage"]) {
+			if a.SourceURL = ldURL(page); a.SourceURL != "" {
+				break
+			}
+		}
+	}
+
+	for _, publisher := range g.list(node["publisher"]) {
+		if a.SourceName = ldName(publisher); a.SourceName != "" {
+			break
+		}
+	}
+
+	for _, image := range g.list(node["image"]) {
+		if img := g.image(image); img != nil {
+			a.Images.Add(img)
+		}
+	}
+
+	for _, video := range g.list(node["video"]) {
+		if v := g.video(video); v != nil {
+			a.Videos.Add(v)
+		}
+	}
+
+	roles := []struct{ key, role string }{
+		{"author", RoleAuthor},
+		{"editor", RoleEditor},
+		{"translator", RoleTranslator},
+		{"contributor", RoleContributor},
+	}
+
+	for _, r := range roles {
+		for _, value := range g.list(node[r.key]) {
+			if person := g.person(value, r.role); person != nil {
+				a.Contributors.Add(person)
+			}
+		}
+	}
+
+	a.Author = a.Contributors.Byline()
+
+	return a
+}
+
+func (g *ldGraph) image(value any) *Image {
+
+	img := NewImage(ldURL(value))
+	if img.URL == "" {
+		return nil
+	}
+
+	if node, ok := value.(map[string]any); ok {
+		img.Title = ldString(node["name"])
+		img.Alt = ldString(node["caption"])
+		if img.Alt == "" {
+			img.Alt = ldString(node["description"])
+		}
+		img.Width = ldInt(node["width"])
+		img.Height = ldInt(node["height"])
+	}
+
+	return img
+}
+
+func (g *ldGraph) video(value any) *Video {
+
+	node, ok := value.(map[string]any)
+	if !ok {
+		if u := ldString(value); u != "" {
+			return NewVideo(u)
+		}
+		return nil
+	}
+
+	video := NewVideo(ldString(node["contentUrl"]))
+	if video.URL == "" {
+		video.URL = ldString(node["embedUrl"])
+	}
+	if video.URL == "" {
+		video.URL = ldString(node["url"])
+	}
+	if video.URL == "" {
+		return nil
+	}
+
+	video.Title = ldString(node["name"])
+	video.Duration = ldSeconds(ldString(node["duration"]))
+
+	if thumbnails := g.list(node["thumbnailUrl"]); len(thumbnails) > 0 {
+		video.Thumbnail = ldURL(thumbnails[0])
+	}
+
+	return video
+}
+
+func (g *ldGraph) person(value any, role string) *Person {
+
+	name := ldName(value)
+	if name == "" {
+		return nil
+	}
+
+	person := NewPerson(name)
+	person.Role = role
+
+	node, ok := value.(map[string]any)
+	if !ok {
+		return person
+	}
+
+	for _, image := range g.list(node["image"]) {
+		if img := g.image(image); img != nil {
+			person.Images.Add(img)
+		}
+	}
+
+	for _, sameAs := range g.list(node["sameAs"]) {
+		if u := ldString(sameAs); u != "" {
+			person.Socials.Add(NewSocial("", u))
+		}
+	}
+
+	return person
+}
+
+// ldArticleTypes are schema.org types of the article nodes
+var ldArticleTypes = map[string]bool{
+	"Article":                  true,
+	"NewsArticle":              true,
+	"BlogPosting":              true,
+	"LiveBlogPosting":          true,
+	"SocialMediaPosting":       true,
+	"Report":                   true,
+	"ScholarlyArticle":         true,
+	"TechArticle":              true,
+	"AnalysisNewsArticle":      true,
+	"AskPublicNewsArticle":     true,
+	"BackgroundNewsArticle":    true,
+	"OpinionNewsArticle":       true,
+	"ReportageNewsArticle":     true,
+	"ReviewNewsArticle":        true,
+	"SatiricalArticle":         true,
+	"AdvertiserContentArticle": true,
+}
+
+func ldIsArticle(node map[string]any) bool {
+
+	var types []any
+	switch v := node["@type"].(type) {
+	case []any:
+		types = v
+	default:
+		types = []any{v}
+	}
+
+	for _, t := range types {
+		name := ldString(t)
+		name = name[strings.LastIndexAny(name, "/:")+1:]
+		if ldArticleTypes[name] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ldString returns the string value, including {"@value": "..."} objects and numbers
+func ldString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]any:
+		return ldString(v["@value"])
+	}
+	return ""
+}
+
+// ldName returns the name of the Person, Organization or Language node, or the string value
+func ldName(value any) string {
+	if node, ok := value.(map[string]any); ok {
+		if name := ldString(node["name"]); name != "" {
+			return name
+		}
+		return ldString(node["alternateName"])
+	}
+	return ldString(value)
+}
+
+// ldURL returns the URL of the node or the string value
+func ldURL(value any) string {
+	if node, ok := value.(map[string]any); ok {
+		for _, key := range []string{"url", "contentUrl", "@id"} {
+			if u := ldString(node[key]); u != "" {
+				return u
+			}
+		}
+		return ""
+	}
+	return ldString(value)
+}
+
+// ldInt returns the number, including numeric strings like "800px" and QuantitativeValue nodes
+func ldInt(value any) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case string:
+		digits := strings.TrimRightFunc(strings.TrimSpace(v), func(r rune) bool { return r < '0' || r > '9' })
+		i, _ := strconv.Atoi(digits)
+		return i
+	case map[string]any:
+		return ldInt(v["value"])
+	}
+	return 0
+}
+
+func ldParseTime(value any) time.Time {
+	t, _ := ParseTime(ldString(value))
+	return t
+}
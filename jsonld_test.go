@@ -0,0 +1,187 @@
+package article_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+func TestArticle_JSONLD(t *testing.T) {
+
+	a := article.NewArticle()
+	a.Title = "The Rise of AI"
+	a.Summary = "How AI is changing industries."
+	a.Text = "Artificial Intelligence is transforming the world."
+	a.Markup = "<p>" + a.Text + "</p>"
+	a.Published = time.Date(2024, 5, 27, 10, 0, 0, 0, time.UTC)
+	a.SourceURL = "https://example.com/news/ai"
+	a.SourceName = "Tech News"
+	a.Language = "en"
+	a.Tags = article.NewTags("AI", "Technology")
+	a.Images.Add(&article.Image{ID: "img-001", URL: "https://example.com/ai.jpg", Alt: "AI", Width: 800, Height: 600})
+	a.Videos.Add(&article.Video{ID: "vid-001", URL: "https://example.com/ai.mp4", Title: "AI explained"})
+	a.Contributors.Add(article.NewPerson("John Doe"))
+
+	data, err := a.JSONLD(article.SchemaNewsArticle)
+	require.NoError(t, err)
+
+	ld := map[string]any{}
+	require.NoError(t, json.Unmarshal(data, &ld))
+
+	assert.Equal(t, "https://schema.org", ld["@context"])
+	assert.Equal(t, "NewsArticle", ld["@type"])
+	assert.Equal(t, "The Rise of AI", ld["headline"])
+	assert.Equal(t, "2024-05-27T10:00:00Z", ld["datePublished"])
+	assert.NotContains(t, ld, "dateModified")
+	assert.Equal(t, "AI, Technology", ld["keywords"])
+	assert.Equal(t, "en", ld["inLanguage"])
+	assert.Equal(t, map[string]any{"@type": "Organization", "name": "Tech News", "url": "https://example.com"}, ld["publisher"])
+	assert.Equal(t, []any{map[string]any{"@type": "Person", "name": "John Doe"}}, ld["author"])
+	assert.Equal(t, []any{map[string]any{
+		"@type":   "ImageObject",
+		"url":     "https://example.com/ai.jpg",
+		"caption": "AI",
+		"width":   800.0,
+		"height":  600.0,
+	}}, ld["image"])
+	assert.Equal(t, []any{map[string]any{
+		"@type":      "VideoObject",
+		"name":       "AI explained",
+		"contentUrl": "https://example.com/ai.mp4",
+	}}, ld["video"])
+
+	// round-trip
+	got, err := article.NewArticleFromJSONLD(data)
+	require.NoError(t, err)
+	require.NoError(t, got.Normalize())
+
+	assert.Equal(t, a.Title, got.Title)
+	assert.Equal(t, a.Summary, got.Summary)
+	assert.Equal(t, a.Text, got.Text)
+	assert.Equal(t, a.Published, got.Published)
+	assert.Equal(t, a.SourceURL, got.SourceURL)
+	assert.Equal(t, a.SourceName, got.SourceName)
+	assert.Equal(t, a.Tags, got.Tags)
+	assert.Equal(t, "John Doe", got.Author)
+	assert.Equal(t, 1, got.Images.Len())
+	assert.Equal(t, 800, got.Images.Slice()[0].Width)
+	assert.Equal(t, 1, got.Videos.Len())
+}
+
+func TestNewArticlesFromJSONLD_Graph(t *testing.T) {
+
+	markup := `<html><head>
+	<script type="application/ld+json">
+	{
+	  "@context": "https://schema.org",
+	  "@graph": [
+		{
+		  "@type": ["Article", "BlogPosting"],
+		  "@id": "https://example.com/post/#article",
+		  "headline": "Tom &amp; Jerry",
+		  "datePublished": "2024-05-27T10:00:00+00:00",
+		  "author": {"@id": "https://example.com/#/schema/person/1"},
+		  "publisher": {"@id": "https://example.com/#organization"},
+		  "image": {"@id": "https://example.com/post/#primaryimage"},
+		  "mainEntityOfPage": {"@id": "https://example.com/post/"},
+		  "keywords": ["cartoons", "cats"],
+		  "articleSection": ["Entertainment"],
+		  "inLanguage": "en-US"
+		},
+		{
+		  "@type": "ImageObject",
+		  "@id": "https://example.com/post/#primaryimage",
+		  "contentUrl": "https://example.com/tom.jpg",
+		  "width": "1200",
+		  "height": 630,
+		  "caption": "Tom"
+		},
+		{
+		  "@type": "Person",
+		  "@id": "https://example.com/#/schema/person/1",
+		  "name": "Jane Smith",
+		  "sameAs": ["https://twitter.com/janesmith"]
+		},
+		{
+		  "@type": "Organization",
+		  "@id": "https://example.com/#organization",
+		  "name": "Example Blog"
+		}
+	  ]
+	}
+	</script>
+	</head></html>`
+
+	blocks := article.ExtractJSONLD(markup)
+	require.Len(t, blocks, 1)
+
+	articles, err := article.NewArticlesFromJSONLD(blocks[0])
+	require.NoError(t, err)
+	require.Equal(t, 1, articles.Len())
+
+	a := articles.Slice()[0]
+	assert.Equal(t, "Tom & Jerry", a.Title)
+	assert.Equal(t, "https://example.com/post/", a.SourceURL)
+	assert.Equal(t, "Example Blog", a.SourceName)
+	assert.Equal(t, "Entertainment", a.Category)
+	assert.Equal(t, "en-US", a.Language)
+	assert.Equal(t, []string{"cartoons", "cats"}, a.Tags.Slice())
+	assert.Equal(t, "Jane Smith", a.Author)
+	assert.Equal(t, 1, a.Contributors.Slice()[0].Socials.Len())
+
+	require.Equal(t, 1, a.Images.Len())
+	img := a.Images.Slice()[0]
+	assert.Equal(t, "https://example.com/tom.jpg", img.URL)
+	assert.Equal(t, 1200, img.Width)
+	assert.Equal(t, 630, img.Height)
+	assert.Equal(t, "Tom", img.Alt)
+}
+
+func TestNewArticleFromJSONLD_NoArticle(t *testing.T) {
+
+	_, err := article.NewArticleFromJSONLD([]byte(`{"@type": "WebSite", "name": "Example"}`))
+	assert.ErrorIs(t, err, article.ErrNoJSONLDArticle)
+
+	_, err = article.NewArticleFromJSONLD([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestArticle_JSONLD_VideoProvider(t *testing.T) {
+
+	a := article.NewArticle()
+	a.Title = "Title"
+	a.Text = "Text"
+	a.Markup = "<p>Text</p>"
+	video := article.NewVideo("https://www.youtube.com/watch?v=dQw4w9WgXcQ")
+	video.Duration = 3723
+	a.Videos.Add(video)
+	require.NoError(t, a.Normalize())
+
+	data, err := a.JSONLD(article.SchemaArticle)
+	require.NoError(t, err)
+
+	ld := map[string]any{}
+	require.NoError(t, json.Unmarshal(data, &ld))
+
+	assert.Equal(t, []any{map[string]any{
+		"@type":        "VideoObject",
+		"contentUrl":   "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+		"embedUrl":     "https://www.youtube.com/embed/dQw4w9WgXcQ",
+		"thumbnailUrl": "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg",
+		"duration":     "PT1H2M3S",
+	}}, ld["video"])
+
+	got, err := article.NewArticleFromJSONLD(data)
+	require.NoError(t, err)
+	require.NoError(t, got.Normalize())
+	require.Equal(t, 1, got.Videos.Len())
+
+	assert.Equal(t, 3723, got.Videos.Slice()[0].Duration)
+	assert.Equal(t, "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg", got.Videos.Slice()[0].Thumbnail)
+	assert.Equal(t, article.ProviderYouTube, got.Videos.Slice()[0].Provider)
+}
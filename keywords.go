@@ -0,0 +1,394 @@
+package article
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Keyword is the key phrase of the text proposed as a tag, see KeywordExtractor.
+type Keyword struct {
+	// Text is the lowercase phrase, e.g. public library.
+	Text string `json:"text"`
+	// Score is the relevance of the phrase from 0 to 1, the best phrase of the text is 1.
+	Score float64 `json:"score"`
+}
+
+// KeywordExtractor proposes the keywords of the Article sorted by the score, the best first.
+// The phrases are split by the punctuation and the Stopwords of the Article.Language,
+// the CJK text without spaces is not supported.
+type KeywordExtractor interface {
+	Keywords(a *Article) []Keyword
+}
+
+// Stopwords are the words never used in the keywords by the primary language subtag, e.g. en.
+// Add own lists or words, the text of the language without the list is split by the punctuation only.
+var Stopwords = DefaultStopwords()
+
+// MaxKeywordWords is the maximum number of the words of the keyword, the longer phrases are skipped.
+var MaxKeywordWords = 3
+
+// RAKE is the Rapid Automatic Keyword Extraction of the single Article:
+// the words of the phrases are scored by the degree to the frequency ratio, the phrase score is the sum of them.
+// It prefers the longer specific phrases, e.g. public library over library.
+type RAKE struct{}
+
+// Keywords proposes the keywords of the Title and the Text.
+func (RAKE) Keywords(a *Article) []Keyword {
+
+	phrases := keywordPhrases(a)
+
+	freq := map[string]float64{}
+	degree := map[string]float64{}
+
+	for _, phrase := range phrases {
+		for _, word := range phrase {
+			freq[word]++
+			degree[word] += float64(len(phrase))
+		}
+	}
+
+	scores := map[string]float64{}
+	for _, phrase := range phrases {
+		score := 0.0
+		for _, word := range phrase {
+			score += degree[word] / freq[word]
+		}
+		scores[strings.Join(phrase, " ")] = score
+	}
+
+	return rankKeywords(scores)
+}
+
+// TextRank is the graph-based keyword extraction of the single Article:
+// the words co-occurring within the Window are linked and ranked like the web pages by PageRank,
+// the phrase score is the sum of the ranks of its words weighted by the frequency of the phrase.
+type TextRank struct {
+	// Window is the distance of the linked words, 2 if zero.
+	Window int
+}
+
+// Keywords proposes the keywords of the Title and the Text.
+func (t TextRank) Keywords(a *Article) []Keyword {
+
+	window := t.Window
+	if window < 2 {
+		window = 2
+	}
+
+	phrases := keywordPhrases(a)
+
+	// the candidate words in the text order
+	var words []string
+	for _, phrase := range phrases {
+		words = append(words, phrase...)
+	}
+
+	links := map[string]map[string]bool{}
+	for i, word := range words {
+		if links[word] == nil {
+			links[word] = map[string]bool{}
+		}
+		for j := i + 1; j < len(words) && j < i+window; j++ {
+			if words[j] == word {
+				continue
+			}
+			if links[words[j]] == nil {
+				links[words[j]] = map[string]bool{}
+			}
+			links[word][words[j]] = true
+			links[words[j]][word] = true
+		}
+	}
+
+	const damping = 0.85
+
+	rank := make(map[string]float64, len(links))
+	for word := range links {
+		rank[word] = 1
+	}
+
+	for iteration := 0; iteration < 30; iteration++ {
+		next := make(map[string]float64, len(links))
+		for word, neighbours := range links {
+			sum := 0.0
+			for neighbour := range neighbours {
+				sum += rank[neighbour] / float64(len(links[neighbour]))
+			}
+			next[word] = 1 - damping + damping*sum
+		}
+		rank = next
+	}
+
+	scores := map[string]float64{}
+	for _, phrase := range phrases {
+		score := 0.0
+		for _, word := range phrase {
+			score += rank[word]
+		}
+		scores[strings.Join(phrase, " ")] += score
+	}
+
+	return rankKeywords(scores)
+}
+
+// Corpus is the TF-IDF keyword extraction against the document frequencies of the Articles:
+// the phrases frequent in the Article and rare in the corpus are scored higher,
+// e.g. the name of the event over the words of every news article.
+// The zero value is the empty corpus ready to use.
+type Corpus struct {
+	docs int
+	df   map[string]int
+}
+
+// NewCorpus counts the document frequencies of the phrases of the articles.
+func NewCorpus(list *Articles) *Corpus {
+
+	c := &Corpus{}
+	c.Add(list.Slice()...)
+
+	return c
+}
+
+// Add counts the document frequencies of the phrases of the articles.
+func (c *Corpus) Add(articles ...*Article) {
+
+	if c.df == nil {
+		c.df = map[string]int{}
+	}
+
+	for _, a := range articles {
+		if a == nil {
+			continue
+		}
+		c.docs++
+		for term := range corpusTerms(keywordPhrases(a)) {
+			c.df[term]++
+		}
+	}
+}
+
+// Len returns the number of the documents of the corpus.
+func (c *Corpus) Len() int {
+	return c.docs
+}
+
+// Keywords proposes the keywords of the Title and the Text, the article does not need to be in the corpus.
+func (c *Corpus) Keywords(a *Article) []Keyword {
+
+	terms := corpusTerms(keywordPhrases(a))
+
+	total := 0
+	for _, count := range terms {
+		total += count
+	}
+
+	scores := map[string]float64{}
+	for term, count := range terms {
+		tf := float64(count) / float64(total)
+		idf := math.Log(float64(1+c.docs)/float64(1+c.df[term])) + 1
+		// the phrases are scored by the sum of the words, so the single words do not outweigh them
+		scores[term] = tf * idf * float64(strings.Count(term, " ")+1)
+	}
+
+	return rankKeywords(scores)
+}
+
+// corpusTerms counts the phrases and their words
+func corpusTerms(phrases [][]string) map[string]int {
+
+	terms := map[string]int{}
+	for _, phrase := range phrases {
+		terms[strings.Join(phrase, " ")]++
+		if len(phrase) > 1 {
+			for _, word := range phrase {
+				terms[word]++
+			}
+		}
+	}
+
+	return terms
+}
+
+// Keywords proposes the keywords of the Article with the extractor, see KeywordExtractor.
+func (a *Article) Keywords(extractor KeywordExtractor) []Keyword {
+	return extractor.Keywords(a)
+}
+
+// ExtractTags merges the keywords proposed by the extractor into the Tags until the Tags have maxTags items,
+// skips the keywords matching the tags, see Tags.Contains, or contained in them, e.g. library in public library,
+// and records the scores of the added tags in the canonical form to the TagScores. Returns the added keywords.
+// The keywords longer than the Tag limit of the DefaultLimits are skipped, see ExtractTagsWithLimits.
+func (a *Article) ExtractTags(extractor KeywordExtractor, maxTags int) []Keyword {
+	return a.ExtractTagsWithLimits(extractor, maxTags, nil)
+}
+
+// ExtractTagsWithLimits merges the keywords into the Tags like ExtractTags, but skips the keywords
+// longer than the Tag limit of the limits, DefaultLimits if nil.
+// Use the limits of the destination, e.g. the short tags of the push notifications.
+func (a *Article) ExtractTagsWithLimits(extractor KeywordExtractor, maxTags int, limits *Limits) []Keyword {
+
+	if a.Tags == nil {
+		a.Tags = NewTags()
+	}
+
+	if limits == nil {
+		limits = DefaultLimits
+	}
+	limit := limits.resolved().Tag
+
+	var added []Keyword
+	for _, keyword := range extractor.Keywords(a) {
+		if a.Tags.Len() >= maxTags {
+			break
+		}
+		tag := CanonicalTag(keyword.Text)
+		if !validTag(tag, limit) || a.Tags.Contains(tag) || a.hasKeyword(keyword.Text) {
+			continue
+		}
+		// the tag invalid for the Tags is skipped by Add
+		if !a.Tags.addWithLimits(limits, tag).Contains(tag) {
+			continue
+		}
+		if a.TagScores == nil {
+			a.TagScores = map[string]float64{}
+		}
+		a.TagScores[tag] = keyword.Score
+		added = append(added, keyword)
+	}
+
+	return added
+}
+
+// tagScoresField drops the scores of the tags missing from the Tags
+func (a *Article) tagScoresField() {
+
+	for tag := range a.TagScores {
+		if a.Tags == nil || !a.Tags.Contains(tag) {
+			delete(a.TagScores, tag)
+		}
+	}
+
+	if len(a.TagScores) == 0 {
+		a.TagScores = nil
+	}
+}
+
+// hasKeyword is true if the phrase matches or is contained in one of the Tags
+func (a *Article) hasKeyword(phrase string) bool {
+
+	padded := " " + phrase + " "
+	for _, tag := range a.Tags.Slice() {
+		if strings.Contains(" "+strings.ToLower(tag)+" ", padded) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// keywordPhrases splits the Title and the Text of the Article into the candidate phrases:
+// the lowercase words between the punctuation and the stopwords, up to MaxKeywordWords,
+// the numbers and the single letters break the phrases too
+func keywordPhrases(a *Article) [][]string {
+
+	stop := map[string]bool{}
+	for _, word := range Stopwords[keywordLanguage(a)] {
+		stop[strings.ToLower(word)] = true
+	}
+
+	var phrases [][]string
+	var phrase []string
+
+	flushPhrase := func() {
+		if len(phrase) > 0 && len(phrase) <= max(MaxKeywordWords, 1) {
+			phrases = append(phrases, phrase)
+		}
+		phrase = nil
+	}
+
+	var word []rune
+	flushWord := func() {
+		for len(word) > 0 && isWordJoiner(word[len(word)-1]) {
+			word = word[:len(word)-1]
+		}
+		if len(word) == 0 {
+			return
+		}
+		w := strings.ToLower(string(word))
+		word = word[:0]
+		if stop[w] || utf8.RuneCountInString(w) < 2 || strings.IndexFunc(w, unicode.IsLetter) < 0 {
+			flushPhrase()
+			return
+		}
+		phrase = append(phrase, w)
+	}
+
+	for _, r := range a.Title + "\n" + a.Text {
+		if r == '’' {
+			r = '\''
+		}
+		switch {
+		case isCJK(r):
+			flushWord()
+			flushPhrase()
+		case unicode.IsLetter(r), unicode.IsDigit(r), unicode.Is(unicode.Mn, r):
+			word = append(word, r)
+		case isWordJoiner(r) && len(word) > 0:
+			word = append(word, r)
+		case r == ' ' || r == '\t':
+			flushWord()
+		default:
+			flushWord()
+			flushPhrase()
+		}
+	}
+	flushWord()
+	flushPhrase()
+
+	return phrases
+}
+
+// keywordLanguage returns the primary subtag of the Article.Language or of the detected language
+func keywordLanguage(a *Article) string {
+
+	language := a.Language
+	if language == "" {
+		language = a.DetectLanguage().Tag
+	}
+
+	primary, _, _ := strings.Cut(strings.ToLower(language), "-")
+	if primary == "no" || primary == "nn" {
+		return "nb"
+	}
+
+	return primary
+}
+
+// rankKeywords sorts the phrases by the score, the ties alphabetically, and scales the scores to the best phrase
+func rankKeywords(scores map[string]float64) []Keyword {
+
+	keywords := make([]Keyword, 0, len(scores))
+	best := 0.0
+	for text, score := range scores {
+		keywords = append(keywords, Keyword{Text: text, Score: score})
+		best = math.Max(best, score)
+	}
+
+	sort.Slice(keywords, func(i, j int) bool {
+		if keywords[i].Score != keywords[j].Score {
+			return keywords[i].Score > keywords[j].Score
+		}
+		return keywords[i].Text < keywords[j].Text
+	})
+
+	if best > 0 {
+		for i := range keywords {
+			keywords[i].Score = roundStat(keywords[i].Score / best)
+		}
+	}
+
+	return keywords
+}
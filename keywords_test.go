@@ -0,0 +1,134 @@
+package article_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+func keywordsArticle() *article.Article {
+	a := article.NewArticle()
+	a.Title = "City council approves new public library"
+	a.Text = "The city council announced on Monday that the new public library will open next spring. " +
+		"According to the mayor, the public library project was delayed because of rising construction costs " +
+		"and a shortage of workers. Local residents have waited for more than five years for the public library. " +
+		"The building will include a reading room for children, a small theatre and a cafe. " +
+		"The construction costs were covered by the city council and a private foundation."
+	a.Markup = "<p>" + a.Text + "</p>"
+	a.Published = time.Now()
+	return a
+}
+
+func keywordTexts(keywords []article.Keyword) []string {
+	texts := make([]string, len(keywords))
+	for i, keyword := range keywords {
+		texts[i] = keyword.Text
+	}
+	return texts
+}
+
+func TestArticle_Keywords(t *testing.T) {
+
+	a := keywordsArticle()
+
+	t.Run("rake", func(t *testing.T) {
+		keywords := a.Keywords(article.RAKE{})
+		require.NotEmpty(t, keywords)
+		assert.Equal(t, 1.0, keywords[0].Score)
+		assert.Contains(t, keywordTexts(keywords[:5]), "rising construction costs")
+		assert.NotContains(t, keywordTexts(keywords), "the", "stopwords are skipped")
+		assert.NotContains(t, keywordTexts(keywords), "new public library", "phrases are split by the stopwords")
+	})
+
+	t.Run("textrank", func(t *testing.T) {
+		keywords := a.Keywords(article.TextRank{})
+		require.NotEmpty(t, keywords)
+		assert.Equal(t, "public library", keywords[0].Text)
+		for i := 1; i < len(keywords); i++ {
+			assert.LessOrEqual(t, keywords[i].Score, keywords[i-1].Score)
+		}
+	})
+
+	t.Run("corpus", func(t *testing.T) {
+		other := article.NewArticle()
+		other.Text = "The city council discussed the budget. The mayor said the city council will vote next week."
+		another := article.NewArticle()
+		another.Text = "The city council met the mayor on Monday to discuss the new park."
+
+		corpus := article.NewCorpus(article.NewArticles(a, other, another))
+		assert.Equal(t, 3, corpus.Len())
+
+		keywords := a.Keywords(corpus)
+		require.NotEmpty(t, keywords)
+		assert.Equal(t, "public library", keywords[0].Text)
+
+		scores := map[string]float64{}
+		for _, keyword := range keywords {
+			scores[keyword.Text] = keyword.Score
+		}
+		assert.Greater(t, scores["library"], scores["council"], "the words of every article are scored lower")
+
+		// the zero corpus is ready to use
+		var zero article.Corpus
+		zero.Add(a, other, another)
+		assert.Equal(t, 3, zero.Len())
+		assert.Equal(t, keywords, a.Keywords(&zero))
+	})
+
+	t.Run("stopwords", func(t *testing.T) {
+		de := article.NewArticle()
+		de.Language = "de-AT"
+		de.Text = "Die neue Bibliothek der Stadt wird im Frühjahr eröffnet, die Bibliothek hat einen Lesesaal."
+		texts := keywordTexts(de.Keywords(article.RAKE{}))
+		assert.Contains(t, texts, "neue bibliothek")
+		assert.Contains(t, texts, "stadt")
+		assert.NotContains(t, texts, "die neue bibliothek")
+	})
+}
+
+func TestArticle_ExtractTags(t *testing.T) {
+
+	a := keywordsArticle()
+	a.Tags.Add("Public Library")
+
+	added := a.ExtractTags(article.TextRank{}, 4)
+	require.Len(t, added, 3)
+	assert.Equal(t, 4, a.Tags.Len())
+	assert.Equal(t, "Public Library", a.Tags.Slice()[0])
+	assert.NotContains(t, a.Tags.Slice(), "public library", "the existing tag matches case-insensitively")
+	assert.NotContains(t, a.Tags.Slice(), "library", "the word of the existing tag is skipped")
+	assert.Len(t, a.TagScores, 3)
+	assert.Equal(t, added[0].Score, a.TagScores[added[0].Text])
+
+	// the tags are full
+	assert.Empty(t, a.ExtractTags(article.RAKE{}, 4))
+
+	fromMap, err := article.NewArticleFromMap(a.Map())
+	require.NoError(t, err)
+	assert.Equal(t, a.TagScores, fromMap.TagScores)
+
+	// the scores of the removed tags are dropped
+	a.Tags.Remove(added[0].Text)
+	require.NoError(t, a.Normalize())
+	assert.Len(t, a.TagScores, 2)
+	assert.NotContains(t, a.TagScores, added[0].Text)
+}
+
+func TestArticle_ExtractTagsWithLimits(t *testing.T) {
+
+	a := keywordsArticle()
+
+	limits := article.NewLimits()
+	limits.Tag = 7
+
+	added := a.ExtractTagsWithLimits(article.RAKE{}, 10, limits)
+	require.NotEmpty(t, added)
+	for _, tag := range a.Tags.Slice() {
+		assert.LessOrEqual(t, len([]rune(tag)), 7)
+	}
+	assert.Len(t, a.TagScores, a.Tags.Len())
+}
@@ -0,0 +1,429 @@
+package article
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// LanguageProfile is the language recognized by DetectLanguage.
+// The languages of the same script are told apart by the trigram profiles built from the Sample,
+// the language with the own script needs no Sample, e.g. Greek.
+type LanguageProfile struct {
+	// Tag is the BCP 47 tag of the language, e.g. en.
+	Tag string
+	// Aliases are the names and the ISO 639-2 codes of the language matched case-insensitively
+	// by NormalizeLanguage, e.g. English, eng.
+	Aliases []string
+	// Script is the name of the unicode.Scripts table of the language, e.g. Latin.
+	Script string
+	// Sample is the text of the language the trigram profile is built from,
+	// a few hundred words of the common vocabulary, e.g. news.
+	Sample string
+
+	once     sync.Once
+	trigrams map[string]int
+}
+
+// DetectedLanguage is the result of DetectLanguage.
+type DetectedLanguage struct {
+	// Tag is the BCP 47 tag of the language, empty if not detected.
+	Tag string `json:"tag"`
+	// Confidence is the confidence of the detection from 0 to 1,
+	// low for short texts and the languages close to the others, e.g. Danish and Norwegian.
+	Confidence float64 `json:"confidence"`
+}
+
+// LanguageProfiles are the languages recognized by DetectLanguage and Normalize.
+// Append own profiles or set to nil to disable the detection.
+var LanguageProfiles = DefaultLanguageProfiles()
+
+// LanguageConfidence is the minimum confidence of the detected language applied by Normalize:
+// the empty Article.Language is filled and the language not matching the content is kept and reported as the mismatch.
+var LanguageConfidence = 0.5
+
+// profileSize is the number of the most frequent trigrams compared by DetectLanguage
+const profileSize = 300
+
+// detectLength is the maximum number of letters of the text analyzed by DetectLanguage
+const detectLength = 4096
+
+// DetectLanguage returns the language of the text from the LanguageProfiles.
+// The script of the letters selects the candidates, e.g. Cyrillic for Russian, Ukrainian, Bulgarian and Serbian,
+// the trigram profile of the text picks the closest of them. The confidence grows with the text length
+// and the distance to the second best language.
+func DetectLanguage(text string) DetectedLanguage {
+
+	scripts, total := countScripts(text)
+	if total == 0 {
+		return DetectedLanguage{}
+	}
+
+	// the most used script
+	script, letters := "", 0
+	for name, count := range scripts {
+		if count > letters || (count == letters && name < script) {
+			script, letters = name, count
+		}
+	}
+
+	var candidates []*LanguageProfile
+	for _, profile := range LanguageProfiles {
+		if profile != nil && profile.Script == script {
+			candidates = append(candidates, profile)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return DetectedLanguage{}
+	}
+
+	// the share of the script and the length of the text
+	confidence := float64(letters) / float64(total) * min(1, float64(letters)/60)
+
+	if len(candidates) == 1 {
+		return DetectedLanguage{Tag: candidates[0].Tag, Confidence: round2(confidence)}
+	}
+
+	doc := rankTrigrams(countTrigrams(text), profileSize)
+	if len(doc) == 0 {
+		return DetectedLanguage{}
+	}
+
+	best, second := -1.0, -1.0
+	var detected *LanguageProfile
+
+	for _, profile := range candidates {
+		d := profile.distance(doc)
+		switch {
+		case best < 0 || d < best:
+			second, best, detected = best, d, profile
+		case second < 0 || d < second:
+			second = d
+		}
+	}
+
+	// the relative gap to the second language, 0.1 of the distance is the confident gap
+	gap := 1.0
+	if second > 0 {
+		gap = min(1, (second-best)/second*10)
+	}
+
+	return DetectedLanguage{Tag: detected.Tag, Confidence: round2(confidence * gap)}
+}
+
+// DetectLanguage detects the language of the Title and the Text, see DetectLanguage.
+func (a *Article) DetectLanguage() DetectedLanguage {
+	return DetectLanguage(a.Title + "\n" + a.Text)
+}
+
+// NormalizeLanguage returns the BCP 47 tag of the language code or name in the canonical case,
+// e.g. EN_us is en-US, pt_br is pt-BR, zh-hans-cn is zh-Hans-CN, Russian and rus are ru.
+// Returns empty string if the value is neither the well-formed tag nor the alias of the LanguageProfiles.
+func NormalizeLanguage(value string) string {
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+
+	if profile := languageByAlias(value); profile != nil {
+		return profile.Tag
+	}
+
+	subtags := strings.FieldsFunc(value, func(r rune) bool { return r == '-' || r == '_' })
+	if len(subtags) == 0 || len(strings.Join(subtags, "-")) != len(value) {
+		return ""
+	}
+
+	// primary language subtag, ISO 639-2 alias replaced with the tag, e.g. rus
+	primary := strings.ToLower(subtags[0])
+	if !isAlpha(primary) || len(primary) < 2 || len(primary) > 3 {
+		return ""
+	}
+	if profile := languageByAlias(primary); profile != nil {
+		primary = profile.Tag
+	}
+
+	tag := []string{primary}
+	private := false
+
+	for idx, subtag := range subtags[1:] {
+		switch {
+		case len(subtag) > 8 || !isAlnum(subtag):
+			return ""
+		case private || len(subtag) == 1:
+			// private use and extensions, e.g. x-custom
+			private = true
+			tag = append(tag, strings.ToLower(subtag))
+		case idx == 0 && len(subtag) == 3 && isAlpha(subtag):
+			// extended language, e.g. zh-yue
+			tag = append(tag, strings.ToLower(subtag))
+		case len(subtag) == 4 && isAlpha(subtag):
+			// script, e.g. Hans
+			tag = append(tag, strings.ToUpper(subtag[:1])+strings.ToLower(subtag[1:]))
+		case len(subtag) == 2 && isAlpha(subtag), len(subtag) == 3 && isDigits(subtag):
+			// region, e.g. US or 419
+			tag = append(tag, strings.ToUpper(subtag))
+		case len(subtag) >= 5, len(subtag) == 4 && subtag[0] >= '0' && subtag[0] <= '9':
+			// variant, e.g. 1901
+			tag = append(tag, strings.ToLower(subtag))
+		default:
+			return ""
+		}
+	}
+
+	return strings.Join(tag, "-")
+}
+
+// validateLanguage is the language tag, the value must be normalized by NormalizeLanguage,
+// e.g. en, EN_us or English, so the stored articles with the language names stay valid.
+func validateLanguage(fl validator.FieldLevel) bool {
+	return NormalizeLanguage(fl.Field().String()) != ""
+}
+
+// languageByAlias returns the profile of the LanguageProfiles by the tag or the alias, nil if not found
+func languageByAlias(value string) *LanguageProfile {
+
+	for _, profile := range LanguageProfiles {
+		if profile == nil {
+			continue
+		}
+		if strings.EqualFold(profile.Tag, value) {
+			return profile
+		}
+		for _, alias := range profile.Aliases {
+			if strings.EqualFold(alias, value) {
+				return profile
+			}
+		}
+	}
+
+	return nil
+}
+
+// sameLanguage compares the primary subtags of the tags, Norwegian Bokmål matches Norwegian
+func sameLanguage(a, b string) bool {
+
+	primary := func(tag string) string {
+		p, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if p == "no" || p == "nn" {
+			return "nb"
+		}
+		return p
+	}
+
+	return primary(a) == primary(b)
+}
+
+// languageField normalizes the Language to the BCP 47 tag, resets the invalid one and fills the empty one
+// with the detected language, falls back to en if the language is not detected.
+// The valid language not matching the content is kept and reported as the mismatch.
+func (a *Article) languageField(report *NormalizeReport) {
+
+	if a.Language != "" {
+		switch tag := NormalizeLanguage(a.Language); {
+		case tag == "":
+			report.Add("Article.Language", "language", a.Language, ActionReset)
+			a.Language = ""
+		case tag != a.Language:
+			report.Add("Article.Language", "language", a.Language, ActionCanonicalized)
+			a.Language = tag
+		}
+	}
+
+	var detected DetectedLanguage
+	if LanguageProfiles != nil {
+		if detected = a.DetectLanguage(); detected.Confidence < LanguageConfidence {
+			detected = DetectedLanguage{}
+		}
+	}
+
+	switch {
+	case detected.Tag == "":
+	case a.Language == "":
+		// the detected language is the default of the empty language
+		report.Add("Article.Language", "language", detected.Confidence, ActionDefault)
+		a.Language = detected.Tag
+	case !sameLanguage(a.Language, detected.Tag):
+		// the language supplied by the caller is kept
+		report.Add("Article.Language", "language", detected.Tag, ActionMismatch)
+	}
+
+	if a.Language == "" {
+		a.Language = "en"
+		report.fallback("Article.Language", "")
+	}
+}
+
+// profile builds the trigram ranks of the Sample once
+func (p *LanguageProfile) profile() map[string]int {
+
+	p.once.Do(func() {
+		p.trigrams = map[string]int{}
+		for rank, trigram := range rankTrigrams(countTrigrams(p.Sample), profileSize) {
+			p.trigrams[trigram] = rank
+		}
+	})
+
+	return p.trigrams
+}
+
+// distance is the out-of-place measure of the ranked trigrams of the text from 0 to 1, 0 is the same order
+func (p *LanguageProfile) distance(doc []string) float64 {
+
+	trigrams := p.profile()
+	if len(trigrams) == 0 {
+		return 1
+	}
+
+	total := 0
+	for rank, trigram := range doc {
+		if r, ok := trigrams[trigram]; ok {
+			total += abs(rank - r)
+		} else {
+			total += profileSize
+		}
+	}
+
+	return float64(total) / float64(len(doc)*profileSize)
+}
+
+// countScripts counts the letters of the text by the scripts of the LanguageProfiles, up to detectLength letters.
+// Katakana is counted as Hiragana and the Han characters of the text with kana are Japanese, counted as Hiragana too.
+func countScripts(text string) (map[string]int, int) {
+
+	tables := map[string]*unicode.RangeTable{}
+	for _, profile := range LanguageProfiles {
+		if profile != nil {
+			if table, ok := unicode.Scripts[profile.Script]; ok {
+				tables[profile.Script] = table
+			}
+		}
+	}
+
+	scripts := map[string]int{}
+	total, kana := 0, 0
+
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		if total++; total > detectLength {
+			break
+		}
+		if unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) {
+			kana++
+			continue
+		}
+		for name, table := range tables {
+			if unicode.Is(table, r) {
+				scripts[name]++
+				break
+			}
+		}
+	}
+
+	total = min(total, detectLength)
+
+	if kana > 0 {
+		if _, ok := tables["Hiragana"]; ok {
+			// Japanese text is mostly Han with the kana, at least one kana of twenty letters
+			if kana*20 >= kana+scripts["Han"] {
+				kana += scripts["Han"]
+				delete(scripts, "Han")
+			}
+			scripts["Hiragana"] += kana
+		}
+	}
+
+	return scripts, total
+}
+
+// countTrigrams counts the trigrams of the lowercase words padded with spaces, up to detectLength letters,
+// e.g. " th", "the", "he " of the
+func countTrigrams(text string) map[string]int {
+
+	counts := map[string]int{}
+	letters := 0
+
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.Is(unicode.Mn, r)
+	})
+
+	for _, word := range words {
+		runes := []rune(" " + strings.ToLower(word) + " ")
+		if letters += len(runes) - 2; letters > detectLength {
+			break
+		}
+		for i := 0; i+3 <= len(runes); i++ {
+			counts[string(runes[i:i+3])]++
+		}
+	}
+
+	return counts
+}
+
+// rankTrigrams returns the most frequent trigrams, the ties ordered alphabetically
+func rankTrigrams(counts map[string]int, limit int) []string {
+
+	trigrams := make([]string, 0, len(counts))
+	for trigram := range counts {
+		trigrams = append(trigrams, trigram)
+	}
+
+	sort.Slice(trigrams, func(i, j int) bool {
+		if counts[trigrams[i]] != counts[trigrams[j]] {
+			return counts[trigrams[i]] > counts[trigrams[j]]
+		}
+		return trigrams[i] < trigrams[j]
+	})
+
+	if len(trigrams) > limit {
+		trigrams = trigrams[:limit]
+	}
+
+	return trigrams
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// round2 rounds the confidence to two decimals
+func round2(x float64) float64 {
+	return float64(int(x*100+0.5)) / 100
+}
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isAlnum(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return s != ""
+}
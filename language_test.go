@@ -0,0 +1,197 @@
+package article_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+func TestDetectLanguage(t *testing.T) {
+
+	tests := []struct {
+		tag  string
+		text string
+	}{
+		{"en", "The weather is nice today and we are going to the beach with our kids after lunch."},
+		{"es", "Hoy hace buen tiempo y después de comer vamos a la playa con nuestros hijos y sus amigos."},
+		{"pt", "Hoje o tempo está bom e vamos à praia com os nossos filhos depois do almoço."},
+		{"fr", "Il fait beau aujourd'hui et nous allons à la plage avec nos enfants après le déjeuner."},
+		{"de", "Heute ist das Wetter schön und wir gehen nach dem Mittagessen mit unseren Kindern an den Strand."},
+		{"it", "Oggi il tempo è bello e dopo pranzo andiamo al mare con i nostri figli."},
+		{"nl", "Vandaag is het mooi weer en we gaan na de lunch met onze kinderen naar het strand."},
+		{"pl", "Dzisiaj jest ładna pogoda i po obiedzie idziemy z dziećmi na plażę."},
+		{"tr", "Bugün hava çok güzel ve öğle yemeğinden sonra çocuklarımızla birlikte sahile gidiyoruz."},
+		{"fi", "Tänään on kaunis sää ja menemme lounaan jälkeen lasten kanssa rannalle."},
+		{"ro", "Astăzi vremea este frumoasă și după prânz mergem la plajă cu copiii noștri."},
+		{"hu", "Ma szép idő van, és ebéd után a gyerekekkel a strandra megyünk."},
+		{"id", "Hari ini cuacanya cerah dan setelah makan siang kami akan pergi ke pantai bersama anak-anak."},
+		{"vi", "Hôm nay trời đẹp và sau bữa trưa chúng tôi sẽ đi biển cùng các con."},
+		{"ru", "Сегодня хорошая погода, и после обеда мы идём на пляж вместе с нашими детьми и их друзьями."},
+		{"uk", "Сьогодні гарна погода, і після обіду ми йдемо на пляж разом із нашими дітьми та їхніми друзями."},
+		{"ar", "الطقس جميل اليوم ونحن ذاهبون إلى الشاطئ مع أطفالنا بعد الغداء."},
+		{"he", "היום מזג האוויר יפה ואנחנו הולכים לים עם הילדים אחרי ארוחת הצהריים."},
+		{"el", "Σήμερα ο καιρός είναι ωραίος και πηγαίνουμε στην παραλία με τα παιδιά."},
+		{"zh", "今天天气很好，吃完午饭以后我们和孩子们一起去海边玩。"},
+		{"ja", "今日は天気が良いので、昼ご飯の後に子供たちと一緒に海に行きます。"},
+		{"ko", "오늘은 날씨가 좋아서 점심을 먹고 아이들과 함께 바다에 갑니다."},
+		{"hi", "आज मौसम अच्छा है और दोपहर के खाने के बाद हम बच्चों के साथ समुद्र तट पर जा रहे हैं।"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			detected := article.DetectLanguage(tt.text)
+			assert.Equal(t, tt.tag, detected.Tag)
+			assert.Greater(t, detected.Confidence, 0.0)
+		})
+	}
+
+	// short and mixed texts have the low confidence
+	assert.Less(t, article.DetectLanguage("OK").Confidence, 0.1)
+	assert.Less(t, article.DetectLanguage("2024 — 1:0, 17.5%, https://example.com").Confidence, 0.1)
+	assert.Empty(t, article.DetectLanguage("").Tag)
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"en", "en"},
+		{"EN_us", "en-US"},
+		{"pt_br", "pt-BR"},
+		{"zh-hans-cn", "zh-Hans-CN"},
+		{"es-419", "es-419"},
+		{"de-CH-1901", "de-CH-1901"},
+		{"en-US-x-Custom", "en-US-x-custom"},
+		{"Russian", "ru"},
+		{"rus", "ru"},
+		{"rus-RU", "ru-RU"},
+		{" English ", "en"},
+		{"iw", "he"},
+		{"", ""},
+		{"english language", ""},
+		{"e", ""},
+		{"en--US", ""},
+		{"en-US-", ""},
+		{"en-toolongsubtag", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, article.NormalizeLanguage(tt.value))
+		})
+	}
+}
+
+func TestArticle_NormalizeLanguage(t *testing.T) {
+
+	text := "Der Stadtrat hat am Dienstag beschlossen, dass die Straßenbahn in der Innenstadt bis zum Ende des Jahres " +
+		"verlängert wird. Die Bürger können ihre Vorschläge zu den neuen Haltestellen bis Ende des Monats einreichen."
+
+	newArticle := func(language string) *article.Article {
+		a := article.NewArticle()
+		a.Title = "Die Straßenbahn wird verlängert"
+		a.Text = text
+		a.Markup = "<p>" + text + "</p>"
+		a.Language = language
+		return a
+	}
+
+	t.Run("detected", func(t *testing.T) {
+		a := newArticle("")
+		report, err := a.NormalizeWithReport()
+		require.NoError(t, err)
+		assert.Equal(t, "de", a.Language)
+
+		detected := report.Field("Article.Language")
+		require.Len(t, detected, 1)
+		assert.Equal(t, article.ActionDefault, detected[0].Action)
+		assert.Equal(t, "language", detected[0].Tag)
+		assert.GreaterOrEqual(t, detected[0].Value, article.LanguageConfidence)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		a := newArticle("fr")
+		report, err := a.NormalizeWithReport()
+		require.NoError(t, err)
+		assert.Equal(t, "fr", a.Language, "the supplied language is kept")
+
+		mismatch := report.Field("Article.Language")
+		require.Len(t, mismatch, 1)
+		assert.Equal(t, article.ActionMismatch, mismatch[0].Action)
+		assert.Equal(t, "de", mismatch[0].Value)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		a := newArticle("invalid language")
+		report, err := a.NormalizeWithReport()
+		require.NoError(t, err)
+		assert.Equal(t, "de", a.Language)
+
+		issues := report.Field("Article.Language")
+		require.Len(t, issues, 2)
+		assert.Equal(t, article.ActionReset, issues[0].Action)
+		assert.Equal(t, article.ActionDefault, issues[1].Action)
+	})
+
+	t.Run("canonicalized", func(t *testing.T) {
+		a := newArticle("de_at")
+		require.Error(t, a.Validate(), "the tag is not canonical")
+		report, err := a.NormalizeWithReport()
+		require.NoError(t, err)
+		assert.Equal(t, "de-AT", a.Language, "the region of the matching language is kept")
+		require.Len(t, report.Field("Article.Language"), 1)
+		assert.Equal(t, article.ActionCanonicalized, report.Field("Article.Language")[0].Action)
+		require.NoError(t, a.Validate())
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		a := newArticle("invalid language")
+		a.Title = "OK"
+		a.Text = "2024"
+		a.Markup = "<p>2024</p>"
+		report, err := a.NormalizeWithReport()
+		require.NoError(t, err)
+		assert.Equal(t, "en", a.Language)
+		assert.Len(t, report.Field("Article.Language"), 2)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		defer func(profiles []*article.LanguageProfile) { article.LanguageProfiles = profiles }(article.LanguageProfiles)
+		article.LanguageProfiles = nil
+
+		a := newArticle("fr")
+		require.NoError(t, a.Normalize())
+		assert.Equal(t, "fr", a.Language)
+
+		assert.Empty(t, article.DetectLanguage(text).Tag)
+		assert.Equal(t, "", article.NormalizeLanguage("German"))
+	})
+}
+
+func TestArticle_LegacyLanguage(t *testing.T) {
+
+	a, err := article.NewArticleFromMap(map[string]any{
+		"id":        "5f1d7e2c-3a4b-4c5d-8e9f-0a1b2c3d4e5f",
+		"title":     "Title",
+		"markup":    "Text",
+		"text":      "Text",
+		"published": "2024-01-02T03:04:05Z",
+		"language":  "english",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "en", a.Language)
+
+	decoded := &article.Article{}
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"5f1d7e2c-3a4b-4c5d-8e9f-0a1b2c3d4e5f","title":"Title","markup":"Text","text":"Text","published":"2024-01-02T03:04:05Z","language":"English"}`), decoded))
+	assert.NoError(t, decoded.Validate())
+	assert.Equal(t, "English", decoded.Language)
+
+	decoded.Language = "klingon language"
+	assert.Error(t, decoded.Validate())
+}
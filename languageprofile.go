@@ -0,0 +1,385 @@
+package article
+
+// DefaultLanguageProfiles returns 37 languages: English, Spanish, Portuguese, French, German, Italian, Dutch, Polish,
+// Turkish, Swedish, Danish, Norwegian Bokmål, Finnish, Czech, Slovak, Romanian, Hungarian, Indonesian, Vietnamese,
+// Croatian, Russian, Ukrainian, Bulgarian, Serbian, Arabic, Persian, Hebrew, Greek, Chinese, Japanese, Korean,
+// Thai, Hindi, Bengali, Georgian, Armenian and Tamil.
+func DefaultLanguageProfiles() []*LanguageProfile {
+	return []*LanguageProfile{
+		{
+			Tag:     "en",
+			Aliases: []string{"English", "eng"},
+			Script:  "Latin",
+			Sample: `The city council announced on Monday that the new public library will open next spring.
+According to the mayor, the project was delayed because of rising construction costs and a shortage of workers.
+Local residents have waited for more than five years, and many of them said they were happy with the decision.
+The building will include a room for children, a small theatre and a cafe where people can meet their friends.
+The government has also promised to support the schools in the region with additional funding.
+Experts believe that the economy is growing again, although the prices of food and energy remain high.
+In the evening, thousands of people gathered in the main square to celebrate the anniversary of the town.
+It is still not clear when the work will begin, but the city expects that it will happen this year.
+The president said that the country would not change its policy and that the talks should continue.`,
+		},
+		{
+			Tag:     "es",
+			Aliases: []string{"Spanish", "Español", "spa", "Castellano"},
+			Script:  "Latin",
+			Sample: `El ayuntamiento anunció el lunes que la nueva biblioteca pública abrirá la próxima primavera.
+Según el alcalde, el proyecto se retrasó por el aumento de los costes de construcción y la falta de trabajadores.
+Los vecinos han esperado durante más de cinco años y muchos de ellos dijeron que estaban contentos con la decisión.
+El edificio tendrá una sala para niños, un pequeño teatro y una cafetería donde la gente podrá reunirse con sus amigos.
+El gobierno también ha prometido apoyar a las escuelas de la región con más fondos.
+Los expertos creen que la economía vuelve a crecer, aunque los precios de los alimentos y de la energía siguen siendo altos.
+Por la noche, miles de personas se reunieron en la plaza mayor para celebrar el aniversario de la ciudad.
+Todavía no está claro cuándo comenzarán las obras, pero la ciudad espera que sea este mismo año.
+El presidente dijo que el país no cambiará su política y que las negociaciones deben continuar.`,
+		},
+		{
+			Tag:     "pt",
+			Aliases: []string{"Portuguese", "Português", "por"},
+			Script:  "Latin",
+			Sample: `A prefeitura anunciou na segunda-feira que a nova biblioteca pública será inaugurada na próxima primavera.
+Segundo o prefeito, o projeto foi adiado por causa do aumento dos custos de construção e da falta de trabalhadores.
+Os moradores esperaram durante mais de cinco anos, e muitos deles disseram que estão satisfeitos com a decisão.
+O edifício terá uma sala para crianças, um pequeno teatro e um café onde as pessoas poderão encontrar os seus amigos.
+O governo também prometeu apoiar as escolas da região com mais recursos.
+Os especialistas acreditam que a economia voltou a crescer, embora os preços dos alimentos e da energia continuem altos.
+À noite, milhares de pessoas se reuniram na praça principal para comemorar o aniversário da cidade.
+Ainda não está claro quando as obras vão começar, mas a cidade espera que isso aconteça ainda este ano.
+O presidente disse que o país não vai mudar a sua política e que as negociações devem continuar.`,
+		},
+		{
+			Tag:     "fr",
+			Aliases: []string{"French", "Français", "fra", "fre"},
+			Script:  "Latin",
+			Sample: `Le conseil municipal a annoncé lundi que la nouvelle bibliothèque publique ouvrira au printemps prochain.
+Selon le maire, le projet a été retardé en raison de la hausse des coûts de construction et du manque de travailleurs.
+Les habitants attendent depuis plus de cinq ans et beaucoup d'entre eux ont dit qu'ils étaient satisfaits de cette décision.
+Le bâtiment comprendra une salle pour les enfants, un petit théâtre et un café où les gens pourront retrouver leurs amis.
+Le gouvernement a également promis de soutenir les écoles de la région avec des fonds supplémentaires.
+Les experts estiment que l'économie est de nouveau en croissance, même si les prix de l'alimentation et de l'énergie restent élevés.
+Le soir, des milliers de personnes se sont rassemblées sur la place principale pour célébrer l'anniversaire de la ville.
+On ne sait pas encore quand les travaux commenceront, mais la ville espère que ce sera cette année.
+Le président a déclaré que le pays ne changerait pas sa politique et que les négociations devaient continuer.`,
+		},
+		{
+			Tag:     "de",
+			Aliases: []string{"German", "Deutsch", "deu", "ger"},
+			Script:  "Latin",
+			Sample: `Der Stadtrat hat am Montag angekündigt, dass die neue öffentliche Bibliothek im nächsten Frühjahr eröffnet wird.
+Nach Angaben des Bürgermeisters wurde das Projekt wegen der steigenden Baukosten und des Mangels an Arbeitskräften verzögert.
+Die Einwohner haben mehr als fünf Jahre gewartet, und viele von ihnen sagten, dass sie mit der Entscheidung zufrieden sind.
+Das Gebäude wird einen Raum für Kinder, ein kleines Theater und ein Café haben, in dem sich die Menschen mit ihren Freunden treffen können.
+Die Regierung hat außerdem versprochen, die Schulen in der Region mit zusätzlichen Mitteln zu unterstützen.
+Experten glauben, dass die Wirtschaft wieder wächst, obwohl die Preise für Lebensmittel und Energie weiterhin hoch sind.
+Am Abend versammelten sich Tausende Menschen auf dem Marktplatz, um den Jahrestag der Stadt zu feiern.
+Es ist noch nicht klar, wann die Arbeiten beginnen, aber die Stadt erwartet, dass es noch in diesem Jahr geschieht.
+Der Präsident sagte, dass das Land seine Politik nicht ändern werde und die Gespräche fortgesetzt werden sollen.`,
+		},
+		{
+			Tag:     "it",
+			Aliases: []string{"Italian", "Italiano", "ita"},
+			Script:  "Latin",
+			Sample: `Il consiglio comunale ha annunciato lunedì che la nuova biblioteca pubblica aprirà la prossima primavera.
+Secondo il sindaco, il progetto è stato rinviato a causa dell'aumento dei costi di costruzione e della mancanza di lavoratori.
+Gli abitanti hanno aspettato per più di cinque anni e molti di loro hanno detto di essere contenti della decisione.
+L'edificio avrà una sala per i bambini, un piccolo teatro e un bar dove le persone potranno incontrare gli amici.
+Il governo ha anche promesso di sostenere le scuole della regione con ulteriori finanziamenti.
+Gli esperti ritengono che l'economia stia tornando a crescere, anche se i prezzi del cibo e dell'energia restano alti.
+La sera, migliaia di persone si sono riunite nella piazza principale per festeggiare l'anniversario della città.
+Non è ancora chiaro quando inizieranno i lavori, ma la città si aspetta che questo accada entro l'anno.
+Il presidente ha detto che il paese non cambierà la sua politica e che i negoziati devono continuare.`,
+		},
+		{
+			Tag:     "nl",
+			Aliases: []string{"Dutch", "Nederlands", "nld", "dut", "Flemish"},
+			Script:  "Latin",
+			Sample: `De gemeenteraad heeft maandag aangekondigd dat de nieuwe openbare bibliotheek volgend voorjaar wordt geopend.
+Volgens de burgemeester is het project vertraagd door de stijgende bouwkosten en een tekort aan werknemers.
+De inwoners hebben meer dan vijf jaar gewacht en veel van hen zeiden dat ze blij zijn met het besluit.
+Het gebouw krijgt een ruimte voor kinderen, een klein theater en een café waar mensen hun vrienden kunnen ontmoeten.
+De regering heeft ook beloofd om de scholen in de regio met extra geld te steunen.
+Deskundigen denken dat de economie weer groeit, hoewel de prijzen van voedsel en energie hoog blijven.
+In de avond kwamen duizenden mensen samen op het centrale plein om de verjaardag van de stad te vieren.
+Het is nog niet duidelijk wanneer de werkzaamheden zullen beginnen, maar de stad verwacht dat het dit jaar gebeurt.
+De president zei dat het land zijn beleid niet zal veranderen en dat de gesprekken moeten doorgaan.`,
+		},
+		{
+			Tag:     "pl",
+			Aliases: []string{"Polish", "Polski", "pol"},
+			Script:  "Latin",
+			Sample: `Rada miasta ogłosiła w poniedziałek, że nowa biblioteka publiczna zostanie otwarta wiosną przyszłego roku.
+Według burmistrza projekt został opóźniony z powodu rosnących kosztów budowy i braku pracowników.
+Mieszkańcy czekali ponad pięć lat i wielu z nich powiedziało, że są zadowoleni z tej decyzji.
+W budynku znajdzie się sala dla dzieci, mały teatr oraz kawiarnia, w której ludzie będą mogli spotykać się z przyjaciółmi.
+Rząd obiecał również wspierać szkoły w regionie dodatkowymi środkami.
+Eksperci uważają, że gospodarka znowu rośnie, chociaż ceny żywności i energii nadal są wysokie.
+Wieczorem tysiące osób zebrały się na głównym placu, aby świętować rocznicę założenia miasta.
+Nie wiadomo jeszcze, kiedy rozpoczną się prace, ale miasto oczekuje, że stanie się to jeszcze w tym roku.
+Prezydent powiedział, że kraj nie zmieni swojej polityki i że rozmowy powinny być kontynuowane.`,
+		},
+		{
+			Tag:     "tr",
+			Aliases: []string{"Turkish", "Türkçe", "tur"},
+			Script:  "Latin",
+			Sample: `Belediye meclisi pazartesi günü yeni halk kütüphanesinin gelecek ilkbaharda açılacağını duyurdu.
+Belediye başkanına göre proje, artan inşaat maliyetleri ve işçi eksikliği nedeniyle ertelendi.
+Mahalle sakinleri beş yıldan fazla bekledi ve birçoğu bu karardan memnun olduklarını söyledi.
+Binada çocuklar için bir oda, küçük bir tiyatro ve insanların arkadaşlarıyla buluşabileceği bir kafe bulunacak.
+Hükümet ayrıca bölgedeki okulları ek kaynaklarla desteklemeye söz verdi.
+Uzmanlar, gıda ve enerji fiyatları yüksek kalmaya devam etse de ekonominin yeniden büyüdüğüne inanıyor.
+Akşam saatlerinde binlerce kişi şehrin kuruluş yıldönümünü kutlamak için ana meydanda toplandı.
+Çalışmaların ne zaman başlayacağı henüz belli değil, ancak belediye bunun bu yıl içinde olmasını bekliyor.
+Cumhurbaşkanı, ülkenin politikasını değiştirmeyeceğini ve görüşmelerin devam etmesi gerektiğini söyledi.`,
+		},
+		{
+			Tag:     "sv",
+			Aliases: []string{"Swedish", "Svenska", "swe"},
+			Script:  "Latin",
+			Sample: `Kommunfullmäktige meddelade på måndagen att det nya offentliga biblioteket ska öppna nästa vår.
+Enligt kommunstyrelsens ordförande har projektet försenats på grund av stigande byggkostnader och brist på arbetare.
+Invånarna har väntat i mer än fem år och många av dem sa att de är nöjda med beslutet.
+Byggnaden kommer att ha ett rum för barn, en liten teater och ett kafé där människor kan träffa sina vänner.
+Regeringen har också lovat att stödja skolorna i regionen med ytterligare pengar.
+Experter tror att ekonomin växer igen, även om priserna på mat och energi fortfarande är höga.
+På kvällen samlades tusentals människor på stora torget för att fira stadens födelsedag.
+Det är ännu inte klart när arbetet kommer att börja, men kommunen räknar med att det sker under året.
+Presidenten sa att landet inte kommer att ändra sin politik och att samtalen måste fortsätta.`,
+		},
+		{
+			Tag:     "da",
+			Aliases: []string{"Danish", "Dansk", "dan"},
+			Script:  "Latin",
+			Sample: `Byrådet meddelte mandag, at det nye offentlige bibliotek skal åbne til foråret.
+Ifølge borgmesteren er projektet blevet forsinket på grund af stigende byggeomkostninger og mangel på arbejdskraft.
+Borgerne har ventet i mere end fem år, og mange af dem sagde, at de er glade for beslutningen.
+Bygningen får et rum til børn, et lille teater og en café, hvor folk kan mødes med deres venner.
+Regeringen har også lovet at støtte skolerne i regionen med flere penge.
+Eksperter mener, at økonomien vokser igen, selv om priserne på mad og energi stadig er høje.
+Om aftenen samledes tusindvis af mennesker på torvet for at fejre byens fødselsdag.
+Det er endnu ikke klart, hvornår arbejdet vil begynde, men kommunen forventer, at det sker i løbet af året.
+Præsidenten sagde, at landet ikke vil ændre sin politik, og at forhandlingerne skal fortsætte.`,
+		},
+		{
+			Tag:     "nb",
+			Aliases: []string{"Norwegian", "Norsk", "Bokmål", "nob", "nor"},
+			Script:  "Latin",
+			Sample: `Bystyret kunngjorde mandag at det nye offentlige biblioteket skal åpne til våren.
+Ifølge ordføreren har prosjektet blitt forsinket på grunn av økende byggekostnader og mangel på arbeidskraft.
+Innbyggerne har ventet i mer enn fem år, og mange av dem sa at de er fornøyde med beslutningen.
+Bygningen skal ha et rom for barn, et lite teater og en kafé der folk kan møte vennene sine.
+Regjeringen har også lovet å støtte skolene i regionen med mer penger.
+Eksperter mener at økonomien vokser igjen, selv om prisene på mat og strøm fortsatt er høye.
+Om kvelden samlet tusenvis av mennesker seg på torget for å feire byens bursdag.
+Det er ennå ikke klart når arbeidet vil begynne, men kommunen regner med at det skjer i løpet av året.
+Presidenten sa at landet ikke vil endre sin politikk, og at forhandlingene må fortsette.`,
+		},
+		{
+			Tag:     "fi",
+			Aliases: []string{"Finnish", "Suomi", "fin"},
+			Script:  "Latin",
+			Sample: `Kaupunginvaltuusto ilmoitti maanantaina, että uusi yleinen kirjasto avataan ensi keväänä.
+Pormestarin mukaan hanke on viivästynyt rakennuskustannusten nousun ja työvoimapulan vuoksi.
+Asukkaat ovat odottaneet yli viisi vuotta, ja monet heistä sanoivat olevansa tyytyväisiä päätökseen.
+Rakennukseen tulee lasten huone, pieni teatteri ja kahvila, jossa ihmiset voivat tavata ystäviään.
+Hallitus on myös luvannut tukea alueen kouluja lisärahoituksella.
+Asiantuntijoiden mukaan talous kasvaa jälleen, vaikka ruoan ja energian hinnat pysyvät korkeina.
+Illalla tuhannet ihmiset kokoontuivat keskustorille juhlimaan kaupungin vuosipäivää.
+Vielä ei ole selvää, milloin työt alkavat, mutta kaupunki odottaa niiden käynnistyvän tämän vuoden aikana.
+Presidentti sanoi, että maa ei muuta politiikkaansa ja että neuvottelujen on jatkuttava.`,
+		},
+		{
+			Tag:     "cs",
+			Aliases: []string{"Czech", "Čeština", "ces", "cze"},
+			Script:  "Latin",
+			Sample: `Městské zastupitelstvo v pondělí oznámilo, že nová veřejná knihovna bude otevřena příští jaro.
+Podle starosty byl projekt zpožděn kvůli rostoucím nákladům na stavbu a nedostatku pracovníků.
+Obyvatelé čekali více než pět let a mnozí z nich řekli, že jsou s rozhodnutím spokojeni.
+V budově bude místnost pro děti, malé divadlo a kavárna, kde se lidé mohou setkávat se svými přáteli.
+Vláda také slíbila, že podpoří školy v regionu dalšími penězi.
+Odborníci se domnívají, že ekonomika opět roste, i když ceny potravin a energií zůstávají vysoké.
+Večer se na hlavním náměstí shromáždily tisíce lidí, aby oslavily výročí založení města.
+Zatím není jasné, kdy práce začnou, ale město očekává, že to bude ještě letos.
+Prezident řekl, že země svou politiku nezmění a že jednání musí pokračovat.`,
+		},
+		{
+			Tag:     "sk",
+			Aliases: []string{"Slovak", "Slovenčina", "slk", "slo"},
+			Script:  "Latin",
+			Sample: `Mestské zastupiteľstvo v pondelok oznámilo, že nová verejná knižnica bude otvorená na jar budúceho roka.
+Podľa primátora sa projekt oneskoril pre rastúce náklady na výstavbu a nedostatok pracovníkov.
+Obyvatelia čakali viac ako päť rokov a mnohí z nich povedali, že sú s rozhodnutím spokojní.
+V budove bude miestnosť pre deti, malé divadlo a kaviareň, kde sa ľudia môžu stretávať so svojimi priateľmi.
+Vláda tiež sľúbila, že podporí školy v regióne ďalšími peniazmi.
+Odborníci sa domnievajú, že hospodárstvo opäť rastie, hoci ceny potravín a energií zostávajú vysoké.
+Večer sa na hlavnom námestí zhromaždili tisíce ľudí, aby oslávili výročie založenia mesta.
+Zatiaľ nie je jasné, kedy sa práce začnú, ale mesto očakáva, že to bude ešte tento rok.
+Prezident povedal, že krajina svoju politiku nezmení a že rokovania musia pokračovať.`,
+		},
+		{
+			Tag:     "ro",
+			Aliases: []string{"Romanian", "Română", "ron", "rum", "Moldavian"},
+			Script:  "Latin",
+			Sample: `Consiliul local a anunțat luni că noua bibliotecă publică va fi deschisă în primăvara anului viitor.
+Potrivit primarului, proiectul a fost amânat din cauza creșterii costurilor de construcție și a lipsei de muncitori.
+Locuitorii au așteptat mai mult de cinci ani, iar mulți dintre ei au spus că sunt mulțumiți de această decizie.
+Clădirea va avea o sală pentru copii, un teatru mic și o cafenea unde oamenii se vor putea întâlni cu prietenii.
+Guvernul a promis, de asemenea, că va sprijini școlile din regiune cu fonduri suplimentare.
+Experții cred că economia crește din nou, deși prețurile la alimente și energie rămân ridicate.
+Seara, mii de oameni s-au adunat în piața centrală pentru a sărbători aniversarea orașului.
+Încă nu este clar când vor începe lucrările, dar orașul se așteaptă ca acest lucru să se întâmple anul acesta.
+Președintele a spus că țara nu își va schimba politica și că negocierile trebuie să continue.`,
+		},
+		{
+			Tag:     "hu",
+			Aliases: []string{"Hungarian", "Magyar", "hun"},
+			Script:  "Latin",
+			Sample: `A városi közgyűlés hétfőn bejelentette, hogy az új közkönyvtár jövő tavasszal nyílik meg.
+A polgármester szerint a projekt az emelkedő építési költségek és a munkaerőhiány miatt késett.
+A lakók több mint öt évet vártak, és sokan közülük azt mondták, hogy elégedettek a döntéssel.
+Az épületben lesz egy gyerekszoba, egy kis színház és egy kávézó, ahol az emberek találkozhatnak a barátaikkal.
+A kormány azt is megígérte, hogy további forrásokkal támogatja a régió iskoláit.
+A szakértők úgy vélik, hogy a gazdaság ismét növekszik, bár az élelmiszerek és az energia ára továbbra is magas.
+Este emberek ezrei gyűltek össze a főtéren, hogy megünnepeljék a város születésnapját.
+Még nem világos, mikor kezdődnek a munkálatok, de a város arra számít, hogy ez még az idén megtörténik.
+Az elnök azt mondta, hogy az ország nem változtat a politikáján, és a tárgyalásoknak folytatódniuk kell.`,
+		},
+		{
+			Tag:     "id",
+			Aliases: []string{"Indonesian", "Bahasa Indonesia", "ind", "in"},
+			Script:  "Latin",
+			Sample: `Dewan kota mengumumkan pada hari Senin bahwa perpustakaan umum yang baru akan dibuka pada musim semi mendatang.
+Menurut wali kota, proyek tersebut tertunda karena meningkatnya biaya pembangunan dan kekurangan tenaga kerja.
+Warga telah menunggu selama lebih dari lima tahun dan banyak dari mereka mengatakan bahwa mereka senang dengan keputusan itu.
+Gedung ini akan memiliki ruang untuk anak-anak, sebuah teater kecil, dan kafe tempat orang dapat bertemu dengan teman-teman mereka.
+Pemerintah juga berjanji untuk mendukung sekolah-sekolah di wilayah tersebut dengan dana tambahan.
+Para ahli percaya bahwa ekonomi kembali tumbuh, meskipun harga makanan dan energi masih tinggi.
+Pada malam hari, ribuan orang berkumpul di alun-alun untuk merayakan ulang tahun kota.
+Belum jelas kapan pekerjaan akan dimulai, tetapi pemerintah kota berharap hal itu terjadi tahun ini.
+Presiden mengatakan bahwa negara tidak akan mengubah kebijakannya dan bahwa perundingan harus dilanjutkan.`,
+		},
+		{
+			Tag:     "vi",
+			Aliases: []string{"Vietnamese", "Tiếng Việt", "vie"},
+			Script:  "Latin",
+			Sample: `Hội đồng thành phố đã thông báo vào thứ Hai rằng thư viện công cộng mới sẽ mở cửa vào mùa xuân tới.
+Theo ông thị trưởng, dự án bị chậm trễ do chi phí xây dựng tăng cao và thiếu công nhân.
+Người dân đã chờ đợi hơn năm năm và nhiều người trong số họ nói rằng họ hài lòng với quyết định này.
+Tòa nhà sẽ có một phòng dành cho trẻ em, một nhà hát nhỏ và một quán cà phê, nơi mọi người có thể gặp gỡ bạn bè.
+Chính phủ cũng hứa sẽ hỗ trợ các trường học trong khu vực bằng nguồn kinh phí bổ sung.
+Các chuyên gia tin rằng nền kinh tế đang tăng trưởng trở lại, mặc dù giá thực phẩm và năng lượng vẫn còn cao.
+Vào buổi tối, hàng nghìn người đã tập trung tại quảng trường chính để kỷ niệm ngày thành lập thành phố.
+Hiện vẫn chưa rõ khi nào công việc sẽ bắt đầu, nhưng thành phố hy vọng điều đó sẽ diễn ra trong năm nay.
+Tổng thống nói rằng đất nước sẽ không thay đổi chính sách và các cuộc đàm phán phải được tiếp tục.`,
+		},
+		{
+			Tag:     "hr",
+			Aliases: []string{"Croatian", "Hrvatski", "hrv"},
+			Script:  "Latin",
+			Sample: `Gradsko vijeće objavilo je u ponedjeljak da će nova javna knjižnica biti otvorena sljedećeg proljeća.
+Prema riječima gradonačelnika, projekt je kasnio zbog rasta troškova gradnje i nedostatka radnika.
+Stanovnici su čekali više od pet godina i mnogi od njih rekli su da su zadovoljni odlukom.
+U zgradi će se nalaziti soba za djecu, malo kazalište i kafić u kojem se ljudi mogu družiti s prijateljima.
+Vlada je također obećala da će dodatnim sredstvima poduprijeti škole u regiji.
+Stručnjaci smatraju da gospodarstvo ponovno raste, iako cijene hrane i energije i dalje ostaju visoke.
+Navečer se tisuće ljudi okupilo na glavnom trgu kako bi proslavili obljetnicu osnutka grada.
+Još nije jasno kada će radovi početi, ali grad očekuje da će to biti ove godine.
+Predsjednik je rekao da zemlja neće promijeniti svoju politiku i da se pregovori moraju nastaviti.`,
+		},
+		{
+			Tag:     "ru",
+			Aliases: []string{"Russian", "Русский", "rus"},
+			Script:  "Cyrillic",
+			Sample: `Городской совет объявил в понедельник, что новая публичная библиотека откроется следующей весной.
+По словам мэра, проект был отложен из-за роста стоимости строительства и нехватки рабочих.
+Жители ждали более пяти лет, и многие из них сказали, что довольны этим решением.
+В здании будет комната для детей, небольшой театр и кафе, где люди смогут встречаться со своими друзьями.
+Правительство также пообещало поддержать школы региона дополнительным финансированием.
+Эксперты считают, что экономика снова растёт, хотя цены на продукты и энергию остаются высокими.
+Вечером тысячи людей собрались на главной площади, чтобы отпраздновать годовщину основания города.
+Пока не ясно, когда начнутся работы, но в городе ожидают, что это произойдёт уже в этом году.
+Президент заявил, что страна не изменит свою политику и что переговоры должны быть продолжены.`,
+		},
+		{
+			Tag:     "uk",
+			Aliases: []string{"Ukrainian", "Українська", "ukr"},
+			Script:  "Cyrillic",
+			Sample: `Міська рада оголосила в понеділок, що нова публічна бібліотека відкриється наступної весни.
+За словами мера, проєкт було відкладено через зростання вартості будівництва та брак робітників.
+Мешканці чекали понад п'ять років, і багато з них сказали, що задоволені цим рішенням.
+У будівлі буде кімната для дітей, невеликий театр і кав'ярня, де люди зможуть зустрічатися зі своїми друзями.
+Уряд також пообіцяв підтримати школи регіону додатковим фінансуванням.
+Експерти вважають, що економіка знову зростає, хоча ціни на продукти та енергію залишаються високими.
+Увечері тисячі людей зібралися на головній площі, щоб відсвяткувати річницю заснування міста.
+Поки що не зрозуміло, коли почнуться роботи, але в місті очікують, що це станеться вже цього року.
+Президент заявив, що країна не змінить свою політику і що переговори мають бути продовжені.`,
+		},
+		{
+			Tag:     "bg",
+			Aliases: []string{"Bulgarian", "Български", "bul"},
+			Script:  "Cyrillic",
+			Sample: `Общинският съвет обяви в понеделник, че новата обществена библиотека ще бъде открита следващата пролет.
+Според кмета проектът е бил забавен заради повишените разходи за строителство и липсата на работници.
+Жителите са чакали повече от пет години и много от тях казаха, че са доволни от решението.
+В сградата ще има стая за деца, малък театър и кафене, където хората ще могат да се срещат с приятелите си.
+Правителството също обеща да подкрепи училищата в региона с допълнително финансиране.
+Експертите смятат, че икономиката отново расте, въпреки че цените на храните и енергията остават високи.
+Вечерта хиляди хора се събраха на централния площад, за да отпразнуват годишнината от основаването на града.
+Все още не е ясно кога ще започнат работите, но в общината очакват това да стане още тази година.
+Президентът заяви, че страната няма да промени политиката си и че преговорите трябва да продължат.`,
+		},
+		{
+			Tag:     "sr",
+			Aliases: []string{"Serbian", "Српски", "srp"},
+			Script:  "Cyrillic",
+			Sample: `Градско веће је у понедељак објавило да ће нова јавна библиотека бити отворена следећег пролећа.
+Према речима градоначелника, пројекат је каснио због раста трошкова изградње и недостатка радника.
+Становници су чекали више од пет година и многи од њих рекли су да су задовољни одлуком.
+У згради ће се налазити соба за децу, мало позориште и кафић у којем људи могу да се друже са пријатељима.
+Влада је такође обећала да ће додатним средствима подржати школе у региону.
+Стручњаци сматрају да привреда поново расте, иако цене хране и енергије и даље остају високе.
+Увече се хиљаде људи окупило на главном тргу како би прославили годишњицу оснивања града.
+Још није јасно када ће радови почети, али град очекује да ће то бити ове године.
+Председник је рекао да земља неће променити своју политику и да се преговори морају наставити.`,
+		},
+		{
+			Tag:     "ar",
+			Aliases: []string{"Arabic", "العربية", "ara"},
+			Script:  "Arabic",
+			Sample: `أعلن مجلس المدينة يوم الاثنين أن المكتبة العامة الجديدة ستفتح أبوابها في الربيع المقبل.
+وبحسب رئيس البلدية، فقد تأخر المشروع بسبب ارتفاع تكاليف البناء ونقص العمال.
+وقد انتظر السكان أكثر من خمس سنوات، وقال كثير منهم إنهم راضون عن هذا القرار.
+وسيضم المبنى غرفة للأطفال ومسرحا صغيرا ومقهى يمكن للناس أن يلتقوا فيه بأصدقائهم.
+كما وعدت الحكومة بدعم المدارس في المنطقة بتمويل إضافي.
+ويعتقد الخبراء أن الاقتصاد يعود إلى النمو، على الرغم من أن أسعار الغذاء والطاقة لا تزال مرتفعة.
+وفي المساء، تجمع آلاف الأشخاص في الساحة الرئيسية للاحتفال بذكرى تأسيس المدينة.
+ولم يتضح بعد متى ستبدأ الأعمال، لكن المدينة تتوقع أن يحدث ذلك خلال هذا العام.
+وقال الرئيس إن البلاد لن تغير سياستها وإن المفاوضات يجب أن تستمر.`,
+		},
+		{
+			Tag:     "fa",
+			Aliases: []string{"Persian", "Farsi", "فارسی", "fas", "per"},
+			Script:  "Arabic",
+			Sample: `شورای شهر روز دوشنبه اعلام کرد که کتابخانه عمومی جدید بهار آینده افتتاح خواهد شد.
+به گفته شهردار، این پروژه به دلیل افزایش هزینه‌های ساخت و کمبود کارگر به تأخیر افتاده است.
+ساکنان بیش از پنج سال منتظر ماندند و بسیاری از آنها گفتند که از این تصمیم راضی هستند.
+این ساختمان یک اتاق برای کودکان، یک تئاتر کوچک و یک کافه خواهد داشت که مردم می‌توانند در آن با دوستان خود دیدار کنند.
+دولت همچنین قول داده است که از مدارس منطقه با بودجه اضافی حمایت کند.
+کارشناسان معتقدند که اقتصاد دوباره در حال رشد است، هرچند قیمت مواد غذایی و انرژی همچنان بالا است.
+شب هنگام هزاران نفر در میدان اصلی شهر گرد هم آمدند تا سالگرد تأسیس شهر را جشن بگیرند.
+هنوز مشخص نیست که کارها چه زمانی آغاز می‌شود، اما شهرداری انتظار دارد که این کار امسال انجام شود.
+رئیس جمهور گفت که کشور سیاست خود را تغییر نخواهد داد و مذاکرات باید ادامه پیدا کند.`,
+		},
+		{Tag: "he", Aliases: []string{"Hebrew", "עברית", "heb", "iw"}, Script: "Hebrew"},
+		{Tag: "el", Aliases: []string{"Greek", "Ελληνικά", "ell", "gre"}, Script: "Greek"},
+		{Tag: "zh", Aliases: []string{"Chinese", "中文", "zho", "chi"}, Script: "Han"},
+		{Tag: "ja", Aliases: []string{"Japanese", "日本語", "jpn"}, Script: "Hiragana"},
+		{Tag: "ko", Aliases: []string{"Korean", "한국어", "kor"}, Script: "Hangul"},
+		{Tag: "th", Aliases: []string{"Thai", "ไทย", "tha"}, Script: "Thai"},
+		{Tag: "hi", Aliases: []string{"Hindi", "हिन्दी", "hin"}, Script: "Devanagari"},
+		{Tag: "bn", Aliases: []string{"Bengali", "Bangla", "বাংলা", "ben"}, Script: "Bengali"},
+		{Tag: "ka", Aliases: []string{"Georgian", "ქართული", "kat", "geo"}, Script: "Georgian"},
+		{Tag: "hy", Aliases: []string{"Armenian", "Հայերեն", "hye", "arm"}, Script: "Armenian"},
+		{Tag: "ta", Aliases: []string{"Tamil", "தமிழ்", "tam"}, Script: "Tamil"},
+	}
+}
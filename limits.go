@@ -0,0 +1,142 @@
+package article
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Limits are the maximum lengths of the text fields in runes. Normalize trims the fields to the limits
+// and the validation checks them with the limit tag, e.g. validate:"limit=Title".
+// The zero limit means the default value, so the profile sets only the changed limits, e.g. &Limits{Title: 60}.
+// The IDs, hashes and other fields of the fixed format keep the limits of their struct tags.
+type Limits struct {
+	// Title is the limit of Article.Title, 255 by default.
+	Title int
+	// Summary is the limit of Article.Summary, 500 by default.
+	Summary int
+	// Markup is the limit of Article.Markup, 65000 by default.
+	Markup int
+	// Text is the limit of Article.Text and Quote.Text, 65000 by default.
+	Text int
+	// URL is the limit of every URL field, 4096 by default.
+	URL int
+	// Name is the limit of the names, e.g. Article.Author, Article.Category, Image.Alt, Media.Title,
+	// Person.Name, Quote.Author, Social.Platform and Social.Handle, 255 by default.
+	Name int
+	// Caption is the limit of Article.Genre, Image.Title, Video.Title and Media.Description, 500 by default.
+	Caption int
+	// Embed is the limit of Video.Embed and Quote.Embed, 65000 by default.
+	Embed int
+	// Tag is the limit of every tag of Article.Tags, 255 by default.
+	Tag int
+}
+
+// NewLimits returns the default limits.
+func NewLimits() *Limits {
+	return &Limits{
+		Title:   255,
+		Summary: 500,
+		Markup:  65000,
+		Text:    65000,
+		URL:     4096,
+		Name:    255,
+		Caption: 500,
+		Embed:   65000,
+		Tag:     255,
+	}
+}
+
+// DefaultLimits are used by Normalize, Validate, the collections and the map conversions.
+// Change the fields to apply the own limits globally or pass the profile to Article.NormalizeWithLimits.
+var DefaultLimits = NewLimits()
+
+// resolved returns the limits with the zero values replaced by the defaults
+func (l *Limits) resolved() Limits {
+
+	limits := *NewLimits()
+	if l == nil {
+		return limits
+	}
+
+	for _, pair := range []struct{ dst, src *int }{
+		{&limits.Title, &l.Title},
+		{&limits.Summary, &l.Summary},
+		{&limits.Markup, &l.Markup},
+		{&limits.Text, &l.Text},
+		{&limits.URL, &l.URL},
+		{&limits.Name, &l.Name},
+		{&limits.Caption, &l.Caption},
+		{&limits.Embed, &l.Embed},
+		{&limits.Tag, &l.Tag},
+	} {
+		if *pair.src > 0 {
+			*pair.dst = *pair.src
+		}
+	}
+
+	return limits
+}
+
+// field returns the limit by the field name of the limit tag, -1 for the unknown name
+func (l Limits) field(name string) int {
+
+	switch name {
+	case "Title":
+		return l.Title
+	case "Summary":
+		return l.Summary
+	case "Markup":
+		return l.Markup
+	case "Text":
+		return l.Text
+	case "URL":
+		return l.URL
+	case "Name":
+		return l.Name
+	case "Caption":
+		return l.Caption
+	case "Embed":
+		return l.Embed
+	case "Tag":
+		return l.Tag
+	}
+
+	return -1
+}
+
+// limitsKey is the context key of the Limits of the validation
+type limitsKey struct{}
+
+// withLimits returns the validation context with the limits
+func withLimits(ctx context.Context, limits *Limits) context.Context {
+	return context.WithValue(ctx, limitsKey{}, limits)
+}
+
+// validateLimit is the limit tag, the length of the string in runes must not exceed the limit of the context,
+// DefaultLimits if the context has none
+func validateLimit(ctx context.Context, fl validator.FieldLevel) bool {
+
+	limits, ok := ctx.Value(limitsKey{}).(*Limits)
+	if !ok {
+		limits = DefaultLimits
+	}
+
+	limit := limits.resolved().field(fl.Param())
+	if limit < 0 {
+		return false
+	}
+
+	return utf8.RuneCountInString(fl.Field().String()) <= limit
+}
+
+// validateWithLimits validates the struct with the limits, DefaultLimits if nil
+func validateWithLimits(s any, limits *Limits) error {
+
+	if limits == nil {
+		return validate.Struct(s)
+	}
+
+	return validate.StructCtx(withLimits(context.Background(), limits), s)
+}
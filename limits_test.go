@@ -0,0 +1,117 @@
+package article_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+func limitsArticle() *article.Article {
+	a := article.NewArticle()
+	a.Title = strings.Repeat("t", 200)
+	a.Text = strings.Repeat("x", 70000)
+	a.Markup = "<p>" + a.Text + "</p>"
+	a.Tags.Add("short", strings.Repeat("g", 100))
+	return a
+}
+
+func TestArticle_NormalizeWithLimits(t *testing.T) {
+
+	t.Run("default", func(t *testing.T) {
+		a := limitsArticle()
+		report, err := a.NormalizeWithReport()
+		require.NoError(t, err)
+		assert.Len(t, a.Title, 200)
+		assert.Len(t, a.Text, 65000)
+		assert.Len(t, report.Field("Article.Text"), 1)
+		assert.Equal(t, 2, a.Tags.Len())
+	})
+
+	t.Run("profile", func(t *testing.T) {
+		a := limitsArticle()
+		limits := &article.Limits{Title: 60, Text: 100000, Markup: 100000, Tag: 50}
+
+		report, err := a.NormalizeWithLimits(limits)
+		require.NoError(t, err)
+		assert.Len(t, a.Title, 60)
+		assert.Len(t, a.Text, 70000)
+		assert.Equal(t, []string{"short"}, a.Tags.Slice())
+
+		trimmed := report.Field("Article.Title")
+		require.Len(t, trimmed, 1)
+		assert.Equal(t, article.ActionTrimmed, trimmed[0].Action)
+		assert.Len(t, report.Field("Article.Tags[1]"), 1)
+
+		// the zero limits are the defaults
+		assert.Equal(t, "General", a.Category)
+		require.NoError(t, a.ValidateWithLimits(limits))
+		assert.Error(t, a.Validate(), "the text exceeds the default limit")
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		a := limitsArticle()
+		quote := article.NewQuote("quote")
+		quote.Author = strings.Repeat("a", 100)
+		quote.SourceURL = "https://example.com/quote"
+		a.Quotes.Add(quote)
+
+		_, err := a.NormalizeWithLimits(&article.Limits{Name: 10})
+		require.NoError(t, err)
+		assert.Len(t, a.Quotes.Slice()[0].Author, 10)
+	})
+}
+
+func TestNewArticleFromMapWithLimits(t *testing.T) {
+
+	limits := &article.Limits{Text: 100000, Markup: 100000, Caption: 1000, Tag: 300}
+
+	a := limitsArticle()
+	img := article.NewImage("https://example.com/image.jpg")
+	a.Images.Add(img)
+	img.Title = strings.Repeat("c", 700)
+	tag := strings.Repeat("l", 280)
+
+	_, err := a.NormalizeWithLimits(limits)
+	require.NoError(t, err)
+	require.Len(t, a.Text, 70000)
+
+	m := a.Map()
+	m["tags"] = append(a.Tags.Slice(), tag)
+
+	_, err = article.NewArticleFromMap(m)
+	assert.Error(t, err, "the text exceeds the default limit")
+
+	got, err := article.NewArticleFromMapWithLimits(m, limits)
+	require.NoError(t, err)
+	assert.Len(t, got.Text, 70000)
+	require.Equal(t, 1, got.Images.Len())
+	assert.Len(t, got.Images.Slice()[0].Title, 700)
+	assert.True(t, got.Tags.Contains(tag))
+	assert.NoError(t, got.ValidateWithLimits(limits))
+}
+
+func TestDefaultLimits(t *testing.T) {
+
+	defer func(limits *article.Limits) { article.DefaultLimits = limits }(article.DefaultLimits)
+
+	assert.Equal(t, 255, article.NewLimits().Title)
+	assert.Equal(t, 4096, article.NewLimits().URL)
+
+	article.DefaultLimits = &article.Limits{Name: 5}
+
+	social := article.NewSocial("Mastodon", "https://mastodon.social/@Gargron")
+	social.Normalize()
+	assert.Equal(t, "Masto", social.Platform)
+
+	// the collections validate with the default limits
+	person := &article.Person{ID: "c4b5a1b2-3c4d-4e5f-8a9b-0c1d2e3f4a5b", Name: "John Doe"}
+	assert.Equal(t, 0, article.NewPersons(person).Len())
+
+	article.DefaultLimits = nil
+	person.Name = "John Doe"
+	assert.Equal(t, 1, article.NewPersons(person).Len())
+}
@@ -0,0 +1,399 @@
+package article
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayouts are the layouts accepted for the date strings, e.g. published and modified.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.ANSIC,
+	time.UnixDate,
+}
+
+// ParseTime parses RFC3339 and common date strings, e.g. 2024-05-27, 2024-05-27 10:00:00
+// or Mon, 27 May 2024 10:00:00 +0000.
+func ParseTime(s string) (time.Time, error) {
+
+	s = strings.TrimSpace(s)
+
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unknown time format: %q", s)
+}
+
+// MapError describes a map value of unexpected type.
+type MapError struct {
+	// Key is the path of the value, e.g. images[0].width
+	Key string `json:"key"`
+	// Expected is the expected type, e.g. int
+	Expected string `json:"expected"`
+	// Got is the type of the value, e.g. bool
+	Got string `json:"got"`
+}
+
+func (e MapError) Error() string {
+	return fmt.Sprintf("%s: expected %s, got %s", e.Key, e.Expected, e.Got)
+}
+
+// MapErrors is the list of type mismatches found by the strict map constructors.
+type MapErrors []MapError
+
+func (e MapErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// mapReader reads typed values from a map[string]any.
+// It accepts both Go values and values produced by json.Unmarshal ([]any, float64, json.Number, strings),
+// and records type mismatches by key. The strict reader fails the items holding the mismatches, see failed.
+type mapReader struct {
+	m      map[string]any
+	path   string
+	strict bool
+	// limits of the validation, DefaultLimits if nil
+	limits *Limits
+	errors *MapErrors
+}
+
+func newMapReader(m map[string]any) *mapReader {
+	return &mapReader{m: m, errors: &MapErrors{}}
+}
+
+func newStrictMapReader(m map[string]any) *mapReader {
+	return &mapReader{m: m, strict: true, errors: &MapErrors{}}
+}
+
+// nested returns the reader of the nested map sharing the mode, the limits and the errors
+func (r *mapReader) nested(m map[string]any, path string) *mapReader {
+	return &mapReader{m: m, path: path, strict: r.strict, limits: r.limits, errors: r.errors}
+}
+
+// validate validates the struct with the limits of the reader
+func (r *mapReader) validate(s any) error {
+	return validateWithLimits(s, r.limits)
+}
+
+// key returns the full path of the key
+func (r *mapReader) key(key string) string {
+	if r.path == "" {
+		return key
+	}
+	return r.path + "." + key
+}
+
+// mismatch records the type mismatch
+func (r *mapReader) mismatch(key, expected string, value any) {
+	*r.errors = append(*r.errors, MapError{Key: r.key(key), Expected: expected, Got: fmt.Sprintf("%T", value)})
+}
+
+// value returns the value by key, false if the key is missing or null
+func (r *mapReader) value(key string) (any, bool) {
+	value, exists := r.m[key]
+	return value, exists && value != nil
+}
+
+func (r *mapReader) string(key string) string {
+
+	value, ok := r.value(key)
+	if !ok {
+		return ""
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	}
+
+	r.mismatch(key, "string", value)
+	return ""
+}
+
+func (r *mapReader) int(key string) int {
+
+	value, ok := r.value(key)
+	if !ok {
+		return 0
+	}
+
+	if i, ok := toInt(value); ok {
+		return i
+	}
+
+	r.mismatch(key, "int", value)
+	return 0
+}
+
+func (r *mapReader) float(key string) float64 {
+
+	value, ok := r.value(key)
+	if !ok {
+		return 0
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	case string:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return f
+		}
+	default:
+		if i, ok := toInt(value); ok {
+			return float64(i)
+		}
+	}
+
+	r.mismatch(key, "float", value)
+	return 0
+}
+
+func (r *mapReader) time(key string) time.Time {
+
+	value, ok := r.value(key)
+	if !ok {
+		return time.Time{}
+	}
+
+	switch v := value.(type) {
+	case time.Time:
+		return v
+	case *time.Time:
+		if v != nil {
+			return *v
+		}
+		return time.Time{}
+	case string:
+		if v == "" {
+			return time.Time{}
+		}
+		if t, err := ParseTime(v); err == nil {
+			return t
+		}
+	default:
+		// unix timestamp in seconds
+		if i, ok := toInt(value); ok {
+			return time.Unix(int64(i), 0).UTC()
+		}
+	}
+
+	r.mismatch(key, "time", value)
+	return time.Time{}
+}
+
+func (r *mapReader) strings(key string) []string {
+
+	value, ok := r.value(key)
+	if !ok {
+		return []string{}
+	}
+
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []any:
+		slice := make([]string, 0, len(v))
+		for i, item := range v {
+			if str, ok := item.(string); ok {
+				slice = append(slice, str)
+			} else {
+				r.mismatch(fmt.Sprintf("%s[%d]", key, i), "string", item)
+			}
+		}
+		return slice
+	}
+
+	r.mismatch(key, "[]string", value)
+	return []string{}
+}
+
+// maps returns readers of the nested maps, e.g. images
+func (r *mapReader) maps(key string) []*mapReader {
+
+	value, ok := r.value(key)
+	if !ok {
+		return nil
+	}
+
+	var maps []map[string]any
+
+	switch v := value.(type) {
+	case []map[string]any:
+		maps = v
+	case []any:
+		maps = make([]map[string]any, 0, len(v))
+		for i, item := range v {
+			if m, ok := item.(map[string]any); ok {
+				maps = append(maps, m)
+			} else {
+				r.mismatch(fmt.Sprintf("%s[%d]", key, i), "map[string]any", item)
+			}
+		}
+	default:
+		r.mismatch(key, "[]map[string]any", value)
+		return nil
+	}
+
+	readers := make([]*mapReader, len(maps))
+	for i, m := range maps {
+		readers[i] = r.nested(m, fmt.Sprintf("%s[%d]", r.key(key), i))
+	}
+
+	return readers
+}
+
+// sub returns the reader of the nested map, e.g. focal, nil if the key is missing or the map is empty
+func (r *mapReader) sub(key string) *mapReader {
+
+	value, ok := r.value(key)
+	if !ok {
+		return nil
+	}
+
+	m, ok := value.(map[string]any)
+	if !ok {
+		r.mismatch(key, "map[string]any", value)
+		return nil
+	}
+
+	if len(m) == 0 {
+		return nil
+	}
+
+	return r.nested(m, r.key(key))
+}
+
+// failed returns the type mismatches of the values read by the strict reader and its nested readers,
+// nil in the lenient mode
+func (r *mapReader) failed() error {
+
+	if !r.strict {
+		return nil
+	}
+
+	var mismatches MapErrors
+	for _, mismatch := range *r.errors {
+		if r.path == "" || strings.HasPrefix(mismatch.Key, r.path+".") || strings.HasPrefix(mismatch.Key, r.path+"[") {
+			mismatches = append(mismatches, mismatch)
+		}
+	}
+
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	return mismatches
+}
+
+// toInt converts Go and JSON-decoded numerics to int
+func toInt(value any) (int, bool) {
+
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int8:
+		return int(v), true
+	case int16:
+		return int(v), true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case uint:
+		return int(v), true
+	case uint8:
+		return int(v), true
+	case uint16:
+		return int(v), true
+	case uint32:
+		return int(v), true
+	case uint64:
+		return int(v), true
+	case float32:
+		return floatToInt(float64(v))
+	case float64:
+		return floatToInt(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i), true
+		}
+		if f, err := v.Float64(); err == nil {
+			return floatToInt(f)
+		}
+	case string:
+		if i, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			return i, true
+		}
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return floatToInt(f)
+		}
+	}
+
+	return 0, false
+}
+
+// floatToInt converts only whole numbers, e.g. 800.0
+func floatToInt(f float64) (int, bool) {
+	if f != math.Trunc(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return int(f), true
+}
+
+// StringFromMap safely extracts a string from the map or returns a zero value.
+func StringFromMap(m map[string]any, key string) string {
+	return newMapReader(m).string(key)
+}
+
+// IntFromMap safely extracts an int from the map or returns a zero value.
+// Accepts any Go integer, whole float64 and json.Number produced by encoding/json, and numeric strings.
+func IntFromMap(m map[string]any, key string) int {
+	return newMapReader(m).int(key)
+}
+
+// TimeFromMap safely extracts a time from the map or returns a zero value.
+// Accepts time.Time, RFC3339 and common date strings (see ParseTime), and unix timestamps.
+func TimeFromMap(m map[string]any, key string) time.Time {
+	return newMapReader(m).time(key)
+}
+
+// GetStringSlice safely extracts a slice of strings from the map or returns a zero value.
+// Accepts []string and []any of strings produced by encoding/json, the non-string items are skipped.
+func GetStringSlice(m map[string]any, key string) []string {
+	return newMapReader(m).strings(key)
+}
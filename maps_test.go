@@ -0,0 +1,228 @@
+package article_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+func TestNewArticleFromMap_JSONDecoded(t *testing.T) {
+
+	expected := article.NewArticle()
+	expected.Title = gofakeit.Sentence(3)
+	expected.Markup = gofakeit.Paragraph(1, 5, 10, " ")
+	expected.Text = gofakeit.Paragraph(1, 5, 10, " ")
+	expected.Published = time.Now().UTC().Truncate(time.Second)
+	expected.Modified = time.Now().UTC().Truncate(time.Second)
+	expected.Tags = article.NewTags("travel", "Phuket")
+	WithImages(expected, GenerateURLs("example.com", 2)...)
+	expected.Videos.Add(&article.Video{ID: gofakeit.UUID(), URL: gofakeit.URL()})
+	expected.Quotes.Add(&article.Quote{ID: gofakeit.UUID(), Text: gofakeit.Sentence(5), SourceURL: gofakeit.URL()})
+	expected.Socials.Add(&article.Social{ID: gofakeit.UUID(), URL: gofakeit.URL()})
+
+	data, err := json.Marshal(expected)
+	require.NoError(t, err)
+
+	m := map[string]any{}
+	require.NoError(t, json.Unmarshal(data, &m))
+
+	got, err := article.NewArticleFromMapStrict(m)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected.Published, got.Published)
+	assert.Equal(t, expected.Modified, got.Modified)
+	assert.Equal(t, expected.Tags, got.Tags)
+	assert.Equal(t, expected.Images, got.Images)
+	assert.Equal(t, expected.Videos.IDs(), got.Videos.IDs())
+	assert.Equal(t, expected.Quotes.IDs(), got.Quotes.IDs())
+	assert.Equal(t, expected.Socials.IDs(), got.Socials.IDs())
+}
+
+func TestNewArticleFromMapStrict_Mismatch(t *testing.T) {
+
+	m := map[string]any{
+		"id":        gofakeit.UUID(),
+		"title":     gofakeit.Sentence(3),
+		"markup":    gofakeit.Paragraph(1, 5, 10, " "),
+		"text":      gofakeit.Paragraph(1, 5, 10, " "),
+		"published": "2024-05-27",
+		"language":  42.0,
+		"tags":      []any{"AI", true},
+		"images": []any{
+			map[string]any{"id": gofakeit.UUID(), "url": gofakeit.URL(), "width": "wide"},
+			"not an image",
+		},
+	}
+
+	// lenient mode skips mismatches
+	a, err := article.NewArticleFromMap(m)
+	require.NoError(t, err)
+	assert.Equal(t, 1, a.Images.Len())
+	assert.Equal(t, []string{"AI"}, a.Tags.Slice())
+	assert.Equal(t, "2024-05-27", a.Published.Format(time.DateOnly))
+
+	// strict mode reports every mismatch
+	_, err = article.NewArticleFromMapStrict(m)
+	require.Error(t, err)
+
+	var mismatches article.MapErrors
+	require.ErrorAs(t, err, &mismatches)
+
+	keys := make([]string, len(mismatches))
+	for i, mismatch := range mismatches {
+		keys[i] = mismatch.Key
+	}
+	assert.ElementsMatch(t, []string{"language", "tags[1]", "images[1]", "images[0].width"}, keys)
+	assert.True(t, strings.Contains(err.Error(), "images[0].width: expected int, got string"))
+}
+
+func TestFromMapStrict_Nested(t *testing.T) {
+
+	image := map[string]any{
+		"id":         gofakeit.UUID(),
+		"url":        "https://example.com/a.jpg",
+		"focal":      map[string]any{"x": "left", "y": 0.5},
+		"renditions": []any{map[string]any{"url": "https://example.com/a-320.jpg", "width": true}},
+	}
+
+	// lenient mode skips mismatches
+	img, err := article.NewImageFromMap(image)
+	require.NoError(t, err)
+	assert.Zero(t, img.Focal.X)
+
+	// strict mode fails the item holding the nested mismatches
+	_, err = article.NewImageFromMapStrict(image)
+	var mismatches article.MapErrors
+	require.ErrorAs(t, err, &mismatches)
+	require.Len(t, mismatches, 2)
+	assert.Equal(t, "renditions[0].width", mismatches[0].Key)
+	assert.Equal(t, "focal.x", mismatches[1].Key)
+
+	quote := map[string]any{
+		"id":         gofakeit.UUID(),
+		"text":       gofakeit.Sentence(5),
+		"source_url": "https://x.com/jack/status/20",
+		"media":      []any{map[string]any{"url": 42}},
+	}
+
+	q, err := article.NewQuoteFromMap(quote)
+	require.NoError(t, err)
+	assert.Empty(t, q.Media)
+
+	_, err = article.NewQuoteFromMapStrict(quote)
+	require.ErrorAs(t, err, &mismatches)
+	assert.Equal(t, "media[0].url", mismatches[0].Key)
+
+	_, err = article.NewVideoFromMapStrict(map[string]any{"id": gofakeit.UUID(), "url": gofakeit.URL(), "duration": "long"})
+	assert.ErrorAs(t, err, &mismatches)
+
+	_, err = article.NewSocialProfileFromMapStrict(map[string]any{"id": gofakeit.UUID(), "url": gofakeit.URL(), "handle": 1.0})
+	assert.ErrorAs(t, err, &mismatches)
+
+	// the nested items are strict within the strict article, the valid items are kept
+	m := map[string]any{
+		"id":        gofakeit.UUID(),
+		"title":     gofakeit.Sentence(3),
+		"markup":    gofakeit.Paragraph(1, 5, 10, " "),
+		"text":      gofakeit.Paragraph(1, 5, 10, " "),
+		"published": "2024-05-27",
+		"images":    []any{image, map[string]any{"id": gofakeit.UUID(), "url": "https://example.com/b.jpg"}},
+	}
+
+	_, err = article.NewArticleFromMapStrict(m)
+	require.ErrorAs(t, err, &mismatches)
+	assert.Equal(t, "images[0].renditions[0].width", mismatches[0].Key)
+
+	a, err := article.NewArticleFromMap(m)
+	require.NoError(t, err)
+	assert.Equal(t, 2, a.Images.Len())
+}
+
+func TestGetStringSlice_String(t *testing.T) {
+
+	m := map[string]any{"tags": "travel, Phuket"}
+
+	// the string is not split into the list
+	assert.Empty(t, article.GetStringSlice(m, "tags"))
+
+	m["id"] = gofakeit.UUID()
+	m["title"] = gofakeit.Sentence(3)
+	m["markup"] = gofakeit.Paragraph(1, 5, 10, " ")
+	m["text"] = gofakeit.Paragraph(1, 5, 10, " ")
+	m["published"] = "2024-05-27"
+
+	_, err := article.NewArticleFromMapStrict(m)
+	var mismatches article.MapErrors
+	require.ErrorAs(t, err, &mismatches)
+	assert.Equal(t, "tags", mismatches[0].Key)
+}
+
+func TestIntFromMap(t *testing.T) {
+
+	m := map[string]any{
+		"int":     800,
+		"float":   600.0,
+		"number":  json.Number("1024"),
+		"string":  " 42 ",
+		"decimal": 1.5,
+		"bool":    true,
+	}
+
+	assert.Equal(t, 800, article.IntFromMap(m, "int"))
+	assert.Equal(t, 600, article.IntFromMap(m, "float"))
+	assert.Equal(t, 1024, article.IntFromMap(m, "number"))
+	assert.Equal(t, 42, article.IntFromMap(m, "string"))
+	assert.Zero(t, article.IntFromMap(m, "decimal"))
+	assert.Zero(t, article.IntFromMap(m, "bool"))
+	assert.Zero(t, article.IntFromMap(m, "missing"))
+}
+
+func TestParseTime(t *testing.T) {
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"2024-05-27T10:00:00Z", "2024-05-27T10:00:00Z"},
+		{"2024-05-27T10:00:00.123+03:00", "2024-05-27T07:00:00Z"},
+		{"2024-05-27 10:00:00", "2024-05-27T10:00:00Z"},
+		{"2024-05-27", "2024-05-27T00:00:00Z"},
+		{"Mon, 27 May 2024 10:00:00 +0000", "2024-05-27T10:00:00Z"},
+		{"Mon, 3 Jun 2024 10:00:00 +0200", "2024-06-03T08:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := article.ParseTime(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got.UTC().Truncate(time.Second).Format(time.RFC3339))
+		})
+	}
+
+	_, err := article.ParseTime("yesterday")
+	assert.Error(t, err)
+}
+
+func TestTimeFromMap(t *testing.T) {
+
+	now := time.Now()
+
+	m := map[string]any{
+		"time":   now,
+		"string": "2024-05-27T10:00:00Z",
+		"unix":   float64(1716804000),
+		"null":   nil,
+	}
+
+	assert.Equal(t, now, article.TimeFromMap(m, "time"))
+	assert.Equal(t, int64(1716804000), article.TimeFromMap(m, "string").Unix())
+	assert.Equal(t, int64(1716804000), article.TimeFromMap(m, "unix").Unix())
+	assert.True(t, article.TimeFromMap(m, "null").IsZero())
+}
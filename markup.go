@@ -0,0 +1,156 @@
+package article
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// paragraphTags are the block elements separated by a blank line in the plain text.
+var paragraphTags = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Blockquote: true, atom.Pre: true, atom.Ul: true, atom.Ol: true, atom.Dl: true, atom.Table: true,
+	atom.Section: true, atom.Article: true, atom.Header: true, atom.Footer: true, atom.Aside: true, atom.Main: true,
+	atom.Figure: true, atom.Figcaption: true, atom.Address: true, atom.Hr: true, atom.Details: true, atom.Summary: true,
+}
+
+// lineTags are the elements placed on a separate line in the plain text.
+var lineTags = map[atom.Atom]bool{
+	atom.Li: true, atom.Dt: true, atom.Dd: true, atom.Tr: true, atom.Caption: true,
+}
+
+// skipTags are the elements without readable text.
+var skipTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+	atom.Iframe: true, atom.Object: true, atom.Embed: true, atom.Svg: true, atom.Canvas: true,
+	atom.Head: true, atom.Title: true, atom.Select: true, atom.Button: true,
+}
+
+// HTMLToText converts the HTML to plain text.
+// Block elements become paragraphs separated by a blank line, list items and <br> become line breaks,
+// whitespace is collapsed except inside <pre>, scripts and styles are skipped.
+func HTMLToText(markup string) string {
+
+	w := &textWriter{}
+	z := html.NewTokenizer(strings.NewReader(markup))
+
+	skip := 0
+	pre := 0
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return w.String()
+
+		case html.TextToken:
+			if skip == 0 {
+				w.text(string(z.Text()), pre > 0)
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			tag := atom.Lookup(name)
+
+			if skipTags[tag] {
+				if tt == html.StartTagToken && tag != atom.Iframe {
+					skip++
+				}
+				continue
+			}
+
+			switch {
+			case tag == atom.Br:
+				w.breakLine(1)
+			case tag == atom.Pre:
+				pre++
+				w.breakLine(2)
+			case paragraphTags[tag]:
+				w.breakLine(2)
+			case lineTags[tag]:
+				w.breakLine(1)
+			case tag == atom.Td || tag == atom.Th:
+				w.space()
+			}
+
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := atom.Lookup(name)
+
+			if skipTags[tag] {
+				if skip > 0 && tag != atom.Iframe {
+					skip--
+				}
+				continue
+			}
+
+			switch {
+			case tag == atom.Pre:
+				if pre > 0 {
+					pre--
+				}
+				w.breakLine(2)
+			case paragraphTags[tag]:
+				w.breakLine(2)
+			case lineTags[tag]:
+				w.breakLine(1)
+			}
+		}
+	}
+}
+
+// textWriter builds the plain text with collapsed whitespace and limited line breaks
+type textWriter struct {
+	b strings.Builder
+	// breaks is the number of pending line breaks
+	breaks int
+	// spaced is true if a space is pending
+	spaced bool
+}
+
+func (w *textWriter) text(s string, pre bool) {
+
+	if pre {
+		w.flush()
+		w.b.WriteString(s)
+		return
+	}
+
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			w.spaced = true
+			continue
+		}
+		if w.spaced && w.breaks == 0 && w.b.Len() > 0 {
+			w.b.WriteByte(' ')
+		}
+		w.spaced = false
+		w.flush()
+		w.b.WriteRune(r)
+	}
+}
+
+func (w *textWriter) space() {
+	w.spaced = true
+}
+
+func (w *textWriter) breakLine(n int) {
+	if w.b.Len() > 0 && n > w.breaks {
+		w.breaks = n
+	}
+	w.spaced = false
+}
+
+func (w *textWriter) flush() {
+	if w.breaks > 0 {
+		w.b.WriteString(strings.Repeat("\n", w.breaks))
+		w.breaks = 0
+		w.spaced = false
+	}
+}
+
+func (w *textWriter) String() string {
+	return strings.TrimSpace(w.b.String())
+}
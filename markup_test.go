@@ -0,0 +1,35 @@
+package article_test
+
+import (
+	"testing"
+
+	"github.com/editorpost/article"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLToText(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		markup string
+		want   string
+	}{
+		{"plain", "Hello  world", "Hello world"},
+		{"entities", "Tom &amp; Jerry", "Tom & Jerry"},
+		{"paragraphs", "<p>First</p><p>Second</p>", "First\n\nSecond"},
+		{"line break", "First<br>Second", "First\nSecond"},
+		{"inline", "<p>Hello <b>bold</b> <i>world</i></p>", "Hello bold world"},
+		{"list", "<ul><li>One</li><li>Two</li></ul>", "One\nTwo"},
+		{"headings", "<h1>Title</h1>Text", "Title\n\nText"},
+		{"script", "<p>Text</p><script>alert(1)</script><style>p{}</style>", "Text"},
+		{"pre", "<pre>a  b\n c</pre>", "a  b\n c"},
+		{"iframe", "<p>Before</p><iframe src=\"https://example.com\"></iframe><p>After</p>", "Before\n\nAfter"},
+		{"table", "<table><tr><td>a</td><td>b</td></tr><tr><td>c</td></tr></table>", "a b\nc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, article.HTMLToText(tt.markup))
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package article
 
 import (
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"log/slog"
 )
@@ -15,19 +14,19 @@ type Media struct {
 
 	// Author is the author of the media.
 	// This field is optional and could be between 1 and 255 characters long.
-	Author string `json:"author,omitempty" validate:"max=255"`
+	Author string `json:"author,omitempty" validate:"limit=Name"`
 
 	// URL is the URL of the media.
 	// This field is required and should be a valid URL.
-	URL string `json:"url" validate:"url,max=4096"`
+	URL string `json:"url" validate:"url,limit=URL"`
 
 	// Title is the alternative text for the media.
 	// This field is required and should be between 1 and 255 characters long.
-	Title string `json:"title" validate:"max=255"`
+	Title string `json:"title" validate:"limit=Name"`
 
 	// Description is the description for the media.
 	// This field is optional.
-	Description string `json:"description,omitempty" validate:"max=500"`
+	Description string `json:"description,omitempty" validate:"limit=Caption"`
 
 	// Width is the width of the media in pixels.
 	// This field is optional.
@@ -52,26 +51,39 @@ func NewMedia(url string) *Media {
 	}
 }
 
+// GetID returns the ID of the Media.
+func (i *Media) GetID() string {
+	return i.ID
+}
+
 // Normalize validates and trims the fields of the Media.
+// The Media is reset to the zero value if it is invalid.
 func (i *Media) Normalize() {
+	if err := i.normalize(nil, "Media"); err != nil {
+		*i = Media{}
+	}
+}
+
+// normalize trims the fields, records the changes to the report and returns the validation error.
+func (i *Media) normalize(report *NormalizeReport, path string) error {
 
 	if i.ID == "" {
 		i.ID = uuid.New().String()
+		report.fallback(path+".ID", "")
 	}
 
-	i.ID = TrimToMaxLen(i.ID, 36)
-	i.Author = TrimToMaxLen(i.Author, 255)
-	i.URL = TrimToMaxLen(i.URL, 4096)
-	i.Title = TrimToMaxLen(i.Title, 255)
-	i.Description = TrimToMaxLen(i.Description, 500)
+	i.ID = report.trim(path+".ID", i.ID, 36)
+	i.Author = report.trim(path+".Author", i.Author, report.limits().Name)
+	i.URL = URLCanonicalizer.asset(report, path+".URL", report.trim(path+".URL", i.URL, report.limits().URL), report.baseURL())
+	i.Title = report.trim(path+".Title", i.Title, report.limits().Name)
+	i.Description = report.trim(path+".Description", i.Description, report.limits().Caption)
 
-	err := validate.Struct(i)
+	err := report.validate(i)
 	if err != nil {
-		for _, fieldErr := range err.(validator.ValidationErrors) {
-			slog.Debug("Validation error in Media", slog.String("field", fieldErr.Namespace()), slog.String("error", fieldErr.Tag()))
-			*i = Media{}
-		}
+		slog.Debug("Validation error in Media", slog.String("path", path), slog.String("error", err.Error()))
 	}
+
+	return err
 }
 
 // Map converts the Media struct to a map[string]any.
@@ -90,18 +102,33 @@ func (i *Media) Map() map[string]any {
 
 // NewMediaFromMap creates a Media from a map[string]any, validates it, and returns a pointer to the Media or an error.
 func NewMediaFromMap(m map[string]any) (*Media, error) {
+	return mediaFromMap(newMapReader(m))
+}
+
+// NewMediaFromMapStrict creates a Media from a map[string]any like NewMediaFromMap,
+// but fails with MapErrors listing every key holding a value of unexpected type.
+func NewMediaFromMapStrict(m map[string]any) (*Media, error) {
+	return mediaFromMap(newStrictMapReader(m))
+}
+
+// mediaFromMap reads the Media with the map reader, validates it, and returns a pointer to the Media or an error.
+func mediaFromMap(r *mapReader) (*Media, error) {
 	img := &Media{
-		ID:          StringFromMap(m, "id"),
-		Author:      StringFromMap(m, "author"),
-		URL:         StringFromMap(m, "url"),
-		Title:       StringFromMap(m, "title"),
-		Description: StringFromMap(m, "description"),
-		Width:       IntFromMap(m, "width"),
-		Height:      IntFromMap(m, "height"),
-		Size:        IntFromMap(m, "size"),
+		ID:          r.string("id"),
+		Author:      r.string("author"),
+		URL:         r.string("url"),
+		Title:       r.string("title"),
+		Description: r.string("description"),
+		Width:       r.int("width"),
+		Height:      r.int("height"),
+		Size:        r.int("size"),
+	}
+
+	if err := r.failed(); err != nil {
+		return nil, err
 	}
 
-	err := validate.Struct(img)
+	err := r.validate(img)
 	if err != nil {
 		return nil, err
 	}
@@ -1,149 +1,130 @@
 package article
 
-import (
-	"encoding/json"
-	"log"
-	"log/slog"
-)
-
-// Medias represents a collection of Media pointers
+// Medias represents a collection of Media pointers.
+// The nil Medias is empty, e.g. the Medias of the Article decoded from the JSON without the medias.
 type Medias struct {
-	items []*Media
+	Collection[*Media]
 }
 
-// NewMediasStrict creates a collection and validates every media
-func NewMediasStrict(medias ...*Media) (*Medias, error) {
-
-	valid := make([]*Media, 0, len(medias))
-
-	for _, media := range medias {
+// NewMedias creates a collection, skips invalid items, and logs errors
+func NewMedias(medias ...*Media) *Medias {
+	return &Medias{Collection: *NewCollection(medias...)}
+}
 
-		if err := validate.Struct(media); err != nil {
-			return nil, err
-		}
+// NewMediasStrict creates a collection and validates every item
+func NewMediasStrict(medias ...*Media) (*Medias, error) {
 
-		valid = append(valid, media)
+	list, err := NewCollectionStrict(medias...)
+	if err != nil {
+		return nil, err
 	}
 
-	return &Medias{items: valid}, nil
+	return &Medias{Collection: *list}, nil
 }
 
-// NewMedias creates a collection, skips invalid items, and logs errors
-func NewMedias(medias ...*Media) *Medias {
-
-	var valid []*Media
-
-	for _, media := range medias {
-		if err := validate.Struct(media); err == nil {
-			valid = append(valid, media)
-		} else {
-			slog.Debug("Invalid media skipped: %v", err)
-		}
+// Add adds items to the collection, invalid items are skipped.
+// The nil collection returns a new one with the items.
+func (list *Medias) Add(medias ...*Media) *Medias {
+	if list == nil {
+		return NewMedias(medias...)
 	}
+	list.Collection.Add(medias...)
+	return list
+}
 
-	return &Medias{items: valid}
+// Remove removes items by ID
+func (list *Medias) Remove(ids ...string) *Medias {
+	if list == nil {
+		return nil
+	}
+	list.Collection.Remove(ids...)
+	return list
 }
 
-// Normalize validates and trims the fields of all items
-func (list *Medias) Normalize() {
-	for _, img := range list.items {
-		img.Normalize()
+// Filter returns a new Medias collection filtered by the provided functions
+func (list *Medias) Filter(fns ...func(*Media) bool) *Medias {
+	if list == nil {
+		return NewMedias()
 	}
+	return &Medias{Collection: *list.Collection.Filter(fns...)}
 }
 
 // Get returns the media by ID
 func (list *Medias) Get(id string) (*Media, bool) {
-	for _, img := range list.items {
-		if img.ID == id {
-			return img, true
-		}
+	if list == nil {
+		return nil, false
 	}
-	return nil, false
+	return list.Collection.Get(id)
 }
 
-// Slice returns a slice of all items
+// Slice returns a slice of all medias
 func (list *Medias) Slice() []*Media {
-	return list.items
+	if list == nil {
+		return nil
+	}
+	return list.Collection.Slice()
 }
 
-// Add adds items to the collection
-func (list *Medias) Add(medias ...*Media) *Medias {
-	for _, img := range medias {
-		if img != nil && img.ID != "" {
-			list.items = append(list.items, img)
-		} else {
-			log.Printf("Invalid media skipped: %+v", img)
-		}
+// IDs returns the IDs of the medias
+func (list *Medias) IDs() []string {
+	if list == nil {
+		return []string{}
 	}
-	return list
+	return list.Collection.IDs()
 }
 
-// Remove removes items by ID
-func (list *Medias) Remove(ids ...string) *Medias {
-	idSet := make(map[string]struct{}, len(ids))
-	for _, id := range ids {
-		idSet[id] = struct{}{}
+// Len returns the number of the medias
+func (list *Medias) Len() int {
+	if list == nil {
+		return 0
 	}
+	return list.Collection.Len()
+}
 
-	var filteredMedias []*Media
-	for _, img := range list.items {
-		if _, found := idSet[img.ID]; !found {
-			filteredMedias = append(filteredMedias, img)
-		}
+// Sort sorts the medias in place by the comparison function, see Collection.Sort
+func (list *Medias) Sort(cmp func(a, b *Media) int) *Medias {
+	if list == nil {
+		return nil
 	}
-
-	list.items = filteredMedias
+	list.Collection.Sort(cmp)
 	return list
 }
 
-// IDs returns a slice of all media IDs
-func (list *Medias) IDs() []string {
-	ids := make([]string, len(list.items))
-	for idx, img := range list.items {
-		ids[idx] = img.ID
+// Dedupe removes the medias with the duplicate ID, the first media is kept
+func (list *Medias) Dedupe() *Medias {
+	if list == nil {
+		return nil
 	}
-	return ids
-}
-
-// Len returns the number of items
-func (list *Medias) Len() int {
-	return len(list.items)
+	list.Collection.Dedupe()
+	return list
 }
 
-// Filter returns a new Medias collection filtered by the provided functions
-func (list *Medias) Filter(fns ...func(*Media) bool) *Medias {
-	var filteredMedias []*Media
-	for _, img := range list.items {
-		include := true
-		for _, fn := range fns {
-			if !fn(img) {
-				include = false
-				break
-			}
-		}
-		if include {
-			filteredMedias = append(filteredMedias, img)
-		}
+// DedupeFunc removes the medias with the duplicate key, the first media is kept
+func (list *Medias) DedupeFunc(key func(*Media) string) *Medias {
+	if list == nil {
+		return nil
 	}
-	return &Medias{items: filteredMedias}
+	list.Collection.DedupeFunc(key)
+	return list
 }
 
-// UnmarshalJSON to array of items using encoding/json
-func (list *Medias) UnmarshalJSON(data []byte) error {
-
-	// Unmarshal to a slice of Media
-	var medias []*Media
-	if err := json.Unmarshal(data, &medias); err != nil {
-		return err
+// Maps converts the medias to a slice of maps
+func (list *Medias) Maps() []map[string]any {
+	if list == nil {
+		return []map[string]any{}
 	}
+	return list.Collection.Maps()
+}
 
-	// Create a new Medias collection
-	*list = *NewMedias(medias...)
-
-	return nil
+// Normalize removes invalid medias
+func (list *Medias) Normalize() {
+	list.normalize(nil, "")
 }
 
-// MarshalJSON from array of items using encoding/json
-func (list *Medias) MarshalJSON() ([]byte, error) {
-	return json.Marshal(list.items)
+// normalize removes invalid medias and records the changes to the report
+func (list *Medias) normalize(report *NormalizeReport, path string) {
+	if list == nil {
+		return
+	}
+	list.Collection.normalize(report, path)
 }
@@ -1,55 +1,120 @@
 package article
 
 import (
-	"github.com/google/uuid"
 	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
 )
 
+// Roles of the persons who contributed to the article.
+const (
+	RoleAuthor       = "author"
+	RoleEditor       = "editor"
+	RolePhotographer = "photographer"
+	RoleTranslator   = "translator"
+	RoleContributor  = "contributor"
+)
+
+// Person represents a contributor of the article, e.g. author or photographer.
 type Person struct {
-	ID      string   `json:"id" validate:"required,max=36"`
-	Name    string   `json:"name" validate:"required,max=255"`
-	Role    string   `json:"role" validate:"max=255"`
-	Images  *Images  `json:"images"`
+
+	// ID is the unique identifier of the person.
+	// It is stable enough to be used as a key in a storage system.
+	ID string `json:"id" validate:"required,max=36"`
+
+	// Name is the display name of the person.
+	// This field is required.
+	Name string `json:"name" validate:"required,limit=Name"`
+
+	// Role is the role of the person in the article, e.g. author, editor, photographer, translator, contributor.
+	// Defaults to author.
+	Role string `json:"role" validate:"limit=Name"`
+
+	// Images are the photos of the person, e.g. avatar.
+	Images *Images `json:"images"`
+
+	// Socials are the social profiles of the person.
 	Socials *Socials `json:"socials"`
 }
 
 // NewPerson creates a new Person with a random ID.
 func NewPerson(name string) *Person {
 	return &Person{
-		ID:   uuid.New().String(),
-		Name: name,
+		ID:      uuid.New().String(),
+		Name:    name,
+		Role:    RoleAuthor,
+		Images:  NewImages(),
+		Socials: NewSocials(),
 	}
 }
 
+// GetID returns the ID of the Person.
+func (p *Person) GetID() string {
+	return p.ID
+}
+
 // Normalize validates and trims the fields of the Person.
+// The Person is reset to the zero value if it is invalid.
 func (p *Person) Normalize() {
+	if err := p.normalize(nil, "Person"); err != nil {
+		*p = Person{}
+	}
+}
+
+// normalize trims the fields, records the changes to the report and returns the validation error.
+func (p *Person) normalize(report *NormalizeReport, path string) error {
 
 	if p.ID == "" {
 		p.ID = uuid.New().String()
+		report.fallback(path+".ID", "")
 	}
 
-	p.ID = TrimToMaxLen(p.ID, 36)
-	p.Name = TrimToMaxLen(p.Name, 255)
-	p.Role = TrimToMaxLen(p.Role, 255)
+	p.ID = report.trim(path+".ID", p.ID, 36)
+	p.Name = report.trim(path+".Name", p.Name, report.limits().Name)
+	p.Role = report.trim(path+".Role", strings.ToLower(p.Role), report.limits().Name)
 
-	if p.Socials != nil {
-		for _, social := range p.Socials.Slice() {
-			social.Normalize()
-		}
+	if p.Role == "" {
+		p.Role = RoleAuthor
+		report.fallback(path+".Role", "")
+	}
+
+	if p.Images == nil {
+		p.Images = NewImages()
+	}
+
+	if p.Socials == nil {
+		p.Socials = NewSocials()
+	}
+
+	p.Images.normalize(report, path+".Images")
+	p.Socials.normalize(report, path+".Socials")
+
+	err := report.validate(p)
+	if err != nil {
+		slog.Debug("Validation error in Person", slog.String("path", path), slog.String("error", err.Error()))
 	}
+
+	return err
 }
 
 // Map converts the Person struct to a map[string]any.
 func (p *Person) Map() map[string]any {
 
-	images := make([]map[string]any, p.Images.Len())
-	for i, image := range p.Images.Slice() {
-		images[i] = image.Map()
+	var images []map[string]any
+	if p.Images != nil {
+		images = make([]map[string]any, p.Images.Len())
+		for i, image := range p.Images.Slice() {
+			images[i] = image.Map()
+		}
 	}
 
-	socials := make([]map[string]any, p.Socials.Len())
-	for i, social := range p.Socials.Slice() {
-		socials[i] = social.Map()
+	var socials []map[string]any
+	if p.Socials != nil {
+		socials = make([]map[string]any, p.Socials.Len())
+		for i, social := range p.Socials.Slice() {
+			socials[i] = social.Map()
+		}
 	}
 
 	return map[string]any{
@@ -61,48 +126,200 @@ func (p *Person) Map() map[string]any {
 	}
 }
 
+// NewPersonFromMap creates a Person from a map[string]any, validates it, and returns a pointer to the Person or an error.
+func NewPersonFromMap(m map[string]any) (*Person, error) {
+	return personFromMap(newMapReader(m))
+}
+
+// NewPersonFromMapStrict creates a Person from a map[string]any like NewPersonFromMap,
+// but fails with MapErrors listing every key holding a value of unexpected type.
+func NewPersonFromMapStrict(m map[string]any) (*Person, error) {
+	return personFromMap(newStrictMapReader(m))
+}
+
+// personFromMap reads the Person with the map reader, validates it, and returns a pointer to the Person or an error.
+func personFromMap(r *mapReader) (*Person, error) {
+
+	images := NewImages()
+	for _, sub := range r.maps("images") {
+		if img, err := imageFromMap(sub); err == nil {
+			images.addWithLimits(r.limits, img)
+		}
+	}
+
+	socials := NewSocials()
+	for _, sub := range r.maps("socials") {
+		if profile, err := socialProfileFromMap(sub); err == nil {
+			socials.addWithLimits(r.limits, profile)
+		}
+	}
+
+	person := &Person{
+		ID:      r.string("id"),
+		Name:    r.string("name"),
+		Role:    r.string("role"),
+		Images:  images,
+		Socials: socials,
+	}
+
+	if err := r.failed(); err != nil {
+		return nil, err
+	}
+
+	err := r.validate(person)
+	if err != nil {
+		return nil, err
+	}
+
+	return person, nil
+}
+
+// Persons represents a collection of Person pointers.
+// The nil Persons is empty, e.g. the Contributors of the Article decoded from the JSON without the contributors.
 type Persons struct {
-	items []*Person
+	Collection[*Person]
 }
 
-// NewPersons creates a new Persons collection.
+// NewPersons creates a collection, skips invalid items, and logs errors
 func NewPersons(persons ...*Person) *Persons {
+	return &Persons{Collection: *NewCollection(persons...)}
+}
 
-	valid := make([]*Person, 0, len(persons))
-	for _, person := range persons {
-		if err := validate.Struct(person); err == nil {
-			valid = append(valid, person)
-		} else {
-			slog.Debug("Invalid person skipped", slog.String("error", err.Error()))
-		}
+// NewPersonsStrict creates a collection and validates every item
+func NewPersonsStrict(persons ...*Person) (*Persons, error) {
+
+	list, err := NewCollectionStrict(persons...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Persons{Collection: *list}, nil
+}
+
+// Add adds items to the collection, invalid items are skipped.
+// The nil collection returns a new one with the items.
+func (list *Persons) Add(persons ...*Person) *Persons {
+	if list == nil {
+		return NewPersons(persons...)
+	}
+	list.Collection.Add(persons...)
+	return list
+}
+
+// Remove removes items by ID
+func (list *Persons) Remove(ids ...string) *Persons {
+	if list == nil {
+		return nil
+	}
+	list.Collection.Remove(ids...)
+	return list
+}
+
+// Filter returns a new Persons collection filtered by the provided functions
+func (list *Persons) Filter(fns ...func(*Person) bool) *Persons {
+	if list == nil {
+		return NewPersons()
+	}
+	return &Persons{Collection: *list.Collection.Filter(fns...)}
+}
+
+// Role returns a new Persons collection with the persons of the given role.
+func (list *Persons) Role(role string) *Persons {
+	return list.Filter(func(person *Person) bool {
+		return strings.EqualFold(person.Role, role)
+	})
+}
+
+// Names returns a slice of all Person names.
+func (list *Persons) Names() []string {
+	names := make([]string, list.Len())
+	for idx, person := range list.Slice() {
+		names[idx] = person.Name
 	}
+	return names
+}
 
-	return &Persons{items: valid}
+// Byline returns comma-separated names of the authors, e.g. "John Doe, Jane Smith".
+func (list *Persons) Byline() string {
+	return strings.Join(list.Role(RoleAuthor).Names(), ", ")
 }
 
-// Get returns the Person by ID.
+// Get returns the person by ID
 func (list *Persons) Get(id string) (*Person, bool) {
-	for _, person := range list.items {
-		if person.ID == id {
-			return person, true
-		}
+	if list == nil {
+		return nil, false
 	}
-	return nil, false
+	return list.Collection.Get(id)
 }
 
-// Slice returns a slice of all Persons.
+// Slice returns a slice of all persons
 func (list *Persons) Slice() []*Person {
-	return list.items
+	if list == nil {
+		return nil
+	}
+	return list.Collection.Slice()
 }
 
-// Add adds Persons to the collection.
-func (list *Persons) Add(persons ...*Person) *Persons {
-	for _, person := range persons {
-		if err := validate.Struct(person); err == nil {
-			list.items = append(list.items, person)
-		} else {
-			slog.Debug("Invalid person skipped", slog.String("error", err.Error()))
-		}
+// IDs returns the IDs of the persons
+func (list *Persons) IDs() []string {
+	if list == nil {
+		return []string{}
+	}
+	return list.Collection.IDs()
+}
+
+// Len returns the number of the persons
+func (list *Persons) Len() int {
+	if list == nil {
+		return 0
+	}
+	return list.Collection.Len()
+}
+
+// Sort sorts the persons in place by the comparison function, see Collection.Sort
+func (list *Persons) Sort(cmp func(a, b *Person) int) *Persons {
+	if list == nil {
+		return nil
+	}
+	list.Collection.Sort(cmp)
+	return list
+}
+
+// Dedupe removes the persons with the duplicate ID, the first person is kept
+func (list *Persons) Dedupe() *Persons {
+	if list == nil {
+		return nil
 	}
+	list.Collection.Dedupe()
 	return list
 }
+
+// DedupeFunc removes the persons with the duplicate key, the first person is kept
+func (list *Persons) DedupeFunc(key func(*Person) string) *Persons {
+	if list == nil {
+		return nil
+	}
+	list.Collection.DedupeFunc(key)
+	return list
+}
+
+// Maps converts the persons to a slice of maps
+func (list *Persons) Maps() []map[string]any {
+	if list == nil {
+		return []map[string]any{}
+	}
+	return list.Collection.Maps()
+}
+
+// Normalize removes invalid persons
+func (list *Persons) Normalize() {
+	list.normalize(nil, "")
+}
+
+// normalize removes invalid persons and records the changes to the report
+func (list *Persons) normalize(report *NormalizeReport, path string) {
+	if list == nil {
+		return
+	}
+	list.Collection.normalize(report, path)
+}
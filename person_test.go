@@ -0,0 +1,104 @@
+package article_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+func fakePerson(role string) *article.Person {
+	p := article.NewPerson(gofakeit.Name())
+	p.Role = role
+	return p
+}
+
+func TestPersonNormalize(t *testing.T) {
+	p := &article.Person{
+		Name: "  " + gofakeit.Name() + "  ",
+		Role: " Editor ",
+	}
+
+	p.Normalize()
+
+	assert.NotEmpty(t, p.ID)
+	assert.Equal(t, article.RoleEditor, p.Role)
+	assert.NotNil(t, p.Images)
+	assert.NotNil(t, p.Socials)
+
+	p = &article.Person{Name: gofakeit.Name()}
+	p.Normalize()
+	assert.Equal(t, article.RoleAuthor, p.Role)
+
+	// name is required
+	p = &article.Person{Role: article.RoleAuthor}
+	p.Normalize()
+	assert.Equal(t, article.Person{}, *p)
+}
+
+func TestPersonConversions(t *testing.T) {
+
+	expected := fakePerson(article.RolePhotographer)
+	expected.Images.Add(NewImageValid())
+	expected.Socials.Add(fakeSocialProfile())
+
+	got, err := article.NewPersonFromMap(expected.Map())
+	require.NoError(t, err)
+	assert.Equal(t, expected, got)
+
+	_, err = article.NewPersonFromMap(map[string]any{"id": gofakeit.UUID()})
+	assert.Error(t, err)
+}
+
+func TestPersons_Role(t *testing.T) {
+
+	author1 := fakePerson(article.RoleAuthor)
+	author2 := fakePerson(article.RoleAuthor)
+	editor := fakePerson(article.RoleEditor)
+
+	persons := article.NewPersons(author1, editor, author2)
+
+	assert.Equal(t, 2, persons.Role(article.RoleAuthor).Len())
+	assert.Equal(t, 1, persons.Role("EDITOR").Len())
+	assert.Equal(t, author1.Name+", "+author2.Name, persons.Byline())
+}
+
+func TestPersons_Remove(t *testing.T) {
+
+	p1 := fakePerson(article.RoleAuthor)
+	p2 := fakePerson(article.RoleTranslator)
+	persons := article.NewPersons(p1, p2)
+
+	persons.Remove(p1.ID)
+	assert.Equal(t, []string{p2.ID}, persons.IDs())
+}
+
+func TestPersons_Normalize(t *testing.T) {
+
+	valid := fakePerson(article.RoleAuthor)
+	invalid := fakePerson(article.RoleAuthor)
+
+	persons := article.NewPersons(valid, invalid)
+	invalid.Name = "  "
+
+	persons.Normalize()
+
+	assert.Equal(t, []string{valid.ID}, persons.IDs())
+}
+
+func TestPersons_JSON(t *testing.T) {
+
+	persons := article.NewPersons(fakePerson(article.RoleAuthor), fakePerson(article.RoleEditor))
+
+	data, err := json.Marshal(persons)
+	require.NoError(t, err)
+
+	got := article.NewPersons()
+	require.NoError(t, json.Unmarshal(data, got))
+	assert.Equal(t, persons.IDs(), got.IDs())
+	assert.Equal(t, persons.Names(), got.Names())
+}
@@ -0,0 +1,386 @@
+package article
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Names of the built-in social platforms.
+const (
+	PlatformX         = "x"
+	PlatformFacebook  = "facebook"
+	PlatformInstagram = "instagram"
+	PlatformLinkedIn  = "linkedin"
+	PlatformYouTube   = "youtube"
+	PlatformTikTok    = "tiktok"
+	PlatformTelegram  = "telegram"
+	PlatformVK        = "vk"
+	PlatformThreads   = "threads"
+	PlatformBluesky   = "bluesky"
+	PlatformGitHub    = "github"
+	PlatformReddit    = "reddit"
+	PlatformPinterest = "pinterest"
+	PlatformTwitch    = "twitch"
+)
+
+// SocialPlatform recognizes the profiles of the social network by the URL.
+type SocialPlatform struct {
+	// Name of the platform, e.g. x. It is the canonical Social.Platform.
+	Name string
+	// Aliases are the other names of the platform matched case-insensitively, e.g. Twitter.
+	Aliases []string
+	// Hosts of the platform, subdomains included, e.g. twitter.com matches mobile.twitter.com.
+	Hosts []string
+	// Handle returns the account handle or ID of the profile URL, empty if the URL is not a profile, e.g. post.
+	Handle func(u *url.URL) string
+	// ProfileURL is the template of the profile URL with the {handle} placeholder, e.g. https://x.com/{handle}.
+	ProfileURL string
+	// Post returns the author handle and the post ID of the post URL, empty ID if the URL is not a post, see DetectPost.
+	// The handle is empty if the URL has none, e.g. instagram.com/p/ID.
+	Post func(u *url.URL) (handle, id string)
+}
+
+// SocialPlatforms are the platforms recognized during Normalize, the first matching platform is used.
+// Append own platforms or set to nil to disable the recognition.
+var SocialPlatforms = DefaultSocialPlatforms()
+
+// DefaultSocialPlatforms returns the platforms of the popular social networks.
+func DefaultSocialPlatforms() []*SocialPlatform {
+	return []*SocialPlatform{
+		{
+			Name:    PlatformX,
+			Aliases: []string{"twitter", "x.com", "twitter.com"},
+			Hosts:   []string{"x.com", "twitter.com"},
+			Handle: firstSegment(`^[A-Za-z0-9_]{1,15}$`,
+				"home", "i", "intent", "share", "search", "hashtag", "explore", "settings", "messages", "notifications", "login"),
+			ProfileURL: "https://x.com/{handle}",
+			Post:       postPattern(`^/(?:i/web|i|(?P<handle>[A-Za-z0-9_]{1,15}))/status(?:es)?/(?P<id>[0-9]{1,20})`),
+		},
+		{
+			Name:       PlatformFacebook,
+			Aliases:    []string{"fb", "facebook.com"},
+			Hosts:      []string{"facebook.com", "fb.com"},
+			Handle:     facebookHandle,
+			ProfileURL: "https://www.facebook.com/{handle}",
+			Post:       postPattern(`^/(?P<handle>[A-Za-z0-9.]{3,50})/posts/(?P<id>[A-Za-z0-9]{5,})`),
+		},
+		{
+			Name:    PlatformInstagram,
+			Aliases: []string{"ig", "insta", "instagram.com"},
+			Hosts:   []string{"instagram.com", "instagr.am"},
+			Handle: firstSegment(`^[A-Za-z0-9._]{1,30}$`,
+				"p", "reel", "reels", "explore", "stories", "accounts", "tv", "direct"),
+			ProfileURL: "https://www.instagram.com/{handle}",
+			Post:       postPattern(`^/(?:(?P<handle>[A-Za-z0-9._]{1,30})/)?(?:p|reel|tv)/(?P<id>[A-Za-z0-9_-]{5,})`),
+		},
+		{
+			Name:       PlatformLinkedIn,
+			Aliases:    []string{"linkedin.com"},
+			Hosts:      []string{"linkedin.com"},
+			Handle:     prefixedSegment(`^[A-Za-z0-9_%-]{3,100}$`, "in"),
+			ProfileURL: "https://www.linkedin.com/in/{handle}",
+		},
+		{
+			Name:       PlatformYouTube,
+			Aliases:    []string{"youtube.com", "yt"},
+			Hosts:      []string{"youtube.com"},
+			Handle:     atSegment(`^[A-Za-z0-9._-]{3,30}$`),
+			ProfileURL: "https://www.youtube.com/@{handle}",
+		},
+		{
+			Name:       PlatformTikTok,
+			Aliases:    []string{"tiktok.com"},
+			Hosts:      []string{"tiktok.com"},
+			Handle:     atSegment(`^[A-Za-z0-9._]{2,24}$`),
+			ProfileURL: "https://www.tiktok.com/@{handle}",
+		},
+		{
+			Name:    PlatformTelegram,
+			Aliases: []string{"tg", "t.me"},
+			Hosts:   []string{"t.me", "telegram.me", "telegram.dog"},
+			Handle: func(u *url.URL) string {
+				segments := pathSegments(u)
+				// public channel preview, e.g. t.me/s/channel
+				if len(segments) > 1 && segments[0] == "s" {
+					u = &url.URL{Path: "/" + segments[1]}
+				}
+				return firstSegment(`^[A-Za-z0-9_]{4,32}$`, "joinchat", "share", "addstickers", "proxy", "c", "iv")(u)
+			},
+			ProfileURL: "https://t.me/{handle}",
+			Post:       postPattern(`^/(?:s/)?(?P<handle>[A-Za-z0-9_]{4,32})/(?P<id>[0-9]{1,20})`),
+		},
+		{
+			Name:    PlatformVK,
+			Aliases: []string{"vkontakte", "vk.com"},
+			Hosts:   []string{"vk.com", "vk.ru", "vkontakte.ru"},
+			Handle: firstSegment(`^[A-Za-z0-9_.]{2,32}$`,
+				"feed", "im", "search", "settings", "video", "audio", "music", "login", "share.php"),
+			ProfileURL: "https://vk.com/{handle}",
+		},
+		{
+			Name:       PlatformThreads,
+			Aliases:    []string{"threads.net"},
+			Hosts:      []string{"threads.net", "threads.com"},
+			Handle:     atSegment(`^[A-Za-z0-9._]{1,30}$`),
+			ProfileURL: "https://www.threads.net/@{handle}",
+			Post:       postPattern(`^/@(?P<handle>[A-Za-z0-9._]{1,30})/post/(?P<id>[A-Za-z0-9_-]{5,})`),
+		},
+		{
+			Name:       PlatformBluesky,
+			Aliases:    []string{"bsky", "bsky.app"},
+			Hosts:      []string{"bsky.app"},
+			Handle:     prefixedSegment(`^[A-Za-z0-9.:-]{3,253}$`, "profile"),
+			ProfileURL: "https://bsky.app/profile/{handle}",
+			Post:       postPattern(`^/profile/(?P<handle>[A-Za-z0-9.:-]{3,253})/post/(?P<id>[a-z0-9]{5,})`),
+		},
+		{
+			Name:    PlatformGitHub,
+			Aliases: []string{"github.com"},
+			Hosts:   []string{"github.com"},
+			Handle: firstSegment(`^[A-Za-z0-9-]{1,39}$`,
+				"features", "topics", "about", "login", "settings", "sponsors", "marketplace", "explore", "orgs", "search"),
+			ProfileURL: "https://github.com/{handle}",
+		},
+		{
+			Name:       PlatformReddit,
+			Aliases:    []string{"reddit.com"},
+			Hosts:      []string{"reddit.com"},
+			Handle:     prefixedSegment(`^[A-Za-z0-9_-]{3,20}$`, "user", "u"),
+			ProfileURL: "https://www.reddit.com/user/{handle}",
+		},
+		{
+			Name:       PlatformPinterest,
+			Aliases:    []string{"pinterest.com"},
+			Hosts:      []string{"pinterest.com", "pin.it"},
+			Handle:     firstSegment(`^[A-Za-z0-9_]{3,30}$`, "pin", "search", "ideas", "today", "business"),
+			ProfileURL: "https://www.pinterest.com/{handle}",
+		},
+		{
+			Name:       PlatformTwitch,
+			Aliases:    []string{"twitch.tv"},
+			Hosts:      []string{"twitch.tv"},
+			Handle:     firstSegment(`^[A-Za-z0-9_]{4,25}$`, "videos", "directory", "p", "search", "settings", "downloads"),
+			ProfileURL: "https://www.twitch.tv/{handle}",
+		},
+	}
+}
+
+// DetectSocial returns the platform and the handle of the profile URL, nil if the host is unknown.
+// The handle is empty if the URL is not a profile, e.g. post of the known platform.
+func DetectSocial(raw string) (*SocialPlatform, string) {
+
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, ""
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "" {
+		return nil, ""
+	}
+
+	for _, platform := range SocialPlatforms {
+		if platform == nil || !platform.host(host) {
+			continue
+		}
+		if platform.Handle == nil {
+			return platform, ""
+		}
+		return platform, platform.Handle(u)
+	}
+
+	return nil, ""
+}
+
+// SocialPlatformByName returns the platform of the SocialPlatforms by the name or the alias, nil if not found.
+func SocialPlatformByName(name string) *SocialPlatform {
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+
+	for _, platform := range SocialPlatforms {
+		if platform == nil {
+			continue
+		}
+		if strings.EqualFold(platform.Name, name) {
+			return platform
+		}
+		for _, alias := range platform.Aliases {
+			if strings.EqualFold(alias, name) {
+				return platform
+			}
+		}
+	}
+
+	return nil
+}
+
+// Profile returns the profile URL of the handle, empty if the template is not set.
+func (p *SocialPlatform) Profile(handle string) string {
+
+	if p.ProfileURL == "" || handle == "" {
+		return ""
+	}
+
+	return strings.ReplaceAll(p.ProfileURL, "{handle}", url.PathEscape(handle))
+}
+
+// host is true if the host is one of the platform hosts or their subdomain
+func (p *SocialPlatform) host(host string) bool {
+
+	for _, h := range p.Hosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Detect recognizes the platform and the handle from the URL, canonicalizes the Platform
+// and replaces the URL with the profile URL of the platform, e.g. mobile.twitter.com/jack?s=20 with x.com/jack.
+// The known platform name without the URL match is canonicalized too, e.g. Twitter to x.
+// Returns false for the unknown platform.
+func (s *Social) Detect() bool {
+	return s.detect(nil, "Social")
+}
+
+// detect recognizes the platform and records the changes to the report
+func (s *Social) detect(report *NormalizeReport, path string) bool {
+
+	platform, handle := DetectSocial(s.URL)
+	if platform == nil {
+		platform = SocialPlatformByName(s.Platform)
+	}
+
+	if platform == nil {
+		return false
+	}
+
+	if s.Platform != platform.Name {
+		report.Add(path+".Platform", "platform", s.Platform, ActionCanonicalized)
+		s.Platform = platform.Name
+	}
+
+	if handle == "" {
+		return true
+	}
+
+	if s.Handle != handle {
+		s.Handle = handle
+		report.fallback(path+".Handle", handle)
+	}
+
+	if profile := platform.Profile(handle); profile != "" && profile != s.URL {
+		report.Add(path+".URL", "platform", s.URL, ActionCanonicalized)
+		s.URL = profile
+	}
+
+	return true
+}
+
+// key returns the dedupe key of the platform and the handle, the URL for the profiles without the handle
+func (s *Social) key() string {
+
+	if s.Handle != "" {
+		return strings.ToLower(s.Platform) + "\n" + strings.ToLower(s.Handle)
+	}
+
+	return s.URL
+}
+
+// firstSegment returns the first path segment matching the pattern, the reserved segments are not the handles
+func firstSegment(pattern string, reserved ...string) func(u *url.URL) string {
+
+	re := regexp.MustCompile(pattern)
+
+	return func(u *url.URL) string {
+		segments := pathSegments(u)
+		if len(segments) == 0 {
+			return ""
+		}
+		for _, r := range reserved {
+			if strings.EqualFold(segments[0], r) {
+				return ""
+			}
+		}
+		if re.MatchString(segments[0]) {
+			return segments[0]
+		}
+		return ""
+	}
+}
+
+// prefixedSegment returns the segment after the prefix, e.g. in of linkedin.com/in/john-doe
+func prefixedSegment(pattern string, prefixes ...string) func(u *url.URL) string {
+
+	re := regexp.MustCompile(pattern)
+
+	return func(u *url.URL) string {
+		segments := pathSegments(u)
+		if len(segments) < 2 {
+			return ""
+		}
+		for _, prefix := range prefixes {
+			if strings.EqualFold(segments[0], prefix) && re.MatchString(segments[1]) {
+				return segments[1]
+			}
+		}
+		return ""
+	}
+}
+
+// atSegment returns the first segment starting with @ without the @, e.g. tiktok.com/@handle
+func atSegment(pattern string) func(u *url.URL) string {
+
+	re := regexp.MustCompile(pattern)
+
+	return func(u *url.URL) string {
+		segments := pathSegments(u)
+		if len(segments) == 0 {
+			return ""
+		}
+		if handle, ok := strings.CutPrefix(segments[0], "@"); ok && re.MatchString(handle) {
+			return handle
+		}
+		return ""
+	}
+}
+
+var (
+	facebookHandlePattern = regexp.MustCompile(`^[A-Za-z0-9.-]{3,50}$`)
+	facebookIDPattern     = regexp.MustCompile(`^[0-9]{5,20}$`)
+)
+
+// facebookHandle supports the username, profile.php?id= and people/Name/ID URLs
+func facebookHandle(u *url.URL) string {
+
+	segments := pathSegments(u)
+	if len(segments) == 0 {
+		return ""
+	}
+
+	switch strings.ToLower(segments[0]) {
+	case "profile.php":
+		if id := u.Query().Get("id"); facebookIDPattern.MatchString(id) {
+			return id
+		}
+		return ""
+	case "people", "pages":
+		if len(segments) > 2 {
+			return segments[2]
+		}
+		return ""
+	case "sharer", "sharer.php", "share", "groups", "events", "watch", "photo.php", "story.php", "login", "permalink.php", "hashtag":
+		return ""
+	}
+
+	if facebookHandlePattern.MatchString(segments[0]) {
+		return segments[0]
+	}
+
+	return ""
+}
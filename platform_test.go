@@ -0,0 +1,161 @@
+package article_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+func TestDetectSocial(t *testing.T) {
+
+	tests := []struct {
+		url      string
+		platform string
+		handle   string
+	}{
+		{"https://twitter.com/jack", article.PlatformX, "jack"},
+		{"https://mobile.twitter.com/jack?s=20", article.PlatformX, "jack"},
+		{"https://x.com/jack/status/20", article.PlatformX, "jack"},
+		{"https://x.com/intent/tweet?text=hi", article.PlatformX, ""},
+		{"https://m.facebook.com/zuck", article.PlatformFacebook, "zuck"},
+		{"https://www.facebook.com/profile.php?id=100004123456789", article.PlatformFacebook, "100004123456789"},
+		{"https://www.facebook.com/people/John-Doe/100004123456789/", article.PlatformFacebook, "100004123456789"},
+		{"https://www.facebook.com/sharer/sharer.php?u=x", article.PlatformFacebook, ""},
+		{"https://instagram.com/natgeo/?hl=en", article.PlatformInstagram, "natgeo"},
+		{"https://www.instagram.com/p/C1a2b3c4d5e/", article.PlatformInstagram, ""},
+		{"https://ru.linkedin.com/in/john-doe-123", article.PlatformLinkedIn, "john-doe-123"},
+		{"https://www.linkedin.com/company/acme", article.PlatformLinkedIn, ""},
+		{"https://m.youtube.com/@mkbhd/videos", article.PlatformYouTube, "mkbhd"},
+		{"https://www.tiktok.com/@scout2015?lang=en", article.PlatformTikTok, "scout2015"},
+		{"https://t.me/durov", article.PlatformTelegram, "durov"},
+		{"https://t.me/s/durov", article.PlatformTelegram, "durov"},
+		{"https://t.me/joinchat/AAAAAE", article.PlatformTelegram, ""},
+		{"https://m.vk.com/id1", article.PlatformVK, "id1"},
+		{"https://www.threads.net/@zuck", article.PlatformThreads, "zuck"},
+		{"https://bsky.app/profile/jay.bsky.team", article.PlatformBluesky, "jay.bsky.team"},
+		{"https://github.com/golang/go", article.PlatformGitHub, "golang"},
+		{"https://old.reddit.com/u/spez", article.PlatformReddit, "spez"},
+		{"https://www.twitch.tv/shroud", article.PlatformTwitch, "shroud"},
+		{"https://mastodon.social/@Gargron", "", ""},
+		{"not a url", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			platform, handle := article.DetectSocial(tt.url)
+			if tt.platform == "" {
+				assert.Nil(t, platform)
+				return
+			}
+			require.NotNil(t, platform)
+			assert.Equal(t, tt.platform, platform.Name)
+			assert.Equal(t, tt.handle, handle)
+		})
+	}
+}
+
+func TestSocialPlatformByName(t *testing.T) {
+
+	for _, name := range []string{"twitter", "X", "x.com", "Twitter", " TWITTER "} {
+		platform := article.SocialPlatformByName(name)
+		require.NotNil(t, platform, name)
+		assert.Equal(t, article.PlatformX, platform.Name)
+	}
+
+	assert.Nil(t, article.SocialPlatformByName("Mastodon"))
+	assert.Nil(t, article.SocialPlatformByName(""))
+}
+
+func TestSocial_NormalizePlatform(t *testing.T) {
+
+	tests := []struct {
+		platform string
+		url      string
+		want     article.Social
+	}{
+		{"Twitter", "https://mobile.twitter.com/Jack?s=20", article.Social{Platform: "x", Handle: "Jack", URL: "https://x.com/Jack"}},
+		{"", "https://instagram.com/natgeo/?hl=en", article.Social{Platform: "instagram", Handle: "natgeo", URL: "https://www.instagram.com/natgeo"}},
+		{"Facebook", "https://www.facebook.com/groups/golang", article.Social{Platform: "facebook", URL: "https://www.facebook.com/groups/golang"}},
+		{"x.com", "https://example.com/jack", article.Social{Platform: "x", URL: "https://example.com/jack"}},
+		{"Mastodon", "https://mastodon.social/@Gargron", article.Social{Platform: "Mastodon", URL: "https://mastodon.social/@Gargron"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			social := article.NewSocial(tt.platform, tt.url)
+			social.Normalize()
+			assert.Equal(t, tt.want.Platform, social.Platform)
+			assert.Equal(t, tt.want.Handle, social.Handle)
+			assert.Equal(t, tt.want.URL, social.URL)
+		})
+	}
+}
+
+func TestSocials_NormalizeDedupe(t *testing.T) {
+
+	a := article.NewArticle()
+	a.Title = "Title"
+	a.Text = "Text"
+	a.Markup = "<p>Text</p>"
+	a.Socials.Add(
+		article.NewSocial("Twitter", "https://twitter.com/jack"),
+		article.NewSocial("X", "https://x.com/JACK?ref_src=twsrc"),
+		article.NewSocial("", "https://mobile.twitter.com/jack/"),
+		article.NewSocial("Instagram", "https://instagram.com/jack"),
+		article.NewSocial("Website", "https://example.com"),
+		article.NewSocial("Homepage", "https://example.com/"),
+	)
+
+	report, err := a.NormalizeWithReport()
+	require.NoError(t, err)
+
+	require.Equal(t, 3, a.Socials.Len())
+	assert.Equal(t, "https://x.com/jack", a.Socials.Slice()[0].URL)
+	assert.Equal(t, "https://www.instagram.com/jack", a.Socials.Slice()[1].URL)
+	assert.Equal(t, "https://example.com/", a.Socials.Slice()[2].URL)
+
+	removed := report.Filter(article.ActionRemoved)
+	require.Len(t, removed, 3)
+	assert.Equal(t, "Article.Socials[1]", removed[0].Field)
+	assert.Equal(t, "duplicate", removed[0].Tag)
+
+	// standalone collection
+	socials := article.NewSocials(
+		article.NewSocial("", "https://t.me/durov"),
+		article.NewSocial("telegram", "https://t.me/s/durov"),
+	)
+	socials.Normalize()
+	assert.Equal(t, 1, socials.Len())
+}
+
+func TestSocialPlatforms_Custom(t *testing.T) {
+
+	defer func(platforms []*article.SocialPlatform) { article.SocialPlatforms = platforms }(article.SocialPlatforms)
+
+	article.SocialPlatforms = append(article.SocialPlatforms, &article.SocialPlatform{
+		Name:    "mastodon",
+		Aliases: []string{"fediverse"},
+		Hosts:   []string{"mastodon.social"},
+		Handle: func(u *url.URL) string {
+			handle, _ := strings.CutPrefix(strings.Trim(u.Path, "/"), "@")
+			return handle
+		},
+		ProfileURL: "https://mastodon.social/@{handle}",
+	})
+
+	social := article.NewSocial("Fediverse", "https://mastodon.social/@Gargron/")
+	social.Normalize()
+	assert.Equal(t, "mastodon", social.Platform)
+	assert.Equal(t, "Gargron", social.Handle)
+	assert.Equal(t, "https://mastodon.social/@Gargron", social.URL)
+
+	article.SocialPlatforms = nil
+	social = article.NewSocial("Twitter", "https://twitter.com/jack")
+	assert.False(t, social.Detect())
+	assert.Equal(t, "Twitter", social.Platform)
+}
@@ -0,0 +1,230 @@
+package article
+
+import (
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// PlatformMastodon is the platform of the posts of the Mastodon instances.
+// The instances are not in the SocialPlatforms, the posts are recognized by the URL path, e.g. /@Gargron/123.
+const PlatformMastodon = "mastodon"
+
+// Types of the PostMedia.
+const (
+	PostMediaImage = "image"
+	PostMediaVideo = "video"
+)
+
+// PostMedia is the image or video attached to the social post of the Quote.
+type PostMedia struct {
+
+	// URL is the URL of the media file or its page, e.g. https://pic.twitter.com/abc.
+	// This field is required and should be a valid URL.
+	URL string `json:"url" validate:"required,url,limit=URL"`
+
+	// Type is the media type: image or video.
+	// This field is optional, empty if unknown.
+	Type string `json:"type,omitempty" validate:"omitempty,oneof=image video"`
+}
+
+// normalize trims the fields and records the changes to the report
+func (m *PostMedia) normalize(report *NormalizeReport, path string) error {
+
+	m.URL = URLCanonicalizer.asset(report, path+".URL", report.trim(path+".URL", m.URL, report.limits().URL), report.baseURL())
+	m.Type = strings.ToLower(report.trim(path+".Type", m.Type, 5))
+
+	err := report.validate(m)
+	if err != nil {
+		slog.Debug("Validation error in PostMedia", slog.String("path", path), slog.String("error", err.Error()))
+	}
+
+	return err
+}
+
+// Map converts the PostMedia struct to a map[string]any.
+func (m *PostMedia) Map() map[string]any {
+	return map[string]any{
+		"url":  m.URL,
+		"type": m.Type,
+	}
+}
+
+// postMediaFromMap reads the PostMedia with the map reader and validates it
+func postMediaFromMap(r *mapReader) (*PostMedia, error) {
+
+	media := &PostMedia{
+		URL:  r.string("url"),
+		Type: r.string("type"),
+	}
+
+	if err := r.failed(); err != nil {
+		return nil, err
+	}
+
+	if err := r.validate(media); err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// mastodonPost recognizes the post URL of any host, e.g. mastodon.social/@Gargron/123 and its /embed
+var mastodonPost = postPattern(`^/@(?P<handle>[A-Za-z0-9_]{1,30}(?:@[A-Za-z0-9.-]+)?)/(?P<id>[0-9]{1,20})(?:/embed)?/?$`)
+
+// DetectPost returns the platform name, the author handle and the post ID of the social post URL,
+// all empty if the URL is not a post, e.g. x.com/jack/status/20 is x, jack, 20.
+// The platform is one of the SocialPlatforms or mastodon for the /@handle/id URLs of the other hosts.
+func DetectPost(raw string) (platform, handle, id string) {
+
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", "", ""
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "" {
+		return "", "", ""
+	}
+
+	for _, p := range SocialPlatforms {
+		if p == nil || !p.host(host) {
+			continue
+		}
+		if p.Post == nil {
+			return "", "", ""
+		}
+		if handle, id = p.Post(u); id != "" {
+			return p.Name, handle, id
+		}
+		return "", "", ""
+	}
+
+	if handle, id = mastodonPost(u); id != "" {
+		return PlatformMastodon, handle, id
+	}
+
+	return "", "", ""
+}
+
+// Detect recognizes the platform, the author handle and the post ID from the SourceURL
+// and canonicalizes the Platform, e.g. Twitter to x. The empty Handle is filled, the set one is kept.
+// Returns false if the SourceURL is not a social post.
+func (q *Quote) Detect() bool {
+	return q.detect(nil, "Quote")
+}
+
+// detect recognizes the post and records the changes to the report
+func (q *Quote) detect(report *NormalizeReport, path string) bool {
+
+	platform, handle, id := DetectPost(q.SourceURL)
+	if id == "" {
+		if p := SocialPlatformByName(q.Platform); p != nil && q.Platform != p.Name {
+			report.Add(path+".Platform", "platform", q.Platform, ActionCanonicalized)
+			q.Platform = p.Name
+		}
+		return false
+	}
+
+	if q.Platform != platform {
+		report.Add(path+".Platform", "platform", q.Platform, ActionCanonicalized)
+		q.Platform = platform
+	}
+
+	if q.Handle == "" && handle != "" {
+		q.Handle = handle
+		report.fallback(path+".Handle", handle)
+	}
+
+	if q.PostID != id {
+		q.PostID = id
+		report.fallback(path+".PostID", id)
+	}
+
+	return true
+}
+
+// EmbedHTML returns the embed code of the social post in the markup of the platform widget:
+// blockquote.twitter-tweet for x, blockquote.instagram-media for instagram, blockquote.mastodon-embed for mastodon
+// and the post iframe for telegram. The widget scripts are not included, the page loads them.
+// Returns empty string for the other platforms and the unrecognized posts, see Detect.
+func (q *Quote) EmbedHTML() string {
+
+	if q.PostID == "" || q.SourceURL == "" {
+		return ""
+	}
+
+	source := html.EscapeString(q.SourceURL)
+
+	var b strings.Builder
+
+	switch q.Platform {
+	case PlatformX:
+		b.WriteString(`<blockquote class="twitter-tweet">`)
+		if q.Text != "" {
+			b.WriteString(`<p>` + postText(q.Text) + `</p>`)
+		}
+		b.WriteString(`&mdash;`)
+		if q.Author != "" {
+			b.WriteString(` ` + html.EscapeString(q.Author))
+		}
+		if q.Handle != "" {
+			b.WriteString(` (@` + html.EscapeString(q.Handle) + `)`)
+		}
+		date := q.SourceURL
+		if !q.Posted.IsZero() {
+			date = q.Posted.Format("January 2, 2006")
+		}
+		b.WriteString(` <a href="` + source + `">` + html.EscapeString(date) + `</a></blockquote>`)
+
+	case PlatformInstagram:
+		b.WriteString(`<blockquote class="instagram-media" data-instgrm-permalink="` + source + `">`)
+		b.WriteString(`<a href="` + source + `">View this post on Instagram</a></blockquote>`)
+
+	case PlatformMastodon:
+		b.WriteString(`<blockquote class="mastodon-embed" data-embed-url="` + source + `/embed">`)
+		if q.Text != "" {
+			b.WriteString(`<p>` + postText(q.Text) + `</p>`)
+		}
+		b.WriteString(`<a href="` + source + `">Post by @` + html.EscapeString(q.Handle) + `</a></blockquote>`)
+
+	case PlatformTelegram:
+		if q.Handle == "" {
+			return ""
+		}
+		src := "https://t.me/" + url.PathEscape(q.Handle) + "/" + url.PathEscape(q.PostID) + "?embed=1"
+		b.WriteString(`<iframe src="` + html.EscapeString(src) + `" width="100%" frameborder="0" loading="lazy"></iframe>`)
+
+	default:
+		return ""
+	}
+
+	return b.String()
+}
+
+// postText escapes the text and converts the line breaks to <br>
+func postText(text string) string {
+	return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+}
+
+// postPattern returns the handle and the ID of the named groups of the URL path pattern
+func postPattern(pattern string) func(u *url.URL) (string, string) {
+
+	re := regexp.MustCompile(pattern)
+	handleIdx, idIdx := re.SubexpIndex("handle"), re.SubexpIndex("id")
+
+	return func(u *url.URL) (string, string) {
+		m := re.FindStringSubmatch(u.Path)
+		if m == nil {
+			return "", ""
+		}
+		handle := ""
+		if handleIdx > 0 {
+			handle = m[handleIdx]
+		}
+		return handle, m[idIdx]
+	}
+}
@@ -0,0 +1,181 @@
+package article_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+func TestDetectPost(t *testing.T) {
+
+	tests := []struct {
+		url      string
+		platform string
+		handle   string
+		id       string
+	}{
+		{"https://twitter.com/jack/status/20", article.PlatformX, "jack", "20"},
+		{"https://x.com/jack/status/20?s=20", article.PlatformX, "jack", "20"},
+		{"https://x.com/i/web/status/20", article.PlatformX, "", "20"},
+		{"https://t.me/durov/43", article.PlatformTelegram, "durov", "43"},
+		{"https://t.me/s/durov/43", article.PlatformTelegram, "durov", "43"},
+		{"https://www.instagram.com/p/C1a2b3c4d5e/?utm_source=ig_embed", article.PlatformInstagram, "", "C1a2b3c4d5e"},
+		{"https://www.instagram.com/natgeo/reel/C1a2b3c4d5e/", article.PlatformInstagram, "natgeo", "C1a2b3c4d5e"},
+		{"https://www.threads.net/@zuck/post/C1a2b3c4d5e", article.PlatformThreads, "zuck", "C1a2b3c4d5e"},
+		{"https://bsky.app/profile/jay.bsky.team/post/3l6oveex3ii2l", article.PlatformBluesky, "jay.bsky.team", "3l6oveex3ii2l"},
+		{"https://mastodon.social/@Gargron/109382929432352411", article.PlatformMastodon, "Gargron", "109382929432352411"},
+		{"https://mastodon.social/@Gargron/109382929432352411/embed", article.PlatformMastodon, "Gargron", "109382929432352411"},
+		{"https://x.com/jack", "", "", ""},
+		{"https://t.me/durov", "", "", ""},
+		{"https://www.youtube.com/@mkbhd/123", "", "", ""},
+		{"https://example.com/news/1", "", "", ""},
+		{"not a url", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			platform, handle, id := article.DetectPost(tt.url)
+			assert.Equal(t, tt.platform, platform)
+			assert.Equal(t, tt.handle, handle)
+			assert.Equal(t, tt.id, id)
+		})
+	}
+}
+
+func TestQuote_NormalizePost(t *testing.T) {
+
+	a := article.NewArticle()
+	a.Title = "Title"
+	a.Text = "Text"
+	a.Markup = "<p>Text</p>"
+
+	quote := article.NewQuote("just setting up my twttr")
+	quote.Author = "jack"
+	quote.Platform = "Twitter"
+	quote.SourceURL = "https://twitter.com/jack/status/20?ref_src=twsrc"
+	quote.Posted = time.Date(2006, 3, 21, 20, 50, 14, 0, time.UTC)
+	a.Quotes.Add(quote)
+	quote.Media = []*article.PostMedia{
+		{URL: "https://pic.twitter.com/abc"},
+		{URL: "invalid", Type: article.PostMediaImage},
+	}
+
+	report, err := a.NormalizeWithReport()
+	require.NoError(t, err)
+
+	assert.Equal(t, article.PlatformX, quote.Platform)
+	assert.Equal(t, "jack", quote.Handle)
+	assert.Equal(t, "20", quote.PostID)
+	assert.Len(t, quote.Media, 1)
+	assert.Equal(t, `<blockquote class="twitter-tweet"><p>just setting up my twttr</p>&mdash; jack (@jack) `+
+		`<a href="https://twitter.com/jack/status/20">March 21, 2006</a></blockquote>`, quote.Embed)
+	assert.Len(t, report.Field("Article.Quotes[0].Platform"), 1)
+	assert.Len(t, report.Field("Article.Quotes[0].Media[1]"), 1)
+
+	// idempotent, the embed passes the policy
+	report, err = a.NormalizeWithReport()
+	require.NoError(t, err)
+	assert.Empty(t, report.Field("Article.Quotes[0]"))
+
+	fromMap, err := article.NewQuoteFromMap(quote.Map())
+	require.NoError(t, err)
+	assert.Equal(t, quote, fromMap)
+}
+
+func TestQuote_EmbedHTML(t *testing.T) {
+
+	quote := article.NewQuote("")
+	quote.SourceURL = "https://t.me/durov/43"
+	require.True(t, quote.Detect())
+	assert.Equal(t, `<iframe src="https://t.me/durov/43?embed=1" width="100%" frameborder="0" loading="lazy"></iframe>`, quote.EmbedHTML())
+
+	quote.Normalize()
+	assert.NotEmpty(t, quote.ID, "quote without text is valid with the embed")
+	assert.Equal(t, quote.EmbedHTML(), quote.Embed)
+
+	quote = article.NewQuote("Text")
+	quote.SourceURL = "https://example.com/news/1"
+	assert.False(t, quote.Detect())
+	assert.Empty(t, quote.EmbedHTML())
+
+	quote = article.NewQuote("")
+	quote.SourceURL = "https://example.com/news/1"
+	quote.Normalize()
+	assert.Empty(t, quote.ID, "quote without text and embed is invalid")
+}
+
+func TestDerive_Posts(t *testing.T) {
+
+	a := article.NewArticle()
+	a.SourceURL = "https://example.com/news/1"
+	a.Markup = `<p>Intro</p>
+<blockquote class="twitter-tweet" data-lang="en"><p lang="en" dir="ltr">just setting up my twttr <a href="https://t.co/xyz">pic.twitter.com/xyz</a></p>&mdash; jack (@jack) <a href="https://twitter.com/jack/status/20?ref_src=twsrc%5Etfw">March 21, 2006</a></blockquote>
+<script async src="https://platform.twitter.com/widgets.js" charset="utf-8"></script>
+<script async src="https://telegram.org/js/telegram-widget.js?22" data-telegram-post="durov/43" data-width="100%"></script>
+<blockquote class="instagram-media" data-instgrm-permalink="https://www.instagram.com/p/C1a2b3c4d5e/?utm_source=ig_embed" data-instgrm-version="14">
+<div><a href="https://www.instagram.com/p/C1a2b3c4d5e/?utm_source=ig_embed">View this post on Instagram</a></div>
+<p><a href="https://www.instagram.com/p/C1a2b3c4d5e/?utm_source=ig_embed">A post shared by National Geographic (@natgeo)</a> on <time datetime="2024-01-02T10:00:00+00:00">Jan 2, 2024</time></p>
+</blockquote>
+<blockquote class="mastodon-embed" data-embed-url="https://mastodon.social/@Gargron/109382929432352411/embed"><a href="https://mastodon.social/@Gargron/109382929432352411">Post by @Gargron@mastodon.social</a></blockquote>
+<iframe src="https://platform.twitter.com/embed/Tweet.html?id=21"></iframe>
+<blockquote><p>Plain quote</p><cite>Someone</cite></blockquote>`
+
+	a.Derive()
+
+	quotes := a.Quotes.Slice()
+	require.Len(t, quotes, 6)
+
+	tweet := quotes[0]
+	assert.Equal(t, article.PlatformX, tweet.Platform)
+	assert.Equal(t, "jack", tweet.Handle)
+	assert.Equal(t, "jack", tweet.Author)
+	assert.Equal(t, "20", tweet.PostID)
+	assert.Equal(t, "just setting up my twttr pic.twitter.com/xyz", tweet.Text)
+	assert.Equal(t, time.Date(2006, 3, 21, 0, 0, 0, 0, time.UTC), tweet.Posted)
+	require.Len(t, tweet.Media, 1)
+	assert.Equal(t, "https://pic.twitter.com/xyz", tweet.Media[0].URL)
+	assert.Contains(t, tweet.Embed, `class="twitter-tweet"`)
+
+	telegram := quotes[1]
+	assert.Equal(t, article.PlatformTelegram, telegram.Platform)
+	assert.Equal(t, "durov", telegram.Handle)
+	assert.Equal(t, "43", telegram.PostID)
+	assert.Equal(t, "https://t.me/durov/43", telegram.SourceURL)
+	assert.Contains(t, telegram.Embed, `src="https://t.me/durov/43?embed=1"`)
+
+	instagram := quotes[2]
+	assert.Equal(t, article.PlatformInstagram, instagram.Platform)
+	assert.Equal(t, "natgeo", instagram.Handle)
+	assert.Equal(t, "National Geographic", instagram.Author)
+	assert.Equal(t, "C1a2b3c4d5e", instagram.PostID)
+	assert.Empty(t, instagram.Text)
+	assert.Equal(t, time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC), instagram.Posted.UTC())
+
+	mastodon := quotes[3]
+	assert.Equal(t, article.PlatformMastodon, mastodon.Platform)
+	assert.Equal(t, "Gargron", mastodon.Handle)
+	assert.Equal(t, "https://mastodon.social/@Gargron/109382929432352411", mastodon.SourceURL)
+
+	iframe := quotes[4]
+	assert.Equal(t, "21", iframe.PostID)
+	assert.Equal(t, "https://x.com/i/status/21", iframe.SourceURL)
+	assert.Empty(t, a.Videos.Slice(), "post iframes are not videos")
+
+	assert.Equal(t, "Plain quote", quotes[5].Text)
+	assert.Empty(t, quotes[5].PostID)
+
+	// the derived posts pass the normalization
+	a.Title = "Title"
+	require.NoError(t, a.Normalize())
+	assert.Equal(t, 6, a.Quotes.Len())
+	assert.Equal(t, "https://www.instagram.com/p/C1a2b3c4d5e", a.Quotes.Slice()[2].SourceURL)
+	assert.NotContains(t, a.Quotes.Slice()[0].Embed, "<script")
+
+	// derived again, the posts are not duplicated
+	a.Derive()
+	assert.Equal(t, 6, a.Quotes.Len())
+}
@@ -0,0 +1,371 @@
+package article
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF decoder
+	_ "image/jpeg" // register JPEG decoder
+	_ "image/png"  // register PNG decoder
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fetcher sends the HTTP request, *http.Client implements it.
+type Fetcher interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// ProbeStatus is the outcome of the image probing.
+type ProbeStatus string
+
+const (
+	// ProbeOK means the image is fetched and decoded.
+	ProbeOK ProbeStatus = "ok"
+	// ProbeBroken means the image failed to fetch or decode, e.g. 404 or truncated file.
+	ProbeBroken ProbeStatus = "broken"
+	// ProbeNotImage means the URL points to other content, e.g. HTML page.
+	ProbeNotImage ProbeStatus = "not_image"
+	// ProbeTrackingPixel means the image is too small to be seen, e.g. 1x1 analytics pixel.
+	ProbeTrackingPixel ProbeStatus = "tracking_pixel"
+)
+
+// ProbeResult describes the probed image.
+type ProbeResult struct {
+	// ImageID is the ID of the probed Image.
+	ImageID string `json:"image_id"`
+	// URL of the image.
+	URL string `json:"url"`
+	// Status of the probing.
+	Status ProbeStatus `json:"status"`
+	// MIME is the sniffed type of the content, e.g. image/jpeg.
+	MIME string `json:"mime,omitempty"`
+	// Width and Height of the image in pixels, zero if unknown, e.g. for SVG.
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+	// Size is the content size in bytes.
+	Size int `json:"size,omitempty"`
+	// Hash is the perceptual hash of the image, see Prober.Hash.
+	Hash string `json:"hash,omitempty"`
+	// Err is the error of the broken image.
+	Err error `json:"-"`
+}
+
+// Prober fetches the images concurrently, fills the Width, Height and Size of the Image
+// and detects the broken images, tracking pixels and the URLs of other content.
+type Prober struct {
+	// Fetcher sends the requests, defaults to http.DefaultClient.
+	Fetcher Fetcher
+	// Concurrency is the maximum number of simultaneous requests, defaults to 4.
+	Concurrency int
+	// Timeout of a single image request, defaults to 10 seconds.
+	Timeout time.Duration
+	// MaxSize is the maximum number of bytes read to count the size of the image without Content-Length,
+	// defaults to 20 MiB. The larger images are considered broken.
+	MaxSize int64
+	// PixelSize is the maximum width and height of the tracking pixel, defaults to 2.
+	PixelSize int
+	// Hash is the algorithm of the perceptual hash set to the Image.Hash, empty skips hashing.
+	// Hashing decodes the whole image, WebP and SVG are not hashed,
+	// the images larger than MaxImagePixels are broken with ErrImageTooLarge.
+	Hash HashAlgorithm
+	// UserAgent of the requests.
+	UserAgent string
+	// Remove removes the images not probed with ProbeOK from the collection,
+	// otherwise they are only reported in the results.
+	Remove bool
+}
+
+// NewProber creates a Prober with the default limits and the fetcher.
+func NewProber(fetcher Fetcher) *Prober {
+	return &Prober{
+		Fetcher:     fetcher,
+		Concurrency: 4,
+		Timeout:     10 * time.Second,
+		MaxSize:     20 << 20,
+		PixelSize:   2,
+	}
+}
+
+// ProbeArticle probes the images of the Article and its contributors.
+func (p *Prober) ProbeArticle(ctx context.Context, a *Article) []ProbeResult {
+
+	results := p.ProbeImages(ctx, a.Images)
+
+	if a.Contributors != nil {
+		for _, person := range a.Contributors.Slice() {
+			if person.Images != nil {
+				results = append(results, p.ProbeImages(ctx, person.Images)...)
+			}
+		}
+	}
+
+	return results
+}
+
+// ProbeImages probes the images concurrently, the results are in the order of the images.
+// With Remove the images failed probing are removed from the collection.
+func (p *Prober) ProbeImages(ctx context.Context, list *Images) []ProbeResult {
+
+	if list == nil {
+		return nil
+	}
+
+	images := list.Slice()
+	results := make([]ProbeResult, len(images))
+
+	concurrency := p.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for idx, img := range images {
+		wg.Add(1)
+		go func(idx int, img *Image) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+				results[idx] = p.Probe(ctx, img)
+			case <-ctx.Done():
+				results[idx] = ProbeResult{ImageID: img.ID, URL: img.URL, Status: ProbeBroken, Err: ctx.Err()}
+			}
+		}(idx, img)
+	}
+
+	wg.Wait()
+
+	if p.Remove {
+		var failed []string
+		for _, result := range results {
+			if result.Status != ProbeOK {
+				failed = append(failed, result.ImageID)
+			}
+		}
+		list.Remove(failed...)
+	}
+
+	return results
+}
+
+// Probe fetches the image and fills its Width, Height and Size.
+// The known dimensions of the Image are kept if the format has none, e.g. SVG.
+func (p *Prober) Probe(ctx context.Context, img *Image) ProbeResult {
+
+	result := p.probe(ctx, img.URL)
+	result.ImageID = img.ID
+
+	if result.Status == ProbeOK || result.Status == ProbeTrackingPixel {
+		if result.Width > 0 && result.Height > 0 {
+			img.Width, img.Height = result.Width, result.Height
+		}
+		img.Size = result.Size
+		if result.Hash != "" {
+			img.Hash = result.Hash
+		}
+	}
+
+	return result
+}
+
+func (p *Prober) probe(ctx context.Context, url string) ProbeResult {
+
+	result := ProbeResult{URL: url, Status: ProbeBroken}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	req.Header.Set("Accept", "image/*")
+	if p.UserAgent != "" {
+		req.Header.Set("User-Agent", p.UserAgent)
+	}
+
+	fetcher := p.Fetcher
+	if fetcher == nil {
+		fetcher = http.DefaultClient
+	}
+
+	resp, err := fetcher.Do(req)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		result.Err = fmt.Errorf("unexpected status %s", resp.Status)
+		return result
+	}
+
+	maxSize := p.MaxSize
+	if maxSize <= 0 {
+		maxSize = 20 << 20
+	}
+
+	counter := &countingReader{r: io.LimitReader(resp.Body, maxSize+1)}
+	body := bufio.NewReaderSize(counter, 512)
+
+	head, _ := body.Peek(512)
+	result.MIME = sniffImage(head, resp.Header.Get("Content-Type"))
+
+	if !strings.HasPrefix(result.MIME, "image/") {
+		result.Status = ProbeNotImage
+		return result
+	}
+
+	switch result.MIME {
+	case "image/svg+xml":
+	case "image/webp":
+		result.Width, result.Height, err = webpSize(head)
+	default:
+		if p.Hash != "" {
+			result.Width, result.Height, result.Hash, err = decodeHash(body, p.Hash)
+			break
+		}
+		var cfg image.Config
+		cfg, _, err = image.DecodeConfig(body)
+		result.Width, result.Height = cfg.Width, cfg.Height
+	}
+
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	// read the rest to count the size and to reuse the connection
+	if _, err = io.Copy(io.Discard, body); err != nil {
+		result.Err = err
+		return result
+	}
+
+	if counter.n > maxSize {
+		result.Err = fmt.Errorf("image exceeds %d bytes", maxSize)
+		return result
+	}
+
+	result.Size = int(counter.n)
+	result.Status = ProbeOK
+
+	pixel := p.PixelSize
+	if pixel <= 0 {
+		pixel = 2
+	}
+
+	if result.Width > 0 && result.Height > 0 && result.Width <= pixel && result.Height <= pixel {
+		result.Status = ProbeTrackingPixel
+	}
+
+	return result
+}
+
+// decodeHash decodes the image and returns its dimensions and the perceptual hash,
+// the images larger than MaxImagePixels are rejected before decoding
+func decodeHash(r io.Reader, alg HashAlgorithm) (int, int, string, error) {
+
+	decoded, err := decodeImage(r)
+	if err != nil {
+		return 0, 0, "", err
+	}
+
+	hash, err := ImageHash(decoded, alg)
+	if err != nil {
+		return 0, 0, "", err
+	}
+
+	bounds := decoded.Bounds()
+	return bounds.Dx(), bounds.Dy(), FormatHash(alg, hash), nil
+}
+
+// countingReader counts the bytes read
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// sniffImage detects the MIME type by the content, SVG is detected by the root element or the header
+func sniffImage(head []byte, header string) string {
+
+	mime := http.DetectContentType(head)
+	if strings.HasPrefix(mime, "image/") {
+		return mime
+	}
+
+	if strings.HasPrefix(mime, "text/xml") || strings.HasPrefix(mime, "text/plain") {
+		if bytes.Contains(head, []byte("<svg")) || strings.HasPrefix(header, "image/svg+xml") {
+			return "image/svg+xml"
+		}
+	}
+
+	return strings.TrimSpace(strings.Split(mime, ";")[0])
+}
+
+// errWebP is returned for the unknown WebP chunk
+var errWebP = errors.New("webp: invalid header")
+
+// webpSize parses the dimensions of the lossy (VP8), lossless (VP8L) and extended (VP8X) WebP,
+// the stdlib has no WebP decoder
+func webpSize(head []byte) (int, int, error) {
+
+	if len(head) < 30 || string(head[0:4]) != "RIFF" || string(head[8:12]) != "WEBP" {
+		return 0, 0, errWebP
+	}
+
+	chunk := head[20:]
+
+	switch string(head[12:16]) {
+	case "VP8 ":
+		// frame tag (3 bytes), start code 9d 01 2a, then 14 bit width and height
+		if chunk[3] != 0x9d || chunk[4] != 0x01 || chunk[5] != 0x2a {
+			return 0, 0, errWebP
+		}
+		width := int(binary.LittleEndian.Uint16(chunk[6:8]) & 0x3fff)
+		height := int(binary.LittleEndian.Uint16(chunk[8:10]) & 0x3fff)
+		return width, height, nil
+
+	case "VP8L":
+		// signature 2f, then 14 bit width-1 and height-1
+		if chunk[0] != 0x2f {
+			return 0, 0, errWebP
+		}
+		bits := binary.LittleEndian.Uint32(chunk[1:5])
+		width := int(bits&0x3fff) + 1
+		height := int((bits>>14)&0x3fff) + 1
+		return width, height, nil
+
+	case "VP8X":
+		// flags (4 bytes), then 24 bit canvas width-1 and height-1
+		width := int(uint32(chunk[4])|uint32(chunk[5])<<8|uint32(chunk[6])<<16) + 1
+		height := int(uint32(chunk[7])|uint32(chunk[8])<<8|uint32(chunk[9])<<16) + 1
+		return width, height, nil
+	}
+
+	return 0, 0, errWebP
+}
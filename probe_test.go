@@ -0,0 +1,223 @@
+package article_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+// encodeImage encodes a blank image of the size with the encoder
+func encodeImage(t *testing.T, width, height int, encode func(*bytes.Buffer, image.Image) error) []byte {
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	img.Set(0, 0, color.White)
+
+	var buf bytes.Buffer
+	require.NoError(t, encode(&buf, img))
+
+	return buf.Bytes()
+}
+
+// webpLossless returns the VP8L header of the size
+func webpLossless(width, height int) []byte {
+
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(22))
+	buf.WriteString("WEBPVP8L")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(10))
+	buf.WriteByte(0x2f)
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(width-1)|uint32(height-1)<<14)
+	buf.Write(make([]byte, 5))
+
+	return buf.Bytes()
+}
+
+// webpExtended returns the VP8X header of the size
+func webpExtended(width, height int) []byte {
+
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(22))
+	buf.WriteString("WEBPVP8X")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(10))
+	buf.Write(make([]byte, 4))
+	w, h := width-1, height-1
+	buf.Write([]byte{byte(w), byte(w >> 8), byte(w >> 16), byte(h), byte(h >> 8), byte(h >> 16)})
+
+	return buf.Bytes()
+}
+
+func newProbeServer(t *testing.T) *httptest.Server {
+
+	files := map[string][]byte{
+		"/a.png":    encodeImage(t, 640, 480, func(b *bytes.Buffer, img image.Image) error { return png.Encode(b, img) }),
+		"/b.jpg":    encodeImage(t, 320, 200, func(b *bytes.Buffer, img image.Image) error { return jpeg.Encode(b, img, nil) }),
+		"/c.gif":    encodeImage(t, 100, 50, func(b *bytes.Buffer, img image.Image) error { return gif.Encode(b, img, nil) }),
+		"/d.webp":   webpLossless(1200, 800),
+		"/e.webp":   webpExtended(2000, 1000),
+		"/f.svg":    []byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg" width="10" height="10"></svg>`),
+		"/pixel":    encodeImage(t, 1, 1, func(b *bytes.Buffer, img image.Image) error { return gif.Encode(b, img, nil) }),
+		"/page":     []byte(`<!DOCTYPE html><html><body>Not an image</body></html>`),
+		"/trunc":    []byte("\x89PNG\r\n\x1a\n"),
+		"/bad.webp": []byte("RIFF\x00\x00\x00\x00WEBPVP8Z0000000000000000000000"),
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+}
+
+func TestProber_ProbeImages(t *testing.T) {
+
+	srv := newProbeServer(t)
+	defer srv.Close()
+
+	tests := []struct {
+		path   string
+		status article.ProbeStatus
+		mime   string
+		width  int
+		height int
+	}{
+		{"/a.png", article.ProbeOK, "image/png", 640, 480},
+		{"/b.jpg", article.ProbeOK, "image/jpeg", 320, 200},
+		{"/c.gif", article.ProbeOK, "image/gif", 100, 50},
+		{"/d.webp", article.ProbeOK, "image/webp", 1200, 800},
+		{"/e.webp", article.ProbeOK, "image/webp", 2000, 1000},
+		{"/f.svg", article.ProbeOK, "image/svg+xml", 0, 0},
+		{"/pixel", article.ProbeTrackingPixel, "image/gif", 1, 1},
+		{"/page", article.ProbeNotImage, "text/html", 0, 0},
+		{"/trunc", article.ProbeBroken, "image/png", 0, 0},
+		{"/bad.webp", article.ProbeBroken, "image/webp", 0, 0},
+		{"/missing.png", article.ProbeBroken, "", 0, 0},
+	}
+
+	images := article.NewImages()
+	for _, tt := range tests {
+		images.Add(article.NewImage(srv.URL + tt.path))
+	}
+	require.Equal(t, len(tests), images.Len())
+
+	results := article.NewProber(srv.Client()).ProbeImages(context.Background(), images)
+	require.Len(t, results, len(tests))
+
+	for idx, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			result := results[idx]
+			img := images.Slice()[idx]
+
+			assert.Equal(t, img.ID, result.ImageID)
+			assert.Equal(t, tt.status, result.Status)
+			assert.Equal(t, tt.mime, result.MIME)
+			assert.Equal(t, tt.width, result.Width)
+			assert.Equal(t, tt.height, result.Height)
+
+			if tt.status == article.ProbeBroken {
+				assert.Error(t, result.Err)
+				assert.Zero(t, img.Size)
+				return
+			}
+
+			assert.NoError(t, result.Err)
+
+			if tt.status != article.ProbeNotImage {
+				assert.Positive(t, result.Size)
+				assert.Equal(t, result.Size, img.Size)
+			}
+			if tt.width > 0 {
+				assert.Equal(t, tt.width, img.Width)
+				assert.Equal(t, tt.height, img.Height)
+			}
+		})
+	}
+}
+
+func TestProber_Remove(t *testing.T) {
+
+	srv := newProbeServer(t)
+	defer srv.Close()
+
+	a := article.NewArticle()
+	a.Images.Add(
+		article.NewImage(srv.URL+"/a.png"),
+		article.NewImage(srv.URL+"/pixel"),
+		article.NewImage(srv.URL+"/page"),
+		article.NewImage(srv.URL+"/missing.png"),
+	)
+
+	person := article.NewPerson("John Doe")
+	person.Images.Add(article.NewImage(srv.URL+"/b.jpg"), article.NewImage(srv.URL+"/missing.jpg"))
+	a.Contributors.Add(person)
+
+	prober := article.NewProber(srv.Client())
+	prober.Remove = true
+
+	results := prober.ProbeArticle(context.Background(), a)
+	assert.Len(t, results, 6)
+
+	require.Equal(t, 1, a.Images.Len())
+	assert.Equal(t, srv.URL+"/a.png", a.Images.Slice()[0].URL)
+	require.Equal(t, 1, person.Images.Len())
+	assert.Equal(t, srv.URL+"/b.jpg", person.Images.Slice()[0].URL)
+}
+
+func TestProber_Limits(t *testing.T) {
+
+	var active, peak atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		_, _ = w.Write(webpLossless(10, 10))
+	}))
+	defer srv.Close()
+
+	images := article.NewImages()
+	for i := 0; i < 8; i++ {
+		images.Add(article.NewImage(srv.URL + "/fast"))
+	}
+	images.Add(article.NewImage(srv.URL + "/slow"))
+
+	prober := article.NewProber(srv.Client())
+	prober.Concurrency = 2
+	prober.Timeout = 50 * time.Millisecond
+
+	results := prober.ProbeImages(context.Background(), images)
+
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+	for _, result := range results[:8] {
+		assert.Equal(t, article.ProbeOK, result.Status)
+	}
+	assert.Equal(t, article.ProbeBroken, results[8].Status)
+	assert.ErrorIs(t, results[8].Err, context.DeadlineExceeded)
+}
@@ -1,9 +1,9 @@
 package article
 
 import (
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"log/slog"
+	"time"
 )
 
 // Quote represents a quote from social media in the article.
@@ -14,20 +14,40 @@ type Quote struct {
 	ID string `json:"id" validate:"required,max=36"`
 
 	// Text is the text of the quote.
-	// This field is required.
-	Text string `json:"text" validate:"required,max=65000"`
+	// This field is required unless the Embed is set, e.g. Telegram widget without the text.
+	Text string `json:"text" validate:"required_without=Embed,limit=Text"`
 
-	// Author is the author of the quote.
+	// Author is the author of the quote, the display name of the social post author.
 	// This field is required and should be between 1 and 255 characters long.
-	Author string `json:"author" validate:"max=255"`
+	Author string `json:"author" validate:"limit=Name"`
 
 	// SourceURL is the source URL of the quote.
 	// This field is required and should be a valid URL.
-	SourceURL string `json:"source_url" validate:"url,max=4096"`
+	SourceURL string `json:"source_url" validate:"url,limit=URL"`
 
 	// Platform is the platform of the quote (e.g., Twitter, Facebook).
 	// This field is required and should be between 1 and 50 characters long.
-	Platform string `json:"platform" validate:"max=255"`
+	Platform string `json:"platform" validate:"limit=Name"`
+
+	// Handle is the account handle of the social post author, e.g. jack.
+	// This field is optional and recognized from the SourceURL during normalization.
+	Handle string `json:"handle,omitempty" validate:"limit=Name"`
+
+	// PostID is the ID of the social post at the platform.
+	// This field is optional and recognized from the SourceURL during normalization.
+	PostID string `json:"post_id,omitempty" validate:"limit=Name"`
+
+	// Posted is the date and time when the social post was published.
+	// This field is optional.
+	Posted time.Time `json:"posted,omitempty"`
+
+	// Media are the images and videos attached to the social post.
+	// This field is optional, invalid media are removed during normalization.
+	Media []*PostMedia `json:"media,omitempty" validate:"omitempty,dive"`
+
+	// Embed is the oEmbed HTML of the social post, e.g. blockquote.twitter-tweet.
+	// This field is optional, generated for the recognized post during normalization, see EmbedHTML.
+	Embed string `json:"embed,omitempty" validate:"limit=Embed"`
 }
 
 //goland:noinspection GoUnusedExportedFunction
@@ -38,50 +58,144 @@ func NewQuote(text string) *Quote {
 	}
 }
 
+// GetID returns the ID of the Quote.
+func (q *Quote) GetID() string {
+	return q.ID
+}
+
 // Normalize validates and trims the fields of the Quote.
+// The Quote is reset to the zero value if it is invalid.
 func (q *Quote) Normalize() {
+	if err := q.normalize(nil, "Quote"); err != nil {
+		*q = Quote{}
+	}
+}
+
+// normalize trims the fields, records the changes to the report and returns the validation error.
+func (q *Quote) normalize(report *NormalizeReport, path string) error {
 
 	if q.ID == "" {
 		q.ID = uuid.New().String()
+		report.fallback(path+".ID", "")
 	}
 
-	q.ID = TrimToMaxLen(q.ID, 36)
-	q.Text = TrimToMaxLen(q.Text, 65000)
-	q.Author = TrimToMaxLen(q.Author, 255)
-	q.SourceURL = TrimToMaxLen(q.SourceURL, 4096)
-	q.Platform = TrimToMaxLen(q.Platform, 255)
+	q.ID = report.trim(path+".ID", q.ID, 36)
+	q.Text = report.trim(path+".Text", q.Text, report.limits().Text)
+	q.Author = report.trim(path+".Author", q.Author, report.limits().Name)
+	q.SourceURL = URLCanonicalizer.canonical(report, path+".SourceURL", report.trim(path+".SourceURL", q.SourceURL, report.limits().URL), report.baseURL())
+	q.Platform = report.trim(path+".Platform", q.Platform, report.limits().Name)
+	q.Handle = report.trim(path+".Handle", q.Handle, report.limits().Name)
+	q.PostID = report.trim(path+".PostID", q.PostID, report.limits().Name)
+	q.Embed = QuoteEmbedPolicy.sanitize(report, path+".Embed", report.trim(path+".Embed", q.Embed, report.limits().Embed))
+	q.normalizeMedia(report, path)
+
+	if q.detect(report, path) && q.Embed == "" {
+		if q.Embed = q.EmbedHTML(); q.Embed != "" {
+			report.fallback(path+".Embed", "")
+		}
+	}
 
-	err := validate.Struct(q)
+	err := report.validate(q)
 	if err != nil {
-		for _, fieldErr := range err.(validator.ValidationErrors) {
-			slog.Debug("Validation error in Quote", slog.String("field", fieldErr.Namespace()), slog.String("error", fieldErr.Tag()))
-			*q = Quote{}
+		slog.Debug("Validation error in Quote", slog.String("path", path), slog.String("error", err.Error()))
+	}
+
+	return err
+}
+
+// normalizeMedia removes the invalid media and records the changes to the report
+func (q *Quote) normalizeMedia(report *NormalizeReport, path string) {
+
+	if len(q.Media) == 0 {
+		return
+	}
+
+	valid := q.Media[:0]
+	for idx, media := range q.Media {
+		if media == nil {
+			continue
+		}
+		if err := media.normalize(report, itemPath(path+".Media", idx)); err != nil {
+			report.invalid(itemPath(path+".Media", idx), err, ActionRemoved)
+			continue
 		}
+		valid = append(valid, media)
 	}
+
+	q.Media = valid
 }
 
 // Map converts the Quote struct to a map[string]any.
+// The fields of the social post are included only if set.
 func (q *Quote) Map() map[string]any {
-	return map[string]any{
+
+	m := map[string]any{
 		"id":         q.ID,
 		"text":       q.Text,
 		"author":     q.Author,
 		"source_url": q.SourceURL,
 		"platform":   q.Platform,
 	}
+
+	if q.Handle != "" {
+		m["handle"] = q.Handle
+	}
+	if q.PostID != "" {
+		m["post_id"] = q.PostID
+	}
+	if !q.Posted.IsZero() {
+		m["posted"] = q.Posted
+	}
+	if len(q.Media) > 0 {
+		media := make([]map[string]any, len(q.Media))
+		for idx, item := range q.Media {
+			media[idx] = item.Map()
+		}
+		m["media"] = media
+	}
+	if q.Embed != "" {
+		m["embed"] = q.Embed
+	}
+
+	return m
 }
 
 // NewQuoteFromMap creates a Quote from a map[string]any, validates it, and returns a pointer to the Quote or an error.
 func NewQuoteFromMap(m map[string]any) (*Quote, error) {
+	return quoteFromMap(newMapReader(m))
+}
+
+// NewQuoteFromMapStrict creates a Quote from a map[string]any like NewQuoteFromMap,
+// but fails with MapErrors listing every key holding a value of unexpected type.
+func NewQuoteFromMapStrict(m map[string]any) (*Quote, error) {
+	return quoteFromMap(newStrictMapReader(m))
+}
+
+// quoteFromMap reads the Quote with the map reader, validates it, and returns a pointer to the Quote or an error.
+func quoteFromMap(r *mapReader) (*Quote, error) {
 	quote := &Quote{
-		ID:        StringFromMap(m, "id"),
-		Text:      StringFromMap(m, "text"),
-		Author:    StringFromMap(m, "author"),
-		SourceURL: StringFromMap(m, "source_url"),
-		Platform:  StringFromMap(m, "platform"),
+		ID:        r.string("id"),
+		Text:      r.string("text"),
+		Author:    r.string("author"),
+		SourceURL: r.string("source_url"),
+		Platform:  r.string("platform"),
+		Handle:    r.string("handle"),
+		PostID:    r.string("post_id"),
+		Posted:    r.time("posted"),
+		Embed:     r.string("embed"),
+	}
+
+	for _, sub := range r.maps("media") {
+		if media, err := postMediaFromMap(sub); err == nil {
+			quote.Media = append(quote.Media, media)
+		}
+	}
+
+	if err := r.failed(); err != nil {
+		return nil, err
 	}
 
-	err := validate.Struct(quote)
+	err := r.validate(quote)
 	if err != nil {
 		return nil, err
 	}
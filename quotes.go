@@ -1,139 +1,57 @@
 package article
 
-import (
-	"encoding/json"
-	"errors"
-	"log"
-)
-
 // Quotes represents a collection of Quote pointers
 type Quotes struct {
-	items []*Quote
-}
-
-// NewQuotesStrict creates a collection and validates every quote
-func NewQuotesStrict(quotes ...*Quote) (*Quotes, error) {
-	for _, quote := range quotes {
-		if err := validate.Struct(quote); err != nil {
-			return nil, errors.New("invalid quote: " + err.Error())
-		}
-	}
-	return &Quotes{items: quotes}, nil
+	Collection[*Quote]
 }
 
 // NewQuotes creates a collection, skips invalid items, and logs errors
 func NewQuotes(quotes ...*Quote) *Quotes {
-	var validQuotes []*Quote
-	for _, quote := range quotes {
-		if err := validate.Struct(quote); err == nil {
-			validQuotes = append(validQuotes, quote)
-		} else {
-			log.Printf("Invalid quote skipped: %+v, error: %v", quote, err)
-		}
-	}
-	return &Quotes{items: validQuotes}
+	return &Quotes{Collection: *NewCollection(quotes...)}
 }
 
-// Get returns the quote by ID
-func (list *Quotes) Get(id string) (*Quote, bool) {
-	for _, quote := range list.items {
-		if quote.ID == id {
-			return quote, true
-		}
+// NewQuotesStrict creates a collection and validates every item
+func NewQuotesStrict(quotes ...*Quote) (*Quotes, error) {
+
+	list, err := NewCollectionStrict(quotes...)
+	if err != nil {
+		return nil, err
 	}
-	return nil, false
-}
 
-// Slice returns a slice of all items
-func (list *Quotes) Slice() []*Quote {
-	return list.items
+	return &Quotes{Collection: *list}, nil
 }
 
-// Add adds items to the collection
+// Add adds items to the collection, invalid items are skipped
 func (list *Quotes) Add(quotes ...*Quote) *Quotes {
-	for _, quote := range quotes {
-		if err := validate.Struct(quote); err == nil {
-			list.items = append(list.items, quote)
-		} else {
-			log.Printf("Invalid quote skipped: %+v, error: %v", quote, err)
-		}
-	}
+	list.Collection.Add(quotes...)
 	return list
 }
 
 // Remove removes items by ID
 func (list *Quotes) Remove(ids ...string) *Quotes {
-	idSet := make(map[string]struct{}, len(ids))
-	for _, id := range ids {
-		idSet[id] = struct{}{}
-	}
-
-	var filteredQuotes []*Quote
-	for _, quote := range list.items {
-		if _, found := idSet[quote.ID]; !found {
-			filteredQuotes = append(filteredQuotes, quote)
-		}
-	}
-
-	list.items = filteredQuotes
+	list.Collection.Remove(ids...)
 	return list
 }
 
-// IDs returns a slice of all quote IDs
-func (list *Quotes) IDs() []string {
-	ids := make([]string, len(list.items))
-	for idx, quote := range list.items {
-		ids[idx] = quote.ID
-	}
-	return ids
-}
-
-// Len returns the number of items
-func (list *Quotes) Len() int {
-	return len(list.items)
-}
-
 // Filter returns a new Quotes collection filtered by the provided functions
 func (list *Quotes) Filter(fns ...func(*Quote) bool) *Quotes {
-	var filteredQuotes []*Quote
-	for _, quote := range list.items {
-		include := true
-		for _, fn := range fns {
-			if !fn(quote) {
-				include = false
-				break
-			}
-		}
-		if include {
-			filteredQuotes = append(filteredQuotes, quote)
-		}
-	}
-	return &Quotes{items: filteredQuotes}
+	return &Quotes{Collection: *list.Collection.Filter(fns...)}
 }
 
-// Normalize validates and trims the fields of all items
-func (list *Quotes) Normalize() {
-	for _, quote := range list.items {
-		quote.Normalize()
-	}
+// Sort sorts the quotes in place by the comparison function, see Collection.Sort
+func (list *Quotes) Sort(cmp func(a, b *Quote) int) *Quotes {
+	list.Collection.Sort(cmp)
+	return list
 }
 
-// UnmarshalJSON to array of items using encoding/json
-func (list *Quotes) UnmarshalJSON(data []byte) error {
-
-	// Unmarshal to a slice of Image
-	var quotes []*Quote
-	if err := json.Unmarshal(data, &quotes); err != nil {
-		return err
-	}
-
-	// Create a new Images collection
-	*list = *NewQuotes(quotes...)
-
-	return nil
+// Dedupe removes the quotes with the duplicate ID, the first quote is kept
+func (list *Quotes) Dedupe() *Quotes {
+	list.Collection.Dedupe()
+	return list
 }
 
-// MarshalJSON from array of items using encoding/json
-func (list *Quotes) MarshalJSON() ([]byte, error) {
-	return json.Marshal(list.items)
+// DedupeFunc removes the quotes with the duplicate key, the first quote is kept
+func (list *Quotes) DedupeFunc(key func(*Quote) string) *Quotes {
+	list.Collection.DedupeFunc(key)
+	return list
 }
@@ -0,0 +1,229 @@
+package article
+
+import (
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Rendition is a resized or converted variant of the Image, e.g. 640w WebP for the srcset.
+type Rendition struct {
+
+	// URL is the URL of the rendition.
+	// This field is required and should be a valid URL.
+	URL string `json:"url" validate:"required,url,limit=URL"`
+
+	// Width is the width of the rendition in pixels.
+	// This field is required for the srcset.
+	Width int `json:"width" validate:"min=0"`
+
+	// Height is the height of the rendition in pixels.
+	// This field is optional.
+	Height int `json:"height,omitempty" validate:"min=0"`
+
+	// Format is the image format, e.g. jpeg, png, webp, avif.
+	// This field is optional, the MIME type is converted to the format during normalization.
+	Format string `json:"format,omitempty" validate:"max=20"`
+
+	// Size is the file size of the rendition in bytes.
+	// This field is optional.
+	Size int `json:"size,omitempty" validate:"min=0"`
+}
+
+// FocalPoint is the point of interest of the Image kept visible when cropping,
+// the coordinates are relative to the image size, e.g. 0.5, 0.5 is the center.
+type FocalPoint struct {
+	X float64 `json:"x" validate:"min=0,max=1"`
+	Y float64 `json:"y" validate:"min=0,max=1"`
+}
+
+// CropBox is the area of the Image in pixels shown instead of the whole image.
+type CropBox struct {
+	X      int `json:"x" validate:"min=0"`
+	Y      int `json:"y" validate:"min=0"`
+	Width  int `json:"width" validate:"min=1"`
+	Height int `json:"height" validate:"min=1"`
+}
+
+// SizeRule is the rule of the sizes attribute, e.g. (max-width: 600px) 100vw.
+// The rule without the media condition is the default width.
+type SizeRule struct {
+	// Media is the media condition, e.g. (max-width: 600px).
+	Media string
+	// Width is the slot width, e.g. 100vw, 600px or calc(100vw - 2rem).
+	Width string
+}
+
+// normalize trims the fields, converts the format and records the changes to the report
+func (r *Rendition) normalize(report *NormalizeReport, path string) error {
+
+	r.URL = URLCanonicalizer.asset(report, path+".URL", report.trim(path+".URL", r.URL, report.limits().URL), report.baseURL())
+	r.Format = report.trim(path+".Format", imageFormat(r.Format), 20)
+
+	err := report.validate(r)
+	if err != nil {
+		slog.Debug("Validation error in Rendition", slog.String("path", path), slog.String("error", err.Error()))
+	}
+
+	return err
+}
+
+// Map converts the Rendition struct to a map[string]any.
+func (r *Rendition) Map() map[string]any {
+	return map[string]any{
+		"url":    r.URL,
+		"width":  r.Width,
+		"height": r.Height,
+		"format": r.Format,
+		"size":   r.Size,
+	}
+}
+
+// renditionFromMap reads the Rendition with the map reader and validates it
+func renditionFromMap(r *mapReader) (*Rendition, error) {
+
+	rendition := &Rendition{
+		URL:    r.string("url"),
+		Width:  r.int("width"),
+		Height: r.int("height"),
+		Format: r.string("format"),
+		Size:   r.int("size"),
+	}
+
+	if err := r.failed(); err != nil {
+		return nil, err
+	}
+
+	if err := r.validate(rendition); err != nil {
+		return nil, err
+	}
+
+	return rendition, nil
+}
+
+// imageFormat converts the MIME type or the extension to the format, e.g. image/jpeg and jpg to jpeg
+func imageFormat(format string) string {
+
+	format = strings.ToLower(strings.TrimSpace(format))
+	format = strings.TrimPrefix(format, "image/")
+	format = strings.TrimPrefix(format, ".")
+
+	switch format {
+	case "jpg", "pjpeg":
+		return "jpeg"
+	case "svg+xml":
+		return "svg"
+	}
+
+	return format
+}
+
+// candidates returns the renditions and the original image with the known width,
+// in the formats if any, ordered by the width
+func (i *Image) candidates(formats []string) []*Rendition {
+
+	accepted := func(format string) bool {
+		if len(formats) == 0 || format == "" {
+			return true
+		}
+		for _, f := range formats {
+			if imageFormat(f) == format {
+				return true
+			}
+		}
+		return false
+	}
+
+	var candidates []*Rendition
+	for _, r := range i.Renditions {
+		if r.URL != "" && accepted(r.Format) {
+			candidates = append(candidates, r)
+		}
+	}
+
+	if i.URL != "" {
+		candidates = append(candidates, &Rendition{URL: i.URL, Width: i.Width, Height: i.Height, Size: i.Size})
+	}
+
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return candidates[a].Width < candidates[b].Width
+	})
+
+	return candidates
+}
+
+// Rendition returns the smallest rendition at least as wide as the target width in one of the formats,
+// the widest one if all are narrower. The original image is a candidate too.
+// The zero width returns the widest rendition, nil if the image has no URLs.
+func (i *Image) Rendition(width int, formats ...string) *Rendition {
+
+	candidates := i.candidates(formats)
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	if width > 0 {
+		for _, c := range candidates {
+			if c.Width >= width {
+				return c
+			}
+		}
+	}
+
+	return candidates[len(candidates)-1]
+}
+
+// SrcSet returns the srcset attribute of the renditions and the original image in one of the formats,
+// e.g. "https://example.com/a-320.jpg 320w, https://example.com/a.jpg 1024w".
+// The candidates without the width are skipped, the first candidate of the width is used.
+func (i *Image) SrcSet(formats ...string) string {
+
+	var parts []string
+	seen := make(map[int]struct{})
+
+	for _, c := range i.candidates(formats) {
+		if _, ok := seen[c.Width]; ok || c.Width <= 0 {
+			continue
+		}
+		seen[c.Width] = struct{}{}
+		parts = append(parts, c.URL+" "+strconv.Itoa(c.Width)+"w")
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// Sizes returns the sizes attribute of the rules, the rule without the media condition goes last.
+// Without rules the image fills the viewport up to its widest candidate,
+// e.g. "(max-width: 1024px) 100vw, 1024px".
+func (i *Image) Sizes(rules ...SizeRule) string {
+
+	if len(rules) == 0 {
+		widest := i.Rendition(0)
+		if widest == nil || widest.Width <= 0 {
+			return "100vw"
+		}
+		px := strconv.Itoa(widest.Width) + "px"
+		return "(max-width: " + px + ") 100vw, " + px
+	}
+
+	var parts []string
+	fallback := ""
+
+	for _, rule := range rules {
+		media, width := strings.TrimSpace(rule.Media), strings.TrimSpace(rule.Width)
+		switch {
+		case width == "":
+		case media == "":
+			fallback = width
+		default:
+			parts = append(parts, media+" "+width)
+		}
+	}
+
+	if fallback != "" {
+		parts = append(parts, fallback)
+	}
+
+	return strings.Join(parts, ", ")
+}
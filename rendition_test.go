@@ -0,0 +1,154 @@
+package article_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+func newRenditionsImage() *article.Image {
+	img := article.NewImage("https://example.com/a.jpg")
+	img.Width, img.Height = 1600, 900
+	img.Renditions = []*article.Rendition{
+		{URL: "https://cdn.example.com/a-640.webp", Width: 640, Height: 360, Format: "image/webp"},
+		{URL: "https://cdn.example.com/a-320.jpg", Width: 320, Height: 180, Format: "jpg"},
+		{URL: "https://cdn.example.com/a-640.jpg", Width: 640, Height: 360, Format: "JPEG"},
+		{URL: "https://cdn.example.com/a-1024.webp", Width: 1024, Height: 576, Format: "webp"},
+	}
+	return img
+}
+
+func TestImage_Renditions(t *testing.T) {
+
+	img := newRenditionsImage()
+	img.Normalize()
+	require.Len(t, img.Renditions, 4)
+
+	assert.Equal(t, "webp", img.Renditions[0].Format)
+	assert.Equal(t, "jpeg", img.Renditions[1].Format)
+	assert.Equal(t, "jpeg", img.Renditions[2].Format)
+
+	tests := []struct {
+		name    string
+		width   int
+		formats []string
+		want    string
+	}{
+		{"smallest wider", 500, nil, "https://cdn.example.com/a-640.webp"},
+		{"exact", 320, nil, "https://cdn.example.com/a-320.jpg"},
+		{"format", 500, []string{"jpeg"}, "https://cdn.example.com/a-640.jpg"},
+		{"mime format", 700, []string{"image/webp"}, "https://cdn.example.com/a-1024.webp"},
+		{"original", 1200, []string{"webp"}, "https://example.com/a.jpg"},
+		{"widest", 4000, nil, "https://example.com/a.jpg"},
+		{"zero width", 0, []string{"avif"}, "https://example.com/a.jpg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, img.Rendition(tt.width, tt.formats...).URL)
+		})
+	}
+
+	assert.Nil(t, (&article.Image{}).Rendition(100))
+}
+
+func TestImage_SrcSet(t *testing.T) {
+
+	img := newRenditionsImage()
+	img.Normalize()
+
+	assert.Equal(t,
+		"https://cdn.example.com/a-320.jpg 320w, https://cdn.example.com/a-640.webp 640w, "+
+			"https://cdn.example.com/a-1024.webp 1024w, https://example.com/a.jpg 1600w",
+		img.SrcSet())
+
+	assert.Equal(t,
+		"https://cdn.example.com/a-320.jpg 320w, https://cdn.example.com/a-640.jpg 640w, https://example.com/a.jpg 1600w",
+		img.SrcSet("jpeg"))
+
+	assert.Equal(t, "(max-width: 1600px) 100vw, 1600px", img.Sizes())
+	assert.Equal(t, "100vw", (&article.Image{URL: "https://example.com/a.jpg"}).Sizes())
+	assert.Equal(t, "(max-width: 600px) 100vw, (max-width: 1200px) 50vw, 800px", img.Sizes(
+		article.SizeRule{Width: "800px"},
+		article.SizeRule{Media: "(max-width: 600px)", Width: "100vw"},
+		article.SizeRule{Media: "(max-width: 1200px)", Width: "50vw"},
+	))
+}
+
+func TestImage_NormalizeRenditions(t *testing.T) {
+
+	img := newRenditionsImage()
+
+	a := article.NewArticle()
+	a.Title = "Title"
+	a.Text = "Text"
+	a.Markup = "<p>Text</p>"
+	a.Images.Add(img)
+
+	// invalid values set after Add, which skips the invalid images
+	img.Renditions = append(img.Renditions, &article.Rendition{URL: "not-a-url", Width: 100}, nil)
+	img.Focal = &article.FocalPoint{X: 1.5, Y: 0.5}
+	img.Crop = &article.CropBox{X: 1000, Y: 0, Width: 800, Height: 900}
+
+	report, err := a.NormalizeWithReport()
+	require.NoError(t, err)
+
+	assert.Len(t, img.Renditions, 4)
+	assert.Nil(t, img.Focal)
+	assert.Nil(t, img.Crop)
+
+	removed := report.Filter(article.ActionRemoved)
+	require.Len(t, removed, 1)
+	assert.Equal(t, "Article.Images[0].Renditions[4].URL", removed[0].Field)
+
+	reset := report.Field("Article.Images[0].Focal")
+	require.Len(t, reset, 1)
+	assert.Equal(t, "Article.Images[0].Focal.X", reset[0].Field)
+
+	reset = report.Field("Article.Images[0].Crop")
+	require.Len(t, reset, 1)
+	assert.Equal(t, "bounds", reset[0].Tag)
+}
+
+func TestImage_RenditionsMapJSON(t *testing.T) {
+
+	img := newRenditionsImage()
+	img.Focal = &article.FocalPoint{X: 0.25, Y: 0.75}
+	img.Crop = &article.CropBox{X: 100, Y: 50, Width: 800, Height: 600}
+	img.Normalize()
+
+	fromMap, err := article.NewImageFromMap(img.Map())
+	require.NoError(t, err)
+	assert.Equal(t, img, fromMap)
+
+	data, err := json.Marshal(img)
+	require.NoError(t, err)
+
+	var fromJSON *article.Image
+	require.NoError(t, json.Unmarshal(data, &fromJSON))
+	assert.Equal(t, img, fromJSON)
+
+	// JSON-decoded map with float64 numbers
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(data, &m))
+	fromMap, err = article.NewImageFromMap(m)
+	require.NoError(t, err)
+	assert.Equal(t, img, fromMap)
+}
+
+func TestDerive_SrcSetRenditions(t *testing.T) {
+
+	a := article.NewArticle()
+	a.SourceURL = "https://example.com/news/1"
+	a.Markup = `<p><img src="/a.jpg" srcset="/a-320.jpg 320w, /a-640.jpg 640w, /a.jpg 1024w, /a@2x.jpg 2x" width="1024"></p>`
+	a.Derive()
+
+	require.Equal(t, 1, a.Images.Len())
+	img := a.Images.Slice()[0]
+
+	assert.Equal(t, "https://example.com/a-320.jpg 320w, https://example.com/a-640.jpg 640w, https://example.com/a.jpg 1024w", img.SrcSet())
+}
@@ -0,0 +1,358 @@
+package article
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// URLChanges lists the URLs of a collection or a markup processed by the URL replacement.
+type URLChanges struct {
+	// Replaced maps the original URL to the new one.
+	Replaced map[string]string `json:"replaced,omitempty"`
+	// Missing are the media URLs not found in the replacement map.
+	Missing []string `json:"missing,omitempty"`
+	// Failed are the IDs of the items with the missing URLs.
+	Failed []string `json:"failed,omitempty"`
+	// Removed are the IDs of the items removed by ReplaceOrRemoveURLs,
+	// for the markup the URLs of the removed elements.
+	Removed []string `json:"removed,omitempty"`
+}
+
+// ReplaceResult is the result of the URL replacement in the Article.
+type ReplaceResult struct {
+	// Images are the article and contributor images.
+	Images URLChanges `json:"images"`
+	Videos URLChanges `json:"videos"`
+	Medias URLChanges `json:"medias"`
+	Quotes URLChanges `json:"quotes"`
+	// Socials are the article and contributor social profiles.
+	Socials URLChanges `json:"socials"`
+	// Markup are the src, srcset, href and poster attributes of the Article.Markup.
+	Markup URLChanges `json:"markup"`
+	// Embeds are the attributes of the Video.Embed and Quote.Embed code.
+	Embeds URLChanges `json:"embeds"`
+}
+
+// Failed returns the IDs of the items with the missing URLs.
+func (r *ReplaceResult) Failed() []string {
+	failed := make([]string, 0)
+	for _, changes := range r.collections() {
+		failed = append(failed, changes.Failed...)
+	}
+	return failed
+}
+
+// Missing returns the media URLs not found in the replacement map, including the markup URLs.
+func (r *ReplaceResult) Missing() []string {
+	var missing []string
+	for _, changes := range append(r.collections(), r.Markup, r.Embeds) {
+		missing = append(missing, changes.Missing...)
+	}
+	return missing
+}
+
+// Replaced returns the number of the replaced URLs.
+func (r *ReplaceResult) Replaced() int {
+	count := 0
+	for _, changes := range append(r.collections(), r.Markup, r.Embeds) {
+		count += len(changes.Replaced)
+	}
+	return count
+}
+
+func (r *ReplaceResult) collections() []URLChanges {
+	return []URLChanges{r.Images, r.Videos, r.Medias, r.Quotes, r.Socials}
+}
+
+// ReplaceURLs replaces the URLs of the Article.Images with the URLs from the provided map.
+// Returns the IDs of the images not found in the map.
+// Use ReplaceURLsWithResult to replace the URLs of the other collections and the Markup.
+func (a *Article) ReplaceURLs(m map[string]string) []string {
+	return a.Images.ReplaceURLs(m)
+}
+
+// ReplaceOrRemoveURLs replaces the URLs like ReplaceURLs and removes the images not found in the map.
+// Returns the IDs of the removed images, see ReplaceOrRemoveURLsWithResult for the other collections.
+func (a *Article) ReplaceOrRemoveURLs(m map[string]string) []string {
+	return a.Images.ReplaceOrRemoveURLs(m)
+}
+
+// ReplaceURLsWithResult replaces every URL of the Article found in the map, e.g. after mirroring the media:
+//   - media URLs: Image.URL, Rendition.URL, Video.URL, Video.Thumbnail, Media.URL,
+//     src, srcset and poster of the media elements in the Markup;
+//   - link URLs: Quote.SourceURL, Quote.Media URLs, Social.URL, href in the Markup,
+//     the attributes of the Video.Embed and Quote.Embed code.
+//
+// The media URLs not found in the map are reported as missing, the link URLs are replaced only if found.
+// The missing renditions and thumbnails do not fail their image or video.
+func (a *Article) ReplaceURLsWithResult(m map[string]string) *ReplaceResult {
+	return a.replaceURLs(m, false)
+}
+
+// ReplaceOrRemoveURLsWithResult replaces the URLs like ReplaceURLsWithResult and removes
+// the items, the image renditions, the video thumbnails and the <img> and <source> elements of the Markup
+// with the missing media URLs.
+func (a *Article) ReplaceOrRemoveURLsWithResult(m map[string]string) *ReplaceResult {
+	return a.replaceURLs(m, true)
+}
+
+func (a *Article) replaceURLs(m map[string]string, remove bool) *ReplaceResult {
+
+	r := &ReplaceResult{}
+
+	r.Images.images(a.Images, m, remove)
+
+	for _, video := range a.Videos.Slice() {
+		r.Videos.media(m, video.ID, &video.URL)
+		r.Videos.thumbnail(video, m, remove)
+		video.Embed = r.Embeds.markup(video.Embed, m, false)
+	}
+	removeFailed(&r.Videos, &a.Videos.Collection, remove, 0)
+
+	if a.Medias != nil {
+		for _, media := range a.Medias.Slice() {
+			r.Medias.media(m, media.ID, &media.URL)
+		}
+		removeFailed(&r.Medias, &a.Medias.Collection, remove, 0)
+	}
+
+	for _, quote := range a.Quotes.Slice() {
+		r.Quotes.link(m, &quote.SourceURL)
+		for _, media := range quote.Media {
+			r.Quotes.link(m, &media.URL)
+		}
+		quote.Embed = r.Embeds.markup(quote.Embed, m, false)
+	}
+
+	r.Socials.socials(a.Socials, m)
+
+	if a.Contributors != nil {
+		for _, person := range a.Contributors.Slice() {
+			r.Images.images(person.Images, m, remove)
+			r.Socials.socials(person.Socials, m)
+		}
+	}
+
+	a.Markup = r.Markup.markup(a.Markup, m, remove)
+
+	return r
+}
+
+func (c *URLChanges) images(list *Images, m map[string]string, remove bool) {
+
+	if list == nil {
+		return
+	}
+
+	since := len(c.Failed)
+	for _, img := range list.Slice() {
+		c.media(m, img.ID, &img.URL)
+		c.renditions(img, m, remove)
+	}
+
+	removeFailed(c, &list.Collection, remove, since)
+}
+
+// renditions replaces the rendition URLs, the missing renditions are removed in the remove mode
+// without failing the image
+func (c *URLChanges) renditions(img *Image, m map[string]string, remove bool) {
+
+	var kept []*Rendition
+	for _, r := range img.Renditions {
+		if !c.link(m, &r.URL) {
+			c.Missing = append(c.Missing, r.URL)
+			if remove {
+				continue
+			}
+		}
+		kept = append(kept, r)
+	}
+
+	img.Renditions = kept
+}
+
+// thumbnail replaces the video thumbnail URL, the missing thumbnail is removed in the remove mode
+// without failing the video
+func (c *URLChanges) thumbnail(video *Video, m map[string]string, remove bool) {
+
+	if video.Thumbnail == "" || c.link(m, &video.Thumbnail) {
+		return
+	}
+
+	c.Missing = append(c.Missing, video.Thumbnail)
+	if remove {
+		video.Thumbnail = ""
+	}
+}
+
+func (c *URLChanges) socials(list *Socials, m map[string]string) {
+
+	if list == nil {
+		return
+	}
+
+	for _, social := range list.Slice() {
+		c.link(m, &social.URL)
+	}
+}
+
+// media replaces the media URL of the item or records it as missing
+func (c *URLChanges) media(m map[string]string, id string, u *string) {
+
+	if *u == "" {
+		return
+	}
+
+	if !c.link(m, u) {
+		c.Missing = append(c.Missing, *u)
+		c.Failed = append(c.Failed, id)
+	}
+}
+
+// link replaces the URL if found in the map
+func (c *URLChanges) link(m map[string]string, u *string) bool {
+
+	replaced, ok := m[*u]
+	if !ok || *u == "" {
+		return false
+	}
+
+	if c.Replaced == nil {
+		c.Replaced = make(map[string]string)
+	}
+
+	c.Replaced[*u] = replaced
+	*u = replaced
+
+	return true
+}
+
+// removeFailed removes the items failed since the index from the collection
+func removeFailed[T Item](c *URLChanges, list *Collection[T], remove bool, since int) {
+	if failed := c.Failed[since:]; remove && len(failed) > 0 {
+		list.Remove(failed...)
+		c.Removed = append(c.Removed, failed...)
+	}
+}
+
+// markup replaces the URLs in the attributes of the HTML elements,
+// in the remove mode the <img> and <source> elements with the missing src are removed
+func (c *URLChanges) markup(markup string, m map[string]string, remove bool) string {
+
+	if markup == "" {
+		return markup
+	}
+
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(markup))
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return b.String()
+		}
+
+		// the raw token is read before Token, which unescapes the text in place
+		raw := string(z.Raw())
+
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			b.WriteString(raw)
+			continue
+		}
+
+		token := z.Token()
+		changed, drop := c.attributes(&token, m, remove)
+
+		switch {
+		case drop:
+		case changed:
+			b.WriteString(token.String())
+		default:
+			b.WriteString(raw)
+		}
+	}
+}
+
+// mediaTags are the elements with the media src, srcset and poster attributes
+var mediaTags = map[atom.Atom]bool{
+	atom.Img: true, atom.Source: true, atom.Video: true, atom.Audio: true, atom.Track: true,
+}
+
+// attributes replaces the URLs of the element, returns true if the element changed or should be dropped
+func (c *URLChanges) attributes(token *html.Token, m map[string]string, remove bool) (changed bool, drop bool) {
+
+	media := mediaTags[token.DataAtom]
+	attrs := token.Attr[:0]
+
+	for _, attr := range token.Attr {
+
+		switch {
+		case attr.Key == "srcset" && media:
+			var ok bool
+			attr.Val, ok = c.srcset(attr.Val, m, remove)
+			changed = changed || ok
+			if attr.Val == "" {
+				continue
+			}
+
+		case (attr.Key == "src" || attr.Key == "poster") && media:
+			value := attr.Val
+			if c.link(m, &attr.Val) {
+				changed = true
+			} else if value != "" {
+				c.Missing = append(c.Missing, value)
+				if remove && attr.Key == "src" && (token.DataAtom == atom.Img || token.DataAtom == atom.Source) {
+					c.Removed = append(c.Removed, value)
+					drop = true
+				}
+			}
+
+		case attr.Key == "href" || attr.Key == "src":
+			changed = c.link(m, &attr.Val) || changed
+		}
+
+		attrs = append(attrs, attr)
+	}
+
+	token.Attr = attrs
+
+	return changed, drop
+}
+
+// srcset replaces the candidate URLs, e.g. "a.jpg 1x, b.jpg 2x",
+// in the remove mode the candidates with the missing URLs are removed
+func (c *URLChanges) srcset(srcset string, m map[string]string, remove bool) (string, bool) {
+
+	changed := false
+	candidates := make([]string, 0)
+
+	for _, candidate := range strings.Split(srcset, ",") {
+
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+
+		value := fields[0]
+		if c.link(m, &fields[0]) {
+			changed = true
+		} else {
+			c.Missing = append(c.Missing, value)
+			if remove {
+				c.Removed = append(c.Removed, value)
+				changed = true
+				continue
+			}
+		}
+
+		candidates = append(candidates, strings.Join(fields, " "))
+	}
+
+	if !changed {
+		return srcset, false
+	}
+
+	return strings.Join(candidates, ", "), true
+}
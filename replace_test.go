@@ -0,0 +1,164 @@
+package article_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+func newMirroredArticle() *article.Article {
+
+	a := article.NewArticle()
+	a.Markup = `<p>Text <a href="https://example.com/page">link</a></p>` +
+		`<img src="https://example.com/a.jpg" srcset="https://example.com/a.jpg 1x, https://example.com/a2.jpg 2x" alt="A">` +
+		`<img src="https://example.com/lost.jpg">` +
+		`<video poster="https://example.com/poster.jpg"><source src="https://example.com/v.mp4"></video>`
+	a.Images.Add(&article.Image{ID: "img-1", URL: "https://example.com/a.jpg"})
+	a.Images.Add(&article.Image{ID: "img-2", URL: "https://example.com/lost.jpg"})
+	video := &article.Video{ID: "video-1", URL: "https://example.com/v.mp4", Embed: `<iframe src="https://example.com/player"></iframe>`}
+	a.Videos.Add(video)
+	a.Medias.Add(&article.Media{ID: "media-1", URL: "https://example.com/a.mp3"})
+	a.Quotes.Add(&article.Quote{ID: "quote-1", Text: "Quote", SourceURL: "https://example.com/quote"})
+	a.Socials.Add(&article.Social{ID: "social-1", URL: "https://example.com/profile"})
+
+	person := article.NewPerson("John Doe")
+	person.Images.Add(&article.Image{ID: "avatar", URL: "https://example.com/avatar.jpg"})
+	a.Contributors.Add(person)
+
+	return a
+}
+
+var mirrored = map[string]string{
+	"https://example.com/a.jpg":      "https://cdn.example.com/a.jpg",
+	"https://example.com/a2.jpg":     "https://cdn.example.com/a2.jpg",
+	"https://example.com/poster.jpg": "https://cdn.example.com/poster.jpg",
+	"https://example.com/v.mp4":      "https://cdn.example.com/v.mp4",
+	"https://example.com/a.mp3":      "https://cdn.example.com/a.mp3",
+	"https://example.com/page":       "https://example.org/page",
+	"https://example.com/player":     "https://example.org/player",
+	"https://example.com/quote":      "https://example.org/quote",
+	"https://example.com/profile":    "https://example.org/profile",
+}
+
+func TestArticle_ReplaceURLsWithResult(t *testing.T) {
+
+	a := newMirroredArticle()
+	result := a.ReplaceURLsWithResult(mirrored)
+
+	assert.Equal(t, `<p>Text <a href="https://example.org/page">link</a></p>`+
+		`<img src="https://cdn.example.com/a.jpg" srcset="https://cdn.example.com/a.jpg 1x, https://cdn.example.com/a2.jpg 2x" alt="A">`+
+		`<img src="https://example.com/lost.jpg">`+
+		`<video poster="https://cdn.example.com/poster.jpg"><source src="https://cdn.example.com/v.mp4"></video>`, a.Markup)
+
+	assert.Equal(t, "https://cdn.example.com/a.jpg", a.Images.Slice()[0].URL)
+	assert.Equal(t, "https://cdn.example.com/v.mp4", a.Videos.Slice()[0].URL)
+	assert.Equal(t, `<iframe src="https://example.org/player"></iframe>`, a.Videos.Slice()[0].Embed)
+	assert.Equal(t, "https://cdn.example.com/a.mp3", a.Medias.Slice()[0].URL)
+	assert.Equal(t, "https://example.org/quote", a.Quotes.Slice()[0].SourceURL)
+	assert.Equal(t, "https://example.org/profile", a.Socials.Slice()[0].URL)
+
+	assert.Equal(t, []string{"img-2", "avatar"}, result.Images.Failed)
+	assert.Equal(t, []string{"https://example.com/lost.jpg", "https://example.com/avatar.jpg"}, result.Images.Missing)
+	assert.Empty(t, result.Images.Removed)
+	assert.Equal(t, []string{"https://example.com/lost.jpg"}, result.Markup.Missing)
+	assert.Equal(t, "https://example.org/page", result.Markup.Replaced["https://example.com/page"])
+	assert.Equal(t, []string{"img-2", "avatar"}, result.Failed())
+	assert.Len(t, result.Missing(), 3)
+	assert.Equal(t, 11, result.Replaced())
+
+	// links not found in the map are not missing
+	assert.Empty(t, result.Quotes.Missing)
+	assert.Empty(t, result.Socials.Missing)
+}
+
+func TestArticle_ReplaceOrRemoveURLsWithResult(t *testing.T) {
+
+	a := newMirroredArticle()
+	result := a.ReplaceOrRemoveURLsWithResult(map[string]string{
+		"https://example.com/a.jpg": "https://cdn.example.com/a.jpg",
+	})
+
+	assert.Equal(t, `<p>Text <a href="https://example.com/page">link</a></p>`+
+		`<img src="https://cdn.example.com/a.jpg" srcset="https://cdn.example.com/a.jpg 1x" alt="A">`+
+		`<video poster="https://example.com/poster.jpg"></video>`, a.Markup)
+
+	assert.Equal(t, []string{"img-1"}, a.Images.IDs())
+	assert.Zero(t, a.Videos.Len())
+	assert.Zero(t, a.Medias.Len())
+	assert.Zero(t, a.Contributors.Slice()[0].Images.Len())
+	assert.Equal(t, 1, a.Quotes.Len())
+
+	assert.Equal(t, []string{"img-2", "avatar"}, result.Images.Removed)
+	assert.Equal(t, []string{"video-1"}, result.Videos.Removed)
+	assert.Equal(t, []string{"media-1"}, result.Medias.Removed)
+	require.Len(t, result.Markup.Removed, 3)
+}
+
+func TestArticle_ReplaceURLsWithResult_VideoAndQuote(t *testing.T) {
+
+	newArticle := func() *article.Article {
+		a := article.NewArticle()
+		a.Videos.Add(&article.Video{ID: "video-1", URL: "https://example.com/v.mp4", Thumbnail: "https://example.com/thumb.jpg"})
+		a.Quotes.Add(&article.Quote{
+			ID:        "quote-1",
+			Text:      "Post",
+			SourceURL: "https://example.com/status/1",
+			Media:     []*article.PostMedia{{URL: "https://example.com/post.jpg", Type: "image"}},
+			Embed:     `<blockquote class="twitter-tweet"><a href="https://example.com/status/1">Post</a><img src="https://example.com/post.jpg"></blockquote>`,
+		})
+		return a
+	}
+
+	m := map[string]string{
+		"https://example.com/v.mp4":     "https://cdn.example.com/v.mp4",
+		"https://example.com/thumb.jpg": "https://cdn.example.com/thumb.jpg",
+		"https://example.com/post.jpg":  "https://cdn.example.com/post.jpg",
+		"https://example.com/status/1":  "https://example.org/status/1",
+	}
+
+	a := newArticle()
+	result := a.ReplaceURLsWithResult(m)
+
+	video := a.Videos.Slice()[0]
+	assert.Equal(t, "https://cdn.example.com/thumb.jpg", video.Thumbnail)
+	quote := a.Quotes.Slice()[0]
+	assert.Equal(t, "https://cdn.example.com/post.jpg", quote.Media[0].URL)
+	assert.Equal(t, `<blockquote class="twitter-tweet"><a href="https://example.org/status/1">Post</a><img src="https://cdn.example.com/post.jpg"></blockquote>`, quote.Embed)
+	assert.Equal(t, "https://cdn.example.com/thumb.jpg", result.Videos.Replaced["https://example.com/thumb.jpg"])
+	assert.Equal(t, "https://cdn.example.com/post.jpg", result.Quotes.Replaced["https://example.com/post.jpg"])
+	assert.Len(t, result.Embeds.Replaced, 2)
+
+	// the missing thumbnail is removed without the video
+	a = newArticle()
+	result = a.ReplaceOrRemoveURLsWithResult(map[string]string{"https://example.com/v.mp4": "https://cdn.example.com/v.mp4"})
+
+	require.Equal(t, []string{"video-1"}, a.Videos.IDs())
+	assert.Empty(t, a.Videos.Slice()[0].Thumbnail)
+	assert.Equal(t, []string{"https://example.com/thumb.jpg"}, result.Videos.Missing)
+	assert.Empty(t, result.Videos.Removed)
+	assert.Equal(t, "https://example.com/post.jpg", a.Quotes.Slice()[0].Media[0].URL)
+}
+
+func TestArticle_ReplaceOrRemoveURLs_ImagesOnly(t *testing.T) {
+
+	a := newMirroredArticle()
+	markup := a.Markup
+
+	// the image-only map keeps the other collections and the markup
+	assert.Equal(t, []string{"img-2"}, a.ReplaceOrRemoveURLs(map[string]string{
+		"https://example.com/a.jpg": "https://cdn.example.com/a.jpg",
+	}))
+
+	assert.Equal(t, []string{"img-1"}, a.Images.IDs())
+	assert.Equal(t, "https://cdn.example.com/a.jpg", a.Images.Slice()[0].URL)
+	assert.Equal(t, []string{"video-1"}, a.Videos.IDs())
+	assert.Equal(t, "https://example.com/v.mp4", a.Videos.Slice()[0].URL)
+	assert.Equal(t, []string{"media-1"}, a.Medias.IDs())
+	assert.Equal(t, 1, a.Contributors.Slice()[0].Images.Len())
+	assert.Equal(t, markup, a.Markup)
+
+	assert.Equal(t, []string{"img-1", "img-2"}, newMirroredArticle().ReplaceURLs(nil))
+}
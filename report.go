@@ -0,0 +1,201 @@
+package article
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Action is the change applied to a field during normalization.
+type Action string
+
+const (
+	// ActionTrimmed means the value was cut to the maximum allowed length.
+	ActionTrimmed Action = "trimmed"
+	// ActionReset means the invalid value was replaced with the zero value.
+	ActionReset Action = "reset"
+	// ActionRemoved means the item holding the invalid value was removed from its collection.
+	ActionRemoved Action = "removed"
+	// ActionDefault means an empty value was replaced with a default.
+	ActionDefault Action = "default"
+	// ActionRejected means the value is invalid and cannot be fixed, normalization failed.
+	ActionRejected Action = "rejected"
+	// ActionSanitized means an element, attribute or URL was removed from the HTML by the sanitization Policy.
+	ActionSanitized Action = "sanitized"
+	// ActionCanonicalized means the value was replaced with its canonical form, e.g. the URL, see Canonicalizer,
+	// the language tag, see NormalizeLanguage, or the tag and the category, see CanonicalTag.
+	ActionCanonicalized Action = "canonicalized"
+	// ActionMismatch means the value disagrees with the content, e.g. the language of the text, the value is kept.
+	ActionMismatch Action = "mismatch"
+)
+
+// Issue describes a single change made by normalization.
+type Issue struct {
+	// Field is the path of the field, e.g. Article.Images[2].URL
+	Field string `json:"field"`
+	// Tag is the validator tag that failed, e.g. url, max, required.
+	// Empty when the change is not caused by validation (e.g. default applied).
+	Tag string `json:"tag,omitempty"`
+	// Value is the original value of the field before normalization.
+	Value any `json:"value,omitempty"`
+	// Action is the change applied to the field.
+	Action Action `json:"action"`
+}
+
+// String returns a human-readable description of the issue.
+func (i Issue) String() string {
+	if i.Tag == "" {
+		return fmt.Sprintf("%s: %s", i.Field, i.Action)
+	}
+	return fmt.Sprintf("%s: %s (%s)", i.Field, i.Action, i.Tag)
+}
+
+// NormalizeReport collects every change made by normalization,
+// so the caller can see why a field was cleared or an item dropped.
+// All methods are safe to call on a nil report, which records nothing.
+type NormalizeReport struct {
+	Issues []Issue `json:"issues"`
+	// profile are the limits of the normalization, DefaultLimits if nil
+	profile *Limits
+	// base resolves the relative URLs of the items, the Article.SourceURL
+	base string
+}
+
+// NewNormalizeReport creates an empty report.
+func NewNormalizeReport() *NormalizeReport {
+	return &NormalizeReport{Issues: []Issue{}}
+}
+
+// Add records an issue.
+func (r *NormalizeReport) Add(field, tag string, value any, action Action) {
+	if r == nil {
+		return
+	}
+	r.Issues = append(r.Issues, Issue{Field: field, Tag: tag, Value: value, Action: action})
+}
+
+// Len returns the number of issues.
+func (r *NormalizeReport) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.Issues)
+}
+
+// Empty returns true if normalization changed nothing.
+func (r *NormalizeReport) Empty() bool {
+	return r.Len() == 0
+}
+
+// Filter returns the issues with the given action.
+func (r *NormalizeReport) Filter(action Action) []Issue {
+	if r == nil {
+		return nil
+	}
+	var filtered []Issue
+	for _, issue := range r.Issues {
+		if issue.Action == action {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// Field returns the issues of the field and its nested fields, e.g. Article.Images
+// returns issues of every image.
+func (r *NormalizeReport) Field(path string) []Issue {
+	if r == nil {
+		return nil
+	}
+	var filtered []Issue
+	for _, issue := range r.Issues {
+		if issue.Field == path ||
+			strings.HasPrefix(issue.Field, path+".") ||
+			strings.HasPrefix(issue.Field, path+"[") {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// String returns a list of issues, one per line.
+func (r *NormalizeReport) String() string {
+	if r == nil {
+		return ""
+	}
+	lines := make([]string, len(r.Issues))
+	for i, issue := range r.Issues {
+		lines[i] = issue.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// trim trims the value with TrimToMaxLen and records the issue if the value was cut.
+func (r *NormalizeReport) trim(field, value string, maxLen int) string {
+	trimmed := TrimToMaxLen(value, maxLen)
+	if utf8.RuneCountInString(strings.TrimSpace(value)) > maxLen {
+		r.Add(field, "max", value, ActionTrimmed)
+	}
+	return trimmed
+}
+
+// limits returns the limits of the normalization, DefaultLimits for the nil report.
+func (r *NormalizeReport) limits() Limits {
+	if r == nil || r.profile == nil {
+		return DefaultLimits.resolved()
+	}
+	return r.profile.resolved()
+}
+
+// baseURL returns the URL resolving the relative URLs of the items, empty for the nil report.
+func (r *NormalizeReport) baseURL() string {
+	if r == nil {
+		return ""
+	}
+	return r.base
+}
+
+// validate validates the struct with the limits of the normalization.
+func (r *NormalizeReport) validate(s any) error {
+	if r == nil {
+		return validateWithLimits(s, nil)
+	}
+	return validateWithLimits(s, r.profile)
+}
+
+// fallback records the default applied to the empty field.
+func (r *NormalizeReport) fallback(field string, value any) {
+	r.Add(field, "", value, ActionDefault)
+}
+
+// invalid records validation errors of the struct placed at the path with the given action.
+// The struct name in the validator namespace is replaced with the path,
+// e.g. Image.URL at Article.Images[2] becomes Article.Images[2].URL
+func (r *NormalizeReport) invalid(path string, err error, action Action) {
+
+	var invalids validator.ValidationErrors
+	if !errors.As(err, &invalids) {
+		r.Add(path, "", nil, action)
+		return
+	}
+
+	for _, invalid := range invalids {
+		r.Add(fieldPath(path, invalid.Namespace()), invalid.Tag(), invalid.Value(), action)
+	}
+}
+
+// itemPath returns the path of the collection item, e.g. Article.Images[2]
+func itemPath(path string, idx int) string {
+	return fmt.Sprintf("%s[%d]", path, idx)
+}
+
+// fieldPath replaces the struct name in the validator namespace with the path.
+func fieldPath(path, namespace string) string {
+	if _, field, found := strings.Cut(namespace, "."); found {
+		return path + "." + field
+	}
+	return path
+}
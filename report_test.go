@@ -0,0 +1,111 @@
+package article_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+func TestArticle_NormalizeWithReport(t *testing.T) {
+
+	a := article.NewArticle()
+	a.Title = strings.Repeat("a", 300)
+	a.Markup = gofakeit.Paragraph(1, 5, 10, " ")
+	a.Text = gofakeit.Paragraph(1, 5, 10, " ")
+	a.Published = time.Now()
+	a.SourceURL = "invalid-url"
+
+	// the image becomes invalid after it was added
+	broken := NewImageValid()
+	a.Images.Add(NewImageValid(), broken)
+	broken.URL = "invalid-url"
+
+	report, err := a.NormalizeWithReport()
+	require.NoError(t, err)
+
+	// title trimmed
+	trimmed := report.Filter(article.ActionTrimmed)
+	require.Len(t, trimmed, 1)
+	assert.Equal(t, "Article.Title", trimmed[0].Field)
+	assert.Equal(t, "max", trimmed[0].Tag)
+	assert.Equal(t, 255, len(a.Title))
+
+	// source url reset
+	reset := report.Filter(article.ActionReset)
+	require.Len(t, reset, 1)
+	assert.Equal(t, "Article.SourceURL", reset[0].Field)
+	assert.Equal(t, "url", reset[0].Tag)
+	assert.Equal(t, "invalid-url", reset[0].Value)
+	assert.Empty(t, a.SourceURL)
+
+	// invalid image removed
+	removed := report.Field("Article.Images")
+	require.Len(t, removed, 1)
+	assert.Equal(t, "Article.Images[1].URL", removed[0].Field)
+	assert.Equal(t, article.ActionRemoved, removed[0].Action)
+	assert.Equal(t, 1, a.Images.Len())
+
+	// defaults applied
+	defaults := report.Filter(article.ActionDefault)
+	assert.Len(t, defaults, 3)
+	assert.Len(t, report.Field("Article.Language"), 1)
+}
+
+func TestArticle_NormalizeWithReport_Rejected(t *testing.T) {
+
+	a := article.NewArticle()
+	a.Markup = gofakeit.Paragraph(1, 5, 10, " ")
+
+	report, err := a.NormalizeWithReport()
+	require.Error(t, err)
+
+	rejected := report.Filter(article.ActionRejected)
+	require.NotEmpty(t, rejected)
+	assert.Equal(t, "required", rejected[0].Tag)
+}
+
+func TestArticle_NormalizeWithReport_RejectedReportsAll(t *testing.T) {
+
+	a := article.NewArticle()
+	a.Markup = gofakeit.Paragraph(1, 5, 10, " ")
+	a.Text = a.Markup
+	a.Published = time.Now()
+	a.SourceURL = "invalid-url"
+
+	report, err := a.NormalizeWithReport()
+	require.Error(t, err)
+
+	// the invalid field after the missing title is reported and reset
+	rejected := report.Filter(article.ActionRejected)
+	require.Len(t, rejected, 1)
+	assert.Equal(t, "Article.Title", rejected[0].Field)
+	reset := report.Field("Article.SourceURL")
+	require.Len(t, reset, 1)
+	assert.Equal(t, article.ActionReset, reset[0].Action)
+	assert.Empty(t, a.SourceURL)
+}
+
+func TestNormalizeReport_Nil(t *testing.T) {
+
+	var report *article.NormalizeReport
+
+	report.Add("Article.Title", "max", "", article.ActionTrimmed)
+	assert.True(t, report.Empty())
+	assert.Empty(t, report.Filter(article.ActionTrimmed))
+	assert.Empty(t, report.String())
+}
+
+func TestNormalizeReport_String(t *testing.T) {
+
+	report := article.NewNormalizeReport()
+	report.Add("Article.Images[0].URL", "url", "invalid-url", article.ActionRemoved)
+	report.Add("Article.Language", "", "", article.ActionDefault)
+
+	assert.Equal(t, "Article.Images[0].URL: removed (url)\nArticle.Language: default", report.String())
+}
@@ -0,0 +1,430 @@
+package article
+
+import (
+	"encoding/xml"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// XML namespaces of the RSS extensions.
+const (
+	nsMedia   = "http://search.yahoo.com/mrss/"
+	nsDC      = "http://purl.org/dc/elements/1.1/"
+	nsContent = "http://purl.org/rss/1.0/modules/content/"
+	nsAtom    = "http://www.w3.org/2005/Atom"
+)
+
+type rssDocument struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	NSMedia   string     `xml:"xmlns:media,attr"`
+	NSDC      string     `xml:"xmlns:dc,attr"`
+	NSContent string     `xml:"xmlns:content,attr"`
+	NSAtom    string     `xml:"xmlns:atom,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title          string    `xml:"title"`
+	Link           string    `xml:"link"`
+	Description    string    `xml:"description"`
+	Language       string    `xml:"language,omitempty"`
+	Copyright      string    `xml:"copyright,omitempty"`
+	ManagingEditor string    `xml:"managingEditor,omitempty"`
+	LastBuildDate  string    `xml:"lastBuildDate,omitempty"`
+	Generator      string    `xml:"generator,omitempty"`
+	Self           *rssSelf  `xml:"atom:link,omitempty"`
+	Image          *rssImage `xml:"image,omitempty"`
+	Items          []rssItem `xml:"item"`
+}
+
+type rssSelf struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssImage struct {
+	URL   string `xml:"url"`
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+}
+
+type rssItem struct {
+	Title       string            `xml:"title"`
+	Link        string            `xml:"link,omitempty"`
+	GUID        rssGUID           `xml:"guid"`
+	Description string            `xml:"description,omitempty"`
+	Content     string            `xml:"content:encoded,omitempty"`
+	Creators    []string          `xml:"dc:creator"`
+	PubDate     string            `xml:"pubDate,omitempty"`
+	Categories  []string          `xml:"category"`
+	Media       []rssMediaContent `xml:"media:content"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssMediaContent struct {
+	URL         string `xml:"url,attr"`
+	Type        string `xml:"type,attr,omitempty"`
+	Medium      string `xml:"medium,attr"`
+	Width       string `xml:"width,attr,omitempty"`
+	Height      string `xml:"height,attr,omitempty"`
+	Duration    string `xml:"duration,attr,omitempty"`
+	Title       string `xml:"media:title,omitempty"`
+	Description string `xml:"media:description,omitempty"`
+}
+
+// WriteRSS writes the Articles as RSS 2.0 feed with media, Dublin Core and content extensions.
+func (list *Articles) WriteRSS(w io.Writer, feed Feed) error {
+
+	doc := rssDocument{
+		Version:   "2.0",
+		NSMedia:   nsMedia,
+		NSDC:      nsDC,
+		NSContent: nsContent,
+		NSAtom:    nsAtom,
+		Channel: rssChannel{
+			Title:          feed.Title,
+			Link:           feed.Link,
+			Description:    feed.Description,
+			Language:       feed.Language,
+			Copyright:      feed.Copyright,
+			ManagingEditor: feed.Author,
+			LastBuildDate:  rssTime(feed.updated(list)),
+			Generator:      feed.Generator,
+		},
+	}
+
+	if feed.FeedURL != "" {
+		doc.Channel.Self = &rssSelf{Href: feed.FeedURL, Rel: "self", Type: "application/rss+xml"}
+	}
+
+	if feed.ImageURL != "" {
+		doc.Channel.Image = &rssImage{URL: feed.ImageURL, Title: feed.Title, Link: feed.Link}
+	}
+
+	for _, a := range list.Slice() {
+		doc.Channel.Items = append(doc.Channel.Items, rssArticle(a))
+	}
+
+	return writeXML(w, doc)
+}
+
+func rssArticle(a *Article) rssItem {
+
+	item := rssItem{
+		Title:       a.Title,
+		Link:        a.SourceURL,
+		GUID:        rssGUID{IsPermaLink: "false", Value: a.ID},
+		Description: a.Summary,
+		Content:     a.Markup,
+		Creators:    a.authors(),
+		PubDate:     rssTime(a.Published),
+		Categories:  a.categories(),
+	}
+
+	for _, img := range a.Images.Slice() {
+		item.Media = append(item.Media, rssMediaContent{
+			URL:         img.URL,
+			Type:        mimeType(img.URL),
+			Medium:      "image",
+			Width:       rssInt(img.Width),
+			Height:      rssInt(img.Height),
+			Title:       img.Title,
+			Description: img.Alt,
+		})
+	}
+
+	for _, video := range a.Videos.Slice() {
+		item.Media = append(item.Media, rssMediaContent{
+			URL:      video.URL,
+			Type:     mimeType(video.URL),
+			Medium:   "video",
+			Duration: rssInt(video.Duration),
+			Title:    video.Title,
+		})
+	}
+
+	return item
+}
+
+func rssTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC1123Z)
+}
+
+func rssInt(i int) string {
+	if i <= 0 {
+		return ""
+	}
+	return strconv.Itoa(i)
+}
+
+// writeXML writes the document with XML header and indentation
+func writeXML(w io.Writer, doc any) error {
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// xmlText captures the element with its namespace, so elements of the same local name
+// from different namespaces, e.g. link and atom:link, could be told apart
+type xmlText struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+// pickText returns the first non-empty value of the elements in the namespace.
+func pickText(elems []xmlText, spaces ...string) string {
+	for _, elem := range elems {
+		for _, space := range spaces {
+			if elem.XMLName.Space == space && strings.TrimSpace(elem.Value) != "" {
+				return strings.TrimSpace(elem.Value)
+			}
+		}
+	}
+	return ""
+}
+
+// rssSpaces are the default namespaces of RSS 0.9x, 1.0 and 2.0
+var rssSpaces = []string{"", "http://purl.org/rss/1.0/", "http://my.netscape.com/rdf/simple/0.9/"}
+
+type rssParseDocument struct {
+	Channel rssParseChannel `xml:"channel"`
+	// RSS 0.90 and 1.0 items are siblings of the channel
+	Items []rssParseItem `xml:"item"`
+}
+
+type rssParseChannel struct {
+	Titles       []xmlText      `xml:"title"`
+	Links        []xmlText      `xml:"link"`
+	Descriptions []xmlText      `xml:"description"`
+	Languages    []xmlText      `xml:"language"`
+	Copyright    string         `xml:"copyright"`
+	Generator    string         `xml:"generator"`
+	Items        []rssParseItem `xml:"item"`
+}
+
+type rssParseItem struct {
+	Titles       []xmlText       `xml:"title"`
+	Links        []xmlText       `xml:"link"`
+	Descriptions []xmlText       `xml:"description"`
+	GUID         rssGUID         `xml:"guid"`
+	PubDate      string          `xml:"pubDate"`
+	Dates        []xmlText       `xml:"date"`
+	Authors      []xmlText       `xml:"author"`
+	Creators     []xmlText       `xml:"creator"`
+	Encoded      []xmlText       `xml:"encoded"`
+	Categories   []xmlText       `xml:"category"`
+	Subjects     []xmlText       `xml:"subject"`
+	Enclosures   []rssEnclosure  `xml:"enclosure"`
+	Media        []rssParseMedia `xml:"content"`
+	Groups       []rssParseGroup `xml:"group"`
+	Thumbnails   []rssParseMedia `xml:"thumbnail"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+// rssParseMedia is media:content or media:thumbnail
+type rssParseMedia struct {
+	XMLName      xml.Name
+	URL          string    `xml:"url,attr"`
+	Type         string    `xml:"type,attr"`
+	Medium       string    `xml:"medium,attr"`
+	Width        string    `xml:"width,attr"`
+	Height       string    `xml:"height,attr"`
+	FileSize     string    `xml:"fileSize,attr"`
+	Duration     string    `xml:"duration,attr"`
+	Titles       []xmlText `xml:"title"`
+	Descriptions []xmlText `xml:"description"`
+}
+
+type rssParseGroup struct {
+	XMLName      xml.Name
+	Media        []rssParseMedia `xml:"content"`
+	Thumbnails   []rssParseMedia `xml:"thumbnail"`
+	Titles       []xmlText       `xml:"title"`
+	Descriptions []xmlText       `xml:"description"`
+}
+
+// isMediaRSS is true for the elements of the Media RSS namespace,
+// the trailing slash is omitted by some publishers
+func isMediaRSS(name xml.Name) bool {
+	return strings.TrimSuffix(name.Space, "/") == strings.TrimSuffix(nsMedia, "/")
+}
+
+// parseRSS parses RSS 0.9x, 1.0 and 2.0 documents
+func parseRSS(data []byte) (Feed, []feedItem, error) {
+
+	var doc rssParseDocument
+	if err := newXMLDecoder(data).Decode(&doc); err != nil {
+		return Feed{}, nil, err
+	}
+
+	ch := doc.Channel
+	feed := Feed{
+		Title:       pickText(ch.Titles, rssSpaces...),
+		Link:        pickText(ch.Links, rssSpaces...),
+		Description: pickText(ch.Descriptions, rssSpaces...),
+		Language:    pickText(ch.Languages, append(rssSpaces, nsDC)...),
+		Copyright:   ch.Copyright,
+		Generator:   ch.Generator,
+	}
+
+	entries := append(ch.Items, doc.Items...)
+	items := make([]feedItem, 0, len(entries))
+
+	for _, entry := range entries {
+		items = append(items, entry.item())
+	}
+
+	return feed, items, nil
+}
+
+func (entry rssParseItem) item() feedItem {
+
+	item := feedItem{
+		title:     pickText(entry.Titles, rssSpaces...),
+		link:      pickText(entry.Links, rssSpaces...),
+		published: feedTime(entry.PubDate, pickText(entry.Dates, nsDC)),
+		markup:    pickText(entry.Encoded, nsContent),
+	}
+
+	description := pickText(entry.Descriptions, rssSpaces...)
+	if item.markup == "" {
+		item.markup = description
+	} else {
+		item.summary = description
+	}
+
+	// guid is a permalink by default
+	if guid := strings.TrimSpace(entry.GUID.Value); item.link == "" && guid != "" && entry.GUID.IsPermaLink != "false" {
+		if u, err := url.Parse(guid); err == nil && u.Scheme != "" {
+			item.link = guid
+		}
+	}
+
+	for _, creator := range entry.Creators {
+		if creator.XMLName.Space == nsDC {
+			item.authors = append(item.authors, creator.Value)
+		}
+	}
+
+	if len(item.authors) == 0 {
+		if author := pickText(entry.Authors, rssSpaces...); author != "" {
+			item.authors = append(item.authors, feedAuthor(author))
+		}
+	}
+
+	for _, category := range entry.Categories {
+		if category.XMLName.Space == "" || category.XMLName.Space == rssSpaces[1] {
+			item.tags = append(item.tags, category.Value)
+		}
+	}
+
+	for _, subject := range entry.Subjects {
+		if subject.XMLName.Space == nsDC {
+			item.tags = append(item.tags, subject.Value)
+		}
+	}
+
+	for _, enclosure := range entry.Enclosures {
+		size, _ := strconv.Atoi(strings.TrimSpace(enclosure.Length))
+		item.media = append(item.media, feedMedia{url: strings.TrimSpace(enclosure.URL), typ: enclosure.Type, size: size})
+	}
+
+	item.media = append(item.media, rssMedia(entry.Media, "")...)
+
+	for _, group := range entry.Groups {
+		if !isMediaRSS(group.XMLName) {
+			continue
+		}
+		// the title and the description of the group apply to its media without own ones
+		title, description := mediaRSSText(group.Titles), mediaRSSText(group.Descriptions)
+		for _, m := range append(rssMedia(group.Media, ""), rssMedia(group.Thumbnails, "image")...) {
+			if m.title == "" {
+				m.title = title
+			}
+			if m.description == "" {
+				m.description = description
+			}
+			item.media = append(item.media, m)
+		}
+	}
+
+	item.media = append(item.media, rssMedia(entry.Thumbnails, "image")...)
+
+	return item
+}
+
+// rssMedia converts media:content and media:thumbnail elements
+func rssMedia(elems []rssParseMedia, medium string) []feedMedia {
+
+	media := make([]feedMedia, 0, len(elems))
+
+	for _, elem := range elems {
+
+		if !isMediaRSS(elem.XMLName) {
+			continue
+		}
+
+		m := feedMedia{
+			url:    strings.TrimSpace(elem.URL),
+			typ:    elem.Type,
+			medium: elem.Medium,
+		}
+
+		if medium != "" {
+			m.medium = medium
+		}
+
+		m.width, _ = strconv.Atoi(elem.Width)
+		m.height, _ = strconv.Atoi(elem.Height)
+		m.size, _ = strconv.Atoi(elem.FileSize)
+		m.duration, _ = strconv.Atoi(elem.Duration)
+
+		m.title = mediaRSSText(elem.Titles)
+		m.description = mediaRSSText(elem.Descriptions)
+
+		media = append(media, m)
+	}
+
+	return media
+}
+
+// mediaRSSText returns the last media:title or media:description of the elements
+func mediaRSSText(elems []xmlText) string {
+
+	var text string
+	for _, elem := range elems {
+		if isMediaRSS(elem.XMLName) {
+			text = strings.TrimSpace(elem.Value)
+		}
+	}
+
+	return text
+}
@@ -0,0 +1,370 @@
+package article
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Policy is the allowlist of HTML elements, attributes, URL schemes and iframe hosts.
+// Everything not allowed is removed by Sanitize: disallowed elements are unwrapped keeping their text,
+// scripts, styles, objects and iframes of unknown hosts are removed with the content.
+// Event handlers (on*) and style attributes are never allowed.
+type Policy struct {
+	// Name of the policy, recorded as the tag of the report issues, e.g. article.
+	Name string
+	// Elements maps the allowed element to its allowed attributes, e.g. "a": {"href", "title"}.
+	Elements map[string][]string
+	// Attributes are allowed on every allowed element, e.g. lang.
+	Attributes []string
+	// Schemes are the allowed schemes of the URL attributes, relative URLs are always allowed.
+	Schemes []string
+	// IframeHosts are the trusted hosts of the iframe src, subdomains included, e.g. youtube.com.
+	// An iframe is kept only if it is in Elements and its src is https URL of a trusted host.
+	IframeHosts []string
+}
+
+// StrictTextPolicy removes every element and keeps only the text.
+func StrictTextPolicy() *Policy {
+	return &Policy{
+		Name:     "strict",
+		Elements: map[string][]string{},
+	}
+}
+
+// TrustedEmbedPolicy keeps only iframes of the trusted video and social platforms:
+// YouTube, Vimeo, Dailymotion, TikTok, Rutube and Twitter.
+func TrustedEmbedPolicy() *Policy {
+	return &Policy{
+		Name: "embed",
+		Elements: map[string][]string{
+			"iframe": iframeAttributes,
+		},
+		Schemes:     []string{"https"},
+		IframeHosts: trustedIframeHosts,
+	}
+}
+
+// SocialEmbedPolicy keeps the embed code of the social posts rendered by the platform widget scripts:
+// blockquote.twitter-tweet, blockquote.instagram-media, blockquote.mastodon-embed with their text and links,
+// and the iframes of TrustedEmbedPolicy and Telegram. The widget scripts are removed, the page includes them.
+func SocialEmbedPolicy() *Policy {
+	return &Policy{
+		Name: "post",
+		Elements: map[string][]string{
+			"blockquote": {"class", "cite", "data-instgrm-permalink", "data-instgrm-version", "data-embed-url", "data-lang", "data-theme"},
+			"iframe":     append([]string{"class"}, iframeAttributes...),
+
+			"p": nil, "br": nil, "a": {"href"}, "time": {"datetime"}, "img": {"src", "alt"},
+		},
+		Attributes:  []string{"lang", "dir"},
+		Schemes:     []string{"https"},
+		IframeHosts: append([]string{"t.me"}, trustedIframeHosts...),
+	}
+}
+
+// ArticlePolicy keeps the elements of the article body: paragraphs, headings, lists, links, images,
+// figures, tables, quotes, code and the iframes of TrustedEmbedPolicy.
+func ArticlePolicy() *Policy {
+	return &Policy{
+		Name: "article",
+		Elements: map[string][]string{
+			"p": nil, "br": nil, "hr": nil, "div": nil, "span": nil,
+			"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+			"b": nil, "strong": nil, "i": nil, "em": nil, "u": nil, "s": nil, "del": nil, "ins": nil,
+			"mark": nil, "small": nil, "sub": nil, "sup": nil, "abbr": {"title"}, "cite": nil, "q": {"cite"},
+			"code": nil, "pre": nil, "kbd": nil, "time": {"datetime"},
+			"ul": nil, "ol": {"start", "reversed"}, "li": nil, "dl": nil, "dt": nil, "dd": nil,
+			"blockquote": {"cite", "class"}, "footer": nil,
+			"a":          {"href", "title", "rel"},
+			"img":        {"src", "srcset", "sizes", "alt", "title", "width", "height"},
+			"picture":    nil,
+			"source":     {"src", "srcset", "sizes", "type", "media"},
+			"video":      {"src", "poster", "controls", "width", "height"},
+			"audio":      {"src", "controls"},
+			"figure":     nil,
+			"figcaption": nil,
+			"table":      nil, "thead": nil, "tbody": nil, "tfoot": nil, "tr": nil,
+			"th": {"colspan", "rowspan", "scope"}, "td": {"colspan", "rowspan"}, "caption": nil,
+			"iframe": iframeAttributes,
+		},
+		Attributes:  []string{"lang", "dir"},
+		Schemes:     []string{"http", "https", "mailto", "tel"},
+		IframeHosts: trustedIframeHosts,
+	}
+}
+
+// iframeAttributes are the allowed attributes of the trusted iframes
+var iframeAttributes = []string{"src", "width", "height", "title", "allow", "allowfullscreen", "frameborder", "loading"}
+
+// trustedIframeHosts are the hosts of YouTube, Vimeo, Dailymotion, TikTok, Rutube and Twitter embeds
+var trustedIframeHosts = []string{
+	"youtube.com", "youtube-nocookie.com", "player.vimeo.com", "dailymotion.com", "tiktok.com", "rutube.ru",
+	"platform.twitter.com",
+}
+
+// MarkupPolicy sanitizes Article.Markup during Normalize, if the markup is HTML.
+// Markdown without HTML elements is kept as is, see isHTML.
+// Set to nil to keep the markup as is.
+var MarkupPolicy = ArticlePolicy()
+
+// EmbedPolicy sanitizes Video.Embed during Normalize.
+// Set to nil to keep the embed code as is.
+var EmbedPolicy = TrustedEmbedPolicy()
+
+// QuoteEmbedPolicy sanitizes Quote.Embed during Normalize.
+// Set to nil to keep the embed code as is.
+var QuoteEmbedPolicy = SocialEmbedPolicy()
+
+// dropTags are removed with the content, if not allowed by the policy
+var dropTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+	atom.Iframe: true, atom.Frame: true, atom.Frameset: true, atom.Object: true, atom.Embed: true, atom.Applet: true,
+	atom.Svg: true, atom.Math: true, atom.Head: true, atom.Title: true, atom.Textarea: true, atom.Select: true,
+	atom.Xmp: true, atom.Noembed: true, atom.Noframes: true, atom.Plaintext: true,
+}
+
+// voidTags of dropTags have no end tag, they are removed alone
+var voidTags = map[atom.Atom]bool{
+	atom.Embed: true, atom.Frame: true,
+}
+
+// rawTags of dropTags hold the raw text up to the end tag, even if self-closing, e.g. <iframe/>
+var rawTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Iframe: true, atom.Title: true,
+	atom.Textarea: true, atom.Xmp: true, atom.Noembed: true, atom.Noframes: true, atom.Plaintext: true,
+}
+
+// urlAttributes hold URLs checked against the policy schemes
+var urlAttributes = map[string]bool{
+	"href": true, "src": true, "poster": true, "cite": true, "action": true, "background": true,
+}
+
+// Sanitize removes everything not allowed by the Policy from the HTML.
+// It returns the sanitized HTML and the list of removed elements, attributes and URLs,
+// e.g. <script>, onclick, javascript:alert(1).
+// The text is escaped, so the special characters of Markdown become entities, e.g. > is &gt;.
+func (p *Policy) Sanitize(markup string) (string, []string) {
+
+	var b strings.Builder
+	var removed []string
+
+	z := html.NewTokenizer(strings.NewReader(markup))
+
+	// drop is the element removed with the content and depth is its nesting level
+	var drop atom.Atom
+	depth := 0
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return b.String(), removed
+		}
+
+		token := z.Token()
+
+		if depth > 0 {
+			switch {
+			case token.DataAtom != drop:
+			case tt == html.StartTagToken:
+				depth++
+			case tt == html.EndTagToken:
+				depth--
+			}
+			continue
+		}
+
+		switch tt {
+		case html.TextToken:
+			// the text is escaped, the raw text of the removed wrapper could be markup, e.g. <xmp>
+			b.WriteString(html.EscapeString(token.Data))
+
+		case html.CommentToken, html.DoctypeToken:
+			removed = append(removed, token.String())
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if !p.allowed(token) {
+				removed = append(removed, "<"+token.Data+">")
+				if p.dropped(token.DataAtom, tt) {
+					drop, depth = token.DataAtom, 1
+				}
+				continue
+			}
+			token.Attr, removed = p.attributes(token, removed)
+			b.WriteString(token.String())
+
+		case html.EndTagToken:
+			if _, ok := p.Elements[token.Data]; ok {
+				b.WriteString(token.String())
+			}
+		}
+	}
+}
+
+// dropped is true if the removed element starts the drop of the content up to its end tag.
+// Void and self-closing elements are removed alone, except the raw text ones.
+func (p *Policy) dropped(a atom.Atom, tt html.TokenType) bool {
+	switch {
+	case !dropTags[a], voidTags[a]:
+		return false
+	case tt == html.SelfClosingTagToken:
+		return rawTags[a]
+	}
+	return true
+}
+
+// allowed checks the element and the iframe source
+func (p *Policy) allowed(token html.Token) bool {
+
+	if _, ok := p.Elements[token.Data]; !ok {
+		return false
+	}
+
+	if token.DataAtom != atom.Iframe {
+		return true
+	}
+
+	for _, attr := range token.Attr {
+		if attr.Key == "src" {
+			return p.trustedIframe(attr.Val)
+		}
+	}
+
+	return false
+}
+
+// trustedIframe is true for https URL of the trusted host or its subdomain
+func (p *Policy) trustedIframe(src string) bool {
+
+	src = strings.TrimSpace(src)
+	if strings.HasPrefix(src, "//") {
+		src = "https:" + src
+	}
+
+	u, err := url.Parse(src)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, trusted := range p.IframeHosts {
+		if host == trusted || strings.HasSuffix(host, "."+trusted) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// attributes filters the element attributes and appends the removed ones
+func (p *Policy) attributes(token html.Token, removed []string) ([]html.Attribute, []string) {
+
+	allowed := make(map[string]bool)
+	for _, key := range p.Elements[token.Data] {
+		allowed[key] = true
+	}
+	for _, key := range p.Attributes {
+		allowed[key] = true
+	}
+
+	attrs := make([]html.Attribute, 0, len(token.Attr))
+
+	for _, attr := range token.Attr {
+
+		key := strings.ToLower(attr.Key)
+
+		switch {
+		case attr.Namespace != "", !allowed[key], strings.HasPrefix(key, "on"), key == "style":
+			removed = append(removed, key)
+			continue
+		case urlAttributes[key] && !p.safeURL(attr.Val):
+			removed = append(removed, attr.Val)
+			continue
+		case key == "srcset" && !p.safeSrcset(attr.Val):
+			removed = append(removed, attr.Val)
+			continue
+		}
+
+		attrs = append(attrs, html.Attribute{Key: key, Val: attr.Val})
+	}
+
+	return attrs, removed
+}
+
+// safeURL checks the URL scheme, relative URLs are safe.
+// Whitespace and control characters are removed first, browsers ignore them in the scheme, e.g. "java\tscript:".
+func (p *Policy) safeURL(raw string) bool {
+
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, raw)
+
+	u, err := url.Parse(cleaned)
+	if err != nil {
+		return false
+	}
+
+	if u.Scheme == "" {
+		return true
+	}
+
+	for _, scheme := range p.Schemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// safeSrcset checks every candidate URL of the srcset, e.g. "a.jpg 1x, b.jpg 2x"
+func (p *Policy) safeSrcset(srcset string) bool {
+	for _, candidate := range strings.Split(srcset, ",") {
+		fields := strings.Fields(candidate)
+		if len(fields) > 0 && !p.safeURL(fields[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isHTML is true if the markup contains an HTML element, a comment or a doctype.
+// Markdown special characters and autolinks are not elements, e.g. a<b or <https://example.com>.
+func isHTML(markup string) bool {
+
+	z := html.NewTokenizer(strings.NewReader(markup))
+
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return false
+		case html.CommentToken, html.DoctypeToken:
+			return true
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			if z.Token().DataAtom != 0 {
+				return true
+			}
+		}
+	}
+}
+
+// sanitize sanitizes the value with the policy and records every removal to the report.
+// The nil policy keeps the value as is.
+func (p *Policy) sanitize(report *NormalizeReport, field, value string) string {
+
+	if p == nil || value == "" {
+		return value
+	}
+
+	sanitized, removed := p.Sanitize(value)
+	for _, item := range removed {
+		report.Add(field, p.Name, item, ActionSanitized)
+	}
+
+	return strings.TrimSpace(sanitized)
+}
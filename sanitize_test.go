@@ -0,0 +1,217 @@
+package article_test
+
+import (
+	"testing"
+
+	"github.com/editorpost/article"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArticlePolicy_Sanitize(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		markup  string
+		want    string
+		removed []string
+	}{
+		{
+			name:   "allowed",
+			markup: `<p>Hello <a href="https://example.com" title="x">world</a></p>`,
+			want:   `<p>Hello <a href="https://example.com" title="x">world</a></p>`,
+		},
+		{
+			name:    "script",
+			markup:  `<p>Text</p><script>alert("<p>x</p>")</script>`,
+			want:    `<p>Text</p>`,
+			removed: []string{"<script>"},
+		},
+		{
+			name:    "event handler and style",
+			markup:  `<p onclick="alert(1)" style="color:red">Text</p>`,
+			want:    `<p>Text</p>`,
+			removed: []string{"onclick", "style"},
+		},
+		{
+			name:    "javascript url",
+			markup:  `<a href="java&#09;script:alert(1)">Link</a>`,
+			want:    `<a>Link</a>`,
+			removed: []string{"java\tscript:alert(1)"},
+		},
+		{
+			name:    "srcset",
+			markup:  `<img src="a.jpg" srcset="a.jpg 1x, javascript:alert(1) 2x">`,
+			want:    `<img src="a.jpg">`,
+			removed: []string{"a.jpg 1x, javascript:alert(1) 2x"},
+		},
+		{
+			name:    "unknown element unwrapped",
+			markup:  `<custom><b>Bold</b></custom>`,
+			want:    `<b>Bold</b>`,
+			removed: []string{"<custom>"},
+		},
+		{
+			name:    "trusted iframe",
+			markup:  `<iframe src="https://www.youtube.com/embed/abc" width="560" onload="x()"></iframe>`,
+			want:    `<iframe src="https://www.youtube.com/embed/abc" width="560"></iframe>`,
+			removed: []string{"onload"},
+		},
+		{
+			name:    "unknown iframe",
+			markup:  `<p>A</p><iframe src="https://evil.example.com/"></iframe><p>B</p>`,
+			want:    `<p>A</p><p>B</p>`,
+			removed: []string{"<iframe>"},
+		},
+		{
+			name:    "nested object",
+			markup:  `<object><object><p>x</p></object><p>y</p></object><p>z</p>`,
+			want:    `<p>z</p>`,
+			removed: []string{"<object>"},
+		},
+		{
+			name:    "comment",
+			markup:  `<p>A</p><!--[if IE]><script></script><![endif]-->`,
+			want:    `<p>A</p>`,
+			removed: []string{"<!--[if IE]><script></script><![endif]-->"},
+		},
+		{
+			name:   "markdown",
+			markup: "# Title\n\n> quote & *text* 1 < 2",
+			want:   "# Title\n\n&gt; quote &amp; *text* 1 &lt; 2",
+		},
+		{
+			name:    "xmp",
+			markup:  `<p>A</p><xmp><script>alert(1)</script></xmp>`,
+			want:    `<p>A</p>`,
+			removed: []string{"<xmp>"},
+		},
+		{
+			name:    "noembed",
+			markup:  `<p>A</p><noembed><script>alert(1)</script></noembed>`,
+			want:    `<p>A</p>`,
+			removed: []string{"<noembed>"},
+		},
+		{
+			name:    "noframes",
+			markup:  `<p>A</p><noframes><script>alert(1)</script></noframes>`,
+			want:    `<p>A</p>`,
+			removed: []string{"<noframes>"},
+		},
+		{
+			name:    "plaintext",
+			markup:  `<p>A</p><plaintext><script>alert(1)</script>`,
+			want:    `<p>A</p>`,
+			removed: []string{"<plaintext>"},
+		},
+		{
+			name:    "embed",
+			markup:  `<p>before</p><embed src="x.swf"><p>after</p>`,
+			want:    `<p>before</p><p>after</p>`,
+			removed: []string{"<embed>"},
+		},
+		{
+			name:    "frame",
+			markup:  `<p>before</p><frame src="x.html"><p>after</p>`,
+			want:    `<p>before</p><p>after</p>`,
+			removed: []string{"<frame>"},
+		},
+		{
+			name:    "self-closing iframe",
+			markup:  `<p>before</p><iframe src="https://evil.example.com/"/></iframe><p>after</p>`,
+			want:    `<p>before</p><p>after</p>`,
+			removed: []string{"<iframe>"},
+		},
+		{
+			name:    "self-closing object",
+			markup:  `<p>before</p><object data="x.swf"/><p>after</p>`,
+			want:    `<p>before</p><p>after</p>`,
+			removed: []string{"<object>"},
+		},
+		{
+			name:   "escaped text",
+			markup: `<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>`,
+			want:   `<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, removed := article.ArticlePolicy().Sanitize(tt.markup)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.removed, removed)
+		})
+	}
+}
+
+func TestStrictTextPolicy_Sanitize(t *testing.T) {
+	got, _ := article.StrictTextPolicy().Sanitize(`<p>Hello <b>world</b></p><script>x</script>`)
+	assert.Equal(t, "Hello world", got)
+}
+
+func TestTrustedEmbedPolicy_Sanitize(t *testing.T) {
+
+	policy := article.TrustedEmbedPolicy()
+
+	got, _ := policy.Sanitize(`<iframe src="https://player.vimeo.com/video/1" allowfullscreen></iframe><script src="x.js"></script>`)
+	assert.Equal(t, `<iframe src="https://player.vimeo.com/video/1" allowfullscreen=""></iframe>`, got)
+
+	got, _ = policy.Sanitize(`<iframe src="http://www.youtube.com/embed/abc"></iframe>`)
+	assert.Empty(t, got)
+
+	got, _ = policy.Sanitize(`<iframe src="https://youtube.com.evil.com/embed/abc"></iframe>`)
+	assert.Empty(t, got)
+}
+
+func TestArticle_NormalizeSanitize(t *testing.T) {
+
+	a := article.NewArticle()
+	a.Title = "Title"
+	a.Text = "Text"
+	a.Markup = `<p onclick="x()">Text</p><script>alert(1)</script>`
+	video := article.NewVideo("https://example.com/video.mp4")
+	video.Embed = `<iframe src="https://evil.example.com/"></iframe>`
+	a.Videos.Add(video)
+
+	report, err := a.NormalizeWithReport()
+	require.NoError(t, err)
+
+	assert.Equal(t, "<p>Text</p>", a.Markup)
+	assert.Empty(t, a.Videos.Slice()[0].Embed)
+
+	sanitized := report.Filter(article.ActionSanitized)
+	require.Len(t, sanitized, 3)
+	assert.Equal(t, "Article.Markup", sanitized[0].Field)
+	assert.Equal(t, "article", sanitized[0].Tag)
+	assert.Equal(t, "Article.Videos[0].Embed", sanitized[2].Field)
+}
+
+func TestArticle_NormalizeSanitizeMarkdown(t *testing.T) {
+
+	markdown := "# Title\n\nMarkdown > quote and `a<b` & R&D, see <https://example.com>"
+
+	a := article.NewArticle()
+	a.Title = "Title"
+	a.Text = "Text"
+	a.Markup = markdown
+
+	report, err := a.NormalizeWithReport()
+	require.NoError(t, err)
+
+	assert.Equal(t, markdown, a.Markup)
+	assert.Empty(t, report.Filter(article.ActionSanitized))
+}
+
+func TestArticle_NormalizeSanitizeDisabled(t *testing.T) {
+
+	defer func(policy *article.Policy) { article.MarkupPolicy = policy }(article.MarkupPolicy)
+	article.MarkupPolicy = nil
+
+	a := article.NewArticle()
+	a.Title = "Title"
+	a.Text = "Text"
+	a.Markup = `<p onclick="x()">Text</p>`
+
+	require.NoError(t, a.Normalize())
+	assert.Equal(t, `<p onclick="x()">Text</p>`, a.Markup)
+}
@@ -1,9 +1,9 @@
 package article
 
 import (
-	"github.com/go-playground/validator/v10"
-	"github.com/google/uuid"
 	"log/slog"
+
+	"github.com/google/uuid"
 )
 
 // Social represents a social media links.
@@ -13,13 +13,17 @@ type Social struct {
 	// It is stable enough to be used as a key in a storage system.
 	ID string `json:"id" validate:"required,max=36"`
 
-	// Platform is the platform of the social profile (e.g., Twitter, Facebook).
-	// This field is required and should be between 1 and 50 characters long.
-	Platform string `json:"platform" validate:"max=255"`
+	// Platform is the platform of the social profile (e.g., x, facebook).
+	// The known platforms are canonicalized from the URL or the name during normalization, see SocialPlatforms.
+	Platform string `json:"platform" validate:"limit=Name"`
+
+	// Handle is the account handle or ID at the platform, e.g. jack.
+	// This field is optional and extracted from the URL of the known platform.
+	Handle string `json:"handle,omitempty" validate:"limit=Name"`
 
 	// URL is the URL of the social profile.
 	// This field is required and should be a valid URL.
-	URL string `json:"url" validate:"required,url,max=4096"`
+	URL string `json:"url" validate:"required,url,limit=URL"`
 }
 
 //goland:noinspection GoUnusedExportedFunction
@@ -31,44 +35,83 @@ func NewSocial(platform, url string) *Social {
 	}
 }
 
+// GetID returns the ID of the Social.
+func (s *Social) GetID() string {
+	return s.ID
+}
+
 // Normalize validates and trims the fields of the Social.
+// The Social is reset to the zero value if it is invalid.
 func (s *Social) Normalize() {
+	if err := s.normalize(nil, "Social"); err != nil {
+		*s = Social{}
+	}
+}
+
+// normalize trims the fields, records the changes to the report and returns the validation error.
+func (s *Social) normalize(report *NormalizeReport, path string) error {
 
 	if s.ID == "" {
 		s.ID = uuid.New().String()
+		report.fallback(path+".ID", "")
 	}
 
-	s.ID = TrimToMaxLen(s.ID, 36)
-	s.Platform = TrimToMaxLen(s.Platform, 255)
-	s.URL = TrimToMaxLen(s.URL, 4096)
+	s.ID = report.trim(path+".ID", s.ID, 36)
+	s.Platform = report.trim(path+".Platform", s.Platform, report.limits().Name)
+	s.Handle = report.trim(path+".Handle", s.Handle, report.limits().Name)
+	s.URL = URLCanonicalizer.canonical(report, path+".URL", report.trim(path+".URL", s.URL, report.limits().URL), report.baseURL())
+	s.detect(report, path)
 
-	err := validate.Struct(s)
+	err := report.validate(s)
 	if err != nil {
-		for _, fieldErr := range err.(validator.ValidationErrors) {
-			slog.Debug("Validation error in Social", slog.String("field", fieldErr.Namespace()), slog.String("error", fieldErr.Tag()))
-			*s = Social{}
-		}
+		slog.Debug("Validation error in Social", slog.String("path", path), slog.String("error", err.Error()))
 	}
+
+	return err
 }
 
 // Map converts the Social struct to a map[string]any.
+// The handle is included only if set.
 func (s *Social) Map() map[string]any {
-	return map[string]any{
+
+	m := map[string]any{
 		"id":       s.ID,
 		"platform": s.Platform,
 		"url":      s.URL,
 	}
+
+	if s.Handle != "" {
+		m["handle"] = s.Handle
+	}
+
+	return m
 }
 
 // NewSocialProfileFromMap creates a Social from a map[string]any, validates it, and returns a pointer to the Social or an error.
 func NewSocialProfileFromMap(m map[string]any) (*Social, error) {
+	return socialProfileFromMap(newMapReader(m))
+}
+
+// NewSocialProfileFromMapStrict creates a Social from a map[string]any like NewSocialProfileFromMap,
+// but fails with MapErrors listing every key holding a value of unexpected type.
+func NewSocialProfileFromMapStrict(m map[string]any) (*Social, error) {
+	return socialProfileFromMap(newStrictMapReader(m))
+}
+
+// socialProfileFromMap reads the Social with the map reader, validates it, and returns a pointer to the Social or an error.
+func socialProfileFromMap(r *mapReader) (*Social, error) {
 	profile := &Social{
-		ID:       StringFromMap(m, "id"),
-		Platform: StringFromMap(m, "platform"),
-		URL:      StringFromMap(m, "url"),
+		ID:       r.string("id"),
+		Platform: r.string("platform"),
+		Handle:   r.string("handle"),
+		URL:      r.string("url"),
+	}
+
+	if err := r.failed(); err != nil {
+		return nil, err
 	}
 
-	err := validate.Struct(profile)
+	err := r.validate(profile)
 	if err != nil {
 		return nil, err
 	}
@@ -1,139 +1,91 @@
 package article
 
-import (
-	"encoding/json"
-	"errors"
-	"log"
-)
-
 // Socials represents a collection of Social pointers
 type Socials struct {
-	items []*Social
+	Collection[*Social]
 }
 
-// NewSocials creates a collection, skips invalid social items, and logs errors
+// NewSocials creates a collection, skips invalid items, and logs errors
 func NewSocials(profiles ...*Social) *Socials {
-	var validProfiles []*Social
-	for _, profile := range profiles {
-		if err := validate.Struct(profile); err == nil {
-			validProfiles = append(validProfiles, profile)
-		} else {
-			log.Printf("Invalid social profile skipped: %+v, error: %v", profile, err)
-		}
-	}
-	return &Socials{items: validProfiles}
+	return &Socials{Collection: *NewCollection(profiles...)}
 }
 
-// NewSocialsStrict creates a collection and validates every social profile
+// NewSocialsStrict creates a collection and validates every item
 func NewSocialsStrict(profiles ...*Social) (*Socials, error) {
-	for _, profile := range profiles {
-		if err := validate.Struct(profile); err != nil {
-			return nil, errors.New("invalid social profile: " + err.Error())
-		}
-	}
-	return &Socials{items: profiles}, nil
-}
 
-// Get returns the social profile by ID
-func (list *Socials) Get(id string) (*Social, bool) {
-	for _, profile := range list.items {
-		if profile.ID == id {
-			return profile, true
-		}
+	list, err := NewCollectionStrict(profiles...)
+	if err != nil {
+		return nil, err
 	}
-	return nil, false
-}
 
-// Slice returns a slice of all social items
-func (list *Socials) Slice() []*Social {
-	return list.items
+	return &Socials{Collection: *list}, nil
 }
 
-// Add adds social items to the collection
+// Add adds items to the collection, invalid items are skipped
 func (list *Socials) Add(profiles ...*Social) *Socials {
-	for _, profile := range profiles {
-		if err := validate.Struct(profile); err == nil {
-			list.items = append(list.items, profile)
-		} else {
-			log.Printf("Invalid social profile skipped: %+v, error: %v", profile, err)
-		}
-	}
+	list.Collection.Add(profiles...)
 	return list
 }
 
-// Remove removes social items by ID
+// Remove removes items by ID
 func (list *Socials) Remove(ids ...string) *Socials {
-	idSet := make(map[string]struct{}, len(ids))
-	for _, id := range ids {
-		idSet[id] = struct{}{}
-	}
-
-	var filteredProfiles []*Social
-	for _, profile := range list.items {
-		if _, found := idSet[profile.ID]; !found {
-			filteredProfiles = append(filteredProfiles, profile)
-		}
-	}
-
-	list.items = filteredProfiles
+	list.Collection.Remove(ids...)
 	return list
 }
 
-// IDs returns a slice of all social profile IDs
-func (list *Socials) IDs() []string {
-	ids := make([]string, len(list.items))
-	for idx, profile := range list.items {
-		ids[idx] = profile.ID
-	}
-	return ids
+// Filter returns a new Socials collection filtered by the provided functions
+func (list *Socials) Filter(fns ...func(*Social) bool) *Socials {
+	return &Socials{Collection: *list.Collection.Filter(fns...)}
 }
 
-// Len returns the number of social items
-func (list *Socials) Len() int {
-	return len(list.items)
+// Sort sorts the profiles in place by the comparison function, see Collection.Sort
+func (list *Socials) Sort(cmp func(a, b *Social) int) *Socials {
+	list.Collection.Sort(cmp)
+	return list
 }
 
-// Filter returns a new Socials collection filtered by the provided functions
-func (list *Socials) Filter(fns ...func(*Social) bool) *Socials {
-	var filteredProfiles []*Social
-	for _, profile := range list.items {
-		include := true
-		for _, fn := range fns {
-			if !fn(profile) {
-				include = false
-				break
-			}
-		}
-		if include {
-			filteredProfiles = append(filteredProfiles, profile)
-		}
-	}
-	return &Socials{items: filteredProfiles}
+// Dedupe removes the profiles with the duplicate ID, the first profile is kept
+func (list *Socials) Dedupe() *Socials {
+	list.Collection.Dedupe()
+	return list
 }
 
-// Normalize validates and trims the fields of all social items
+// DedupeFunc removes the profiles with the duplicate key, the first profile is kept
+func (list *Socials) DedupeFunc(key func(*Social) string) *Socials {
+	list.Collection.DedupeFunc(key)
+	return list
+}
+
+// Normalize validates and trims the fields of all profiles, invalid and duplicate profiles are removed
 func (list *Socials) Normalize() {
-	for _, profile := range list.items {
-		profile.Normalize()
-	}
+	list.normalize(nil, "")
 }
 
-// UnmarshalJSON to array of items using encoding/json
-func (list *Socials) UnmarshalJSON(data []byte) error {
+// normalize removes invalid profiles and the duplicates of the same platform and handle,
+// the first profile is kept, and records the changes to the report
+func (list *Socials) normalize(report *NormalizeReport, path string) {
 
-	// Unmarshal to a slice of Image
-	var socials []*Social
-	if err := json.Unmarshal(data, &socials); err != nil {
-		return err
+	// the duplicates are reported at their index before the invalid profiles are removed
+	index := make(map[*Social]int, len(list.items))
+	for idx, social := range list.items {
+		index[social] = idx
 	}
 
-	// Create a new Images collection
-	*list = *NewSocials(socials...)
+	list.Collection.normalize(report, path)
+	valid := list.items
 
-	return nil
-}
+	list.DedupeFunc(func(social *Social) string {
+		return social.key()
+	})
 
-// MarshalJSON from array of items using encoding/json
-func (list *Socials) MarshalJSON() ([]byte, error) {
-	return json.Marshal(list.items)
+	kept := make(map[*Social]struct{}, len(list.items))
+	for _, social := range list.items {
+		kept[social] = struct{}{}
+	}
+
+	for _, social := range valid {
+		if _, found := kept[social]; !found {
+			report.Add(itemPath(path, index[social]), "duplicate", social.URL, ActionRemoved)
+		}
+	}
 }
@@ -0,0 +1,314 @@
+package article
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// WordsPerMinute is the reading speed of the space-separated words used by Stats.
+var WordsPerMinute = 230
+
+// CharactersPerMinute is the reading speed of the CJK characters used by Stats.
+var CharactersPerMinute = 500
+
+// Stats are the reading statistics of the text, see Article.Stats.
+// The CJK characters are counted as words, the readability indices are calibrated for English
+// and are zero for the text written mostly in CJK.
+type Stats struct {
+	// Words is the number of the words, every CJK character is counted as a word.
+	Words int `json:"words"`
+	// Characters is the number of the characters except whitespace.
+	Characters int `json:"characters"`
+	// Sentences is the number of the sentences, a line without the terminal punctuation is a sentence too, e.g. heading.
+	Sentences int `json:"sentences"`
+	// Paragraphs is the number of the blocks separated by blank lines, or by line breaks if the text has no blank lines.
+	Paragraphs int `json:"paragraphs"`
+	// ReadingTime is the estimated reading time in seconds, see WordsPerMinute and CharactersPerMinute.
+	ReadingTime int `json:"reading_time"`
+	// FleschKincaid is the Flesch-Kincaid grade level, the US school grade needed to understand the text.
+	FleschKincaid float64 `json:"flesch_kincaid"`
+	// SMOG is the SMOG grade, the years of education needed to understand the text.
+	SMOG float64 `json:"smog"`
+	// ColemanLiau is the Coleman-Liau index, the US school grade based on the characters instead of the syllables.
+	ColemanLiau float64 `json:"coleman_liau"`
+}
+
+// ArticlesStats are the aggregated reading statistics of the Articles.
+type ArticlesStats struct {
+	// Articles is the number of the articles.
+	Articles int `json:"articles"`
+	// Total is the sum of the counts and the reading time,
+	// the readability indices are the averages weighted by the words.
+	Total Stats `json:"total"`
+	// Average is the average per article of the counts and the reading time rounded to integers,
+	// the readability indices are the same as in Total.
+	Average Stats `json:"average"`
+}
+
+// Stats returns the reading statistics of the Text.
+func (a *Article) Stats() *Stats {
+	return TextStats(a.Text)
+}
+
+// AssignStats assigns the reading statistics of the Text to ReadingStats,
+// so they are included in Map and JSON and refreshed by Normalize.
+func (a *Article) AssignStats() {
+	a.ReadingStats = a.Stats()
+}
+
+// ReadingMinutes returns the reading time rounded up to minutes, e.g. for "5 min read".
+func (s *Stats) ReadingMinutes() int {
+	return (s.ReadingTime + 59) / 60
+}
+
+// Map converts the Stats to a map[string]any.
+func (s *Stats) Map() map[string]any {
+	return map[string]any{
+		"words":          s.Words,
+		"characters":     s.Characters,
+		"sentences":      s.Sentences,
+		"paragraphs":     s.Paragraphs,
+		"reading_time":   s.ReadingTime,
+		"flesch_kincaid": s.FleschKincaid,
+		"smog":           s.SMOG,
+		"coleman_liau":   s.ColemanLiau,
+	}
+}
+
+// statsFromMap reads the Stats with the map reader
+func statsFromMap(r *mapReader) *Stats {
+	return &Stats{
+		Words:         r.int("words"),
+		Characters:    r.int("characters"),
+		Sentences:     r.int("sentences"),
+		Paragraphs:    r.int("paragraphs"),
+		ReadingTime:   r.int("reading_time"),
+		FleschKincaid: r.float("flesch_kincaid"),
+		SMOG:          r.float("smog"),
+		ColemanLiau:   r.float("coleman_liau"),
+	}
+}
+
+// TextStats returns the reading statistics of the plain text.
+func TextStats(text string) *Stats {
+
+	s := &Stats{}
+
+	var words []string
+	cjk := 0
+
+	// words, the CJK characters break the words and are counted separately
+	var word []rune
+	flush := func() {
+		// trailing apostrophes and hyphens are not the part of the word
+		for len(word) > 0 && isWordJoiner(word[len(word)-1]) {
+			word = word[:len(word)-1]
+		}
+		if len(word) > 0 {
+			words = append(words, string(word))
+		}
+		word = word[:0]
+	}
+
+	for _, r := range text {
+		if !unicode.IsSpace(r) {
+			s.Characters++
+		}
+		switch {
+		case isCJK(r):
+			flush()
+			cjk++
+		case unicode.IsLetter(r), unicode.IsDigit(r), unicode.Is(unicode.Mn, r):
+			word = append(word, r)
+		case isWordJoiner(r) && len(word) > 0:
+			word = append(word, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	s.Words = len(words) + cjk
+	s.Sentences = countSentences(text)
+	s.Paragraphs = countParagraphs(text)
+
+	seconds := float64(len(words))/float64(max(WordsPerMinute, 1))*60 + float64(cjk)/float64(max(CharactersPerMinute, 1))*60
+	s.ReadingTime = int(math.Ceil(seconds))
+
+	if len(words) == 0 || cjk >= len(words) {
+		return s
+	}
+
+	letters, syllables, polysyllables := 0, 0, 0
+	for _, w := range words {
+		for _, r := range w {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				letters++
+			}
+		}
+		n := countSyllables(w)
+		syllables += n
+		if n >= 3 {
+			polysyllables++
+		}
+	}
+
+	n := float64(len(words))
+	sentences := float64(max(s.Sentences, 1))
+
+	s.FleschKincaid = roundStat(0.39*n/sentences + 11.8*float64(syllables)/n - 15.59)
+	s.SMOG = roundStat(1.043*math.Sqrt(float64(polysyllables)*30/sentences) + 3.1291)
+	s.ColemanLiau = roundStat(0.0588*float64(letters)/n*100 - 0.296*sentences/n*100 - 15.8)
+
+	return s
+}
+
+// Stats returns the aggregated reading statistics of the articles.
+func (list *Articles) Stats() *ArticlesStats {
+
+	result := &ArticlesStats{Articles: len(list.items)}
+	if result.Articles == 0 {
+		return result
+	}
+
+	total := &result.Total
+	var fk, smog, cl float64
+
+	for _, article := range list.items {
+		s := article.Stats()
+		total.Words += s.Words
+		total.Characters += s.Characters
+		total.Sentences += s.Sentences
+		total.Paragraphs += s.Paragraphs
+		total.ReadingTime += s.ReadingTime
+		fk += s.FleschKincaid * float64(s.Words)
+		smog += s.SMOG * float64(s.Words)
+		cl += s.ColemanLiau * float64(s.Words)
+	}
+
+	if total.Words > 0 {
+		total.FleschKincaid = roundStat(fk / float64(total.Words))
+		total.SMOG = roundStat(smog / float64(total.Words))
+		total.ColemanLiau = roundStat(cl / float64(total.Words))
+	}
+
+	avg := func(v int) int {
+		return int(math.Round(float64(v) / float64(result.Articles)))
+	}
+
+	result.Average = Stats{
+		Words:         avg(total.Words),
+		Characters:    avg(total.Characters),
+		Sentences:     avg(total.Sentences),
+		Paragraphs:    avg(total.Paragraphs),
+		ReadingTime:   avg(total.ReadingTime),
+		FleschKincaid: total.FleschKincaid,
+		SMOG:          total.SMOG,
+		ColemanLiau:   total.ColemanLiau,
+	}
+
+	return result
+}
+
+// countSentences counts the sentences ended by the terminal punctuation or the line break
+func countSentences(text string) int {
+
+	runes := []rune(text)
+	count := 0
+	pending := false
+
+	for i, r := range runes {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			pending = true
+		case r == '\n' || isTerminal(r):
+			if !pending {
+				continue
+			}
+			// the dot between the digits or letters is not the end, e.g. 3.14 or example.com
+			if r == '.' && i+1 < len(runes) && (unicode.IsLetter(runes[i+1]) || unicode.IsDigit(runes[i+1])) {
+				continue
+			}
+			count++
+			pending = false
+		}
+	}
+
+	if pending {
+		count++
+	}
+
+	return count
+}
+
+// countParagraphs counts the blocks of the text separated by blank lines, or the lines if the text has no blank lines
+func countParagraphs(text string) int {
+
+	lines := strings.Split(text, "\n")
+	blank := false
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			blank = true
+			break
+		}
+	}
+
+	count := 0
+	inside := false
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			inside = false
+			continue
+		}
+		if !inside || !blank {
+			count++
+		}
+		inside = true
+	}
+
+	return count
+}
+
+// countSyllables estimates the syllables of the word by the groups of the vowels, the final silent e is skipped
+func countSyllables(word string) int {
+
+	word = strings.ToLower(word)
+	count := 0
+	vowel := false
+
+	for _, r := range word {
+		isVowel := strings.ContainsRune("aeiouyàáâãäåæèéêëìíîïòóôõöøùúûüýÿąęěőűůаеёиоуыэюяіїє", r)
+		if isVowel && !vowel {
+			count++
+		}
+		vowel = isVowel
+	}
+
+	if count > 1 && strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && !strings.HasSuffix(word, "ee") {
+		count--
+	}
+
+	return max(count, 1)
+}
+
+// isCJK is true for the Han, Hiragana and Katakana characters written without spaces
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// isWordJoiner is true for the apostrophes and hyphens inside the words, e.g. don't or well-known
+func isWordJoiner(r rune) bool {
+	return r == '\'' || r == '’' || r == '-'
+}
+
+// isTerminal is true for the punctuation ending the sentence, including CJK full stops
+func isTerminal(r rune) bool {
+	return strings.ContainsRune(".!?…。！？", r)
+}
+
+// roundStat rounds the index to two decimals
+func roundStat(x float64) float64 {
+	return math.Round(x*100) / 100
+}
@@ -0,0 +1,113 @@
+package article_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+// colemanLiauSample is the sample text of the Coleman-Liau paper
+const colemanLiauSample = "Existing computer programs that measure readability are based largely upon subroutines which " +
+	"estimate number of syllables, usually by counting vowels. The shortcoming in estimating syllables is that it " +
+	"necessitates keypunching the prose into the computer. There is no need to estimate syllables since word length " +
+	"in letters is a better predictor of readability than word length in syllables. Therefore, a new readability " +
+	"formula was computed that has for its predictors letters per 100 words and sentences per 100 words. Both " +
+	"predictors can be counted by an optical scanning device, and thus the formula makes it economically feasible " +
+	"for an organization such as the U.S. Office of Education to calibrate the readability of all textbooks for the " +
+	"public school system."
+
+func TestTextStats(t *testing.T) {
+
+	t.Run("simple", func(t *testing.T) {
+		s := article.TextStats("The cat sat on the mat. The dog ate the bone.\n\nIt was a sunny day! Everyone was happy, weren't they?")
+		assert.Equal(t, 21, s.Words)
+		assert.Equal(t, 79, s.Characters)
+		assert.Equal(t, 4, s.Sentences)
+		assert.Equal(t, 2, s.Paragraphs)
+		assert.Equal(t, 6, s.ReadingTime)
+		assert.Equal(t, 1, s.ReadingMinutes())
+		assert.Less(t, s.FleschKincaid, 3.0)
+	})
+
+	t.Run("complex", func(t *testing.T) {
+		s := article.TextStats(colemanLiauSample)
+		assert.Equal(t, 120, s.Words)
+		assert.Equal(t, 1, s.Paragraphs)
+		assert.InDelta(t, 14.5, s.ColemanLiau, 1)
+		assert.Greater(t, s.FleschKincaid, 12.0)
+		assert.Greater(t, s.SMOG, 12.0)
+	})
+
+	t.Run("lines", func(t *testing.T) {
+		s := article.TextStats("Heading\nThe first line, 3.14 and example.com.\nThe second line")
+		assert.Equal(t, 3, s.Sentences)
+		assert.Equal(t, 3, s.Paragraphs, "the lines are paragraphs without blank lines")
+	})
+
+	t.Run("cjk", func(t *testing.T) {
+		s := article.TextStats("今天天气很好。我们和孩子们一起去海边玩！\n\n明天下雨。")
+		assert.Equal(t, 22, s.Words, "every character is a word")
+		assert.Equal(t, 3, s.Sentences)
+		assert.Equal(t, 2, s.Paragraphs)
+		assert.Equal(t, 3, s.ReadingTime)
+		assert.Zero(t, s.FleschKincaid)
+		assert.Zero(t, s.SMOG)
+		assert.Zero(t, s.ColemanLiau)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, &article.Stats{}, article.TextStats(" \n\n "))
+	})
+}
+
+func TestArticle_Stats(t *testing.T) {
+
+	a := article.NewArticle()
+	a.Title = "Readability"
+	a.Text = colemanLiauSample
+	a.Markup = "<p>" + colemanLiauSample + "</p>"
+	a.Published = time.Now()
+
+	m := a.Map()
+	assert.NotContains(t, m, "stats", "the stats are optional")
+
+	a.AssignStats()
+	assert.Equal(t, a.Stats(), a.ReadingStats)
+
+	fromMap, err := article.NewArticleFromMap(a.Map())
+	require.NoError(t, err)
+	assert.Equal(t, a.ReadingStats, fromMap.ReadingStats)
+
+	data, err := json.Marshal(a)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"stats":{"words":120,`)
+
+	// refreshed by the normalization
+	a.Text = "Short text."
+	require.NoError(t, a.Normalize())
+	assert.Equal(t, 2, a.ReadingStats.Words)
+}
+
+func TestArticles_Stats(t *testing.T) {
+
+	first := article.NewArticle()
+	first.Text = "The cat sat on the mat."
+	second := article.NewArticle()
+	second.Text = colemanLiauSample
+
+	s := article.NewArticles(first, second).Stats()
+	assert.Equal(t, 2, s.Articles)
+	assert.Equal(t, 126, s.Total.Words)
+	assert.Equal(t, 63, s.Average.Words)
+	assert.Equal(t, 7, s.Total.Sentences)
+	assert.Equal(t, 34, s.Total.ReadingTime, "the reading time of every article is rounded up")
+	assert.Equal(t, s.Total.ColemanLiau, s.Average.ColemanLiau)
+	assert.Greater(t, s.Total.ColemanLiau, first.Stats().ColemanLiau, "the index is weighted by the words")
+
+	assert.Equal(t, &article.ArticlesStats{}, article.NewArticles().Stats())
+}
@@ -0,0 +1,93 @@
+package article
+
+import "strings"
+
+// DefaultStopwords returns the stopwords of English, Spanish, Portuguese, French, German, Italian, Dutch, Polish,
+// Swedish, Danish, Norwegian, Finnish, Turkish, Indonesian, Russian and Ukrainian by the primary language subtag.
+func DefaultStopwords() map[string][]string {
+
+	lists := map[string]string{
+		"en": `a about above after again against all also am an and any are aren't as at be because been before being
+below between both but by can can't cannot could couldn't did didn't do does doesn't doing don't down during each
+either even ever every few for from further get got had hadn't has hasn't have haven't having he he'd he'll he's her
+here here's hers herself him himself his how how's however i i'd i'll i'm i've if in into is isn't it it's its itself
+just let's like made make many may me might more most much must mustn't my myself new no nor not now of off often on
+once one only or other ought our ours ourselves out over own said same say says shall shan't she she'd she'll she's
+should shouldn't since so some still such than that that's the their theirs them themselves then there there's these
+they they'd they'll they're they've this those though through thus to too two under until up upon us very was wasn't
+we we'd we'll we're we've were weren't what what's when when's where where's whether which while who who's whom whose
+why why's will with within without won't would wouldn't yes yet you you'd you'll you're you've your yours yourself
+yourselves`,
+		"es": `a al algo algunas algunos ante antes aquel aquella aquellas aquellos aquí así aunque bajo bien cada casi como
+con contra cual cuales cuando cuanto de del desde donde dos durante e el él ella ellas ellos en entre era eran es esa
+esas ese eso esos esta está están estas este esto estos fue fueron ha había hace hacia han hasta hay la las le les lo
+los más me mi mientras muy nada ni no nos nosotros o otra otras otro otros para pero poco por porque que qué quien se
+sea según ser si sí sido sin sobre su sus también tan tanto te tiene tienen todo todos tras tu un una uno unos y ya yo`,
+		"pt": `a à ao aos aquela aquele aquilo as às até com como da das de dela dele deles depois do dos e é ela elas ele
+eles em entre era essa esse esta está estão este eu foi foram há isso isto já lhe mais mas me mesmo meu minha muito na
+nas não nem no nos nós num numa o os ou para pela pelas pelo pelos por porque qual quando que quem se sem ser seu sua
+são também te tem têm toda todas todo todos um uma umas uns você vocês`,
+		"fr": `à afin ai aie ainsi alors au aucun aussi autre aux avant avec avoir ça car ce ceci cela celle celles celui
+cependant ces cet cette ceux chaque chez comme comment d dans de depuis des donc dont du elle elles en encore entre est
+et été être eu eux fait fut il ils j je l la le les leur leurs lors lui m ma mais me même mes moi mon n ne ni nos notre
+nous on ont ou où par parce pas peu peut plus pour pourquoi qu quand que quel quelle quelles quels qui s sa sans se
+selon ses si son sont sous sur ta te tes toi ton tous tout toute toutes très tu un une vers vos votre vous y`,
+		"de": `aber alle allem allen aller alles als also am an ander andere anderen auch auf aus bei beim bin bis bist da
+damit dann das dass dein deine dem den denn der des dich die dies diese diesem diesen dieser dieses dir doch dort du
+durch ein eine einem einen einer eines er es etwa etwas euch euer für gegen gewesen hab habe haben hat hatte hatten
+hier hin ich ihm ihn ihnen ihr ihre ihrem ihren ihrer im in ins ist ja jede jedem jeden jeder jedes jetzt kann kein
+keine können könnte man manche mehr mein meine mich mir mit muss musste nach nicht nichts noch nun nur ob oder ohne
+schon sehr sein seine seinem seinen seiner seit sich sie sind so solche soll sollte sondern sowie über um und uns
+unser unter viel vom von vor war waren warum was weil welche welchem welchen welcher wenn wer werde werden wie wieder
+will wir wird wo wurde wurden zu zum zur zwar zwischen`,
+		"it": `a ad al alla alle allo anche ancora avere aveva c che chi ci come con contro cosa così cui da dal dalla dalle
+degli dei del della delle dello di dopo dove e è ed era erano essere gli ha hanno ho i il in io l la le lei li lo loro
+lui ma mai me mentre mi mia mio molto ne negli nei nel nella nelle nello noi non nostro o ogni per perché più poi
+quale quando quella quelle quelli quello questa queste questi questo se sei senza si sia siamo sono sopra su sua sue
+sui sul sulla suo suoi tra tu tutta tutte tutti tutto un una uno vi voi`,
+		"nl": `aan al alles als altijd andere ben bij daar dan dat de der deze die dit doch doen door dus een eens en er ge
+geen geweest haar had heb hebben heeft hem het hier hij hoe hun iemand iets ik in is ja je kan kon kunnen maar me meer
+men met mij mijn moet na naar niet niets nog nu of om omdat ons ook op over reeds te tegen toch toen tot u uit uw van
+veel voor want waren was wat we wel werd wezen wie wij wil worden wordt zal ze zei zelf zich zij zijn zo zonder zou`,
+		"pl": `a aby ale bardzo bez bo by być był była było były będzie co czy dla do gdy gdzie go i ich im jak jako je jego
+jej jest jeszcze jednak już ją każdy kiedy kto która które który lub ma mi może mu na nad nas nie nich nim niż o od
+oraz po pod ponieważ przed przez przy się są ta tak także tam te tego tej ten też to tu tylko tym w we więc wszystko z
+za że żeby`,
+		"sv": `alla allt att av blev bli blir de dem den denna deras dess det detta dig din du där efter ej eller en er ett
+från för ha hade han hans har henne hennes hon honom hur här i icke ingen inom inte jag ju kan kunde man med mellan men
+mig min mot mycket ni nu när någon något några och om oss på samma sedan sig sin sina ska skulle som så till under upp
+ut utan vad var vara varit vi vid vilka vilken vilket än är åt över`,
+		"da": `af alle andet andre at blev blive bliver da de dem den denne der deres det dette dig din disse du efter eller
+en end er et for fra ham han hans har havde have hende hendes her hos hun hvad hvis hvor i ikke ind jeg jer kan kunne
+man mange med meget men mig min mod ned noget nogle nu når og også om op os over på sig sin skal skulle som så til
+ud under var vi vil ville være været`,
+		"nb": `alle at av bare begge ble bli blir da de dem den denne der deres det dette di din disse du eller en ene er et
+ett etter for fra før han hans har hun hva hvem hvis hvor i ikke inn jeg kan kunne man mange med meg men mer mot mye
+ned noe noen nå og også om opp oss over på seg selv si sin skal skulle som så til under ut var ved vi vil ville være
+vært å`,
+		"fi": `ai ei eivät emme en et ette he hän ja jo joka jos jotka kanssa koska kuin kun me minä mitä mukaan mutta myös
+ne niin nyt ole olen oli olivat olla olleet ollut on ovat se sekä sen siitä sitä sinä te tai tämä tämän että vaan
+vai voi`,
+		"tr": `acaba ama ancak bazı belki ben beni benim bir biri birkaç biz bu buna bunu bunun da daha de değil diye en gibi
+göre hem her hiç için ile ise kadar ki kim mi mu mü nasıl ne neden nerede o olan olarak olduğu onu onun sen siz şey
+şu tüm ve veya ya yani`,
+		"id": `ada adalah agar akan aku anda atau bagi bahwa banyak belum bisa dalam dan dapat dari dengan di dia hanya harus
+ia ini itu jika juga kami kata ke kepada ketika lebih mereka oleh pada para saat sangat saya sebagai sebuah secara
+sedang sejak sekarang setelah sudah tak tapi telah tentang tersebut tidak untuk yang`,
+		"ru": `а без более бы был была были было быть в вам вас весь во вот все всего всех вы где да даже для до его ее ей
+если есть еще же за здесь и из или им их к как какой когда кто ли либо мне может мы на над надо наш не него нее нет
+ни них но ну о об однако он она они оно от очень по под после при с со так также такой там те тем то того тоже той
+только том ту ты у уже хотя чего чей чем что чтобы чье эта эти это этого этой этом я`,
+		"uk": `а аби але б без би був була були було бути в вам вас ви від все всі де для до є його її з за і із їй їх к
+коли котрий котра котре котрі лише мене ми мій на над не нас наш неї них ні о об однак він вона вони воно під після
+по при про саме та так також там те тим то тобто того тож той ти тільки у хоча це цей ці цього цю чи що щоб я як яка
+який які`,
+	}
+
+	stopwords := make(map[string][]string, len(lists))
+	for tag, list := range lists {
+		stopwords[tag] = strings.Fields(list)
+	}
+
+	return stopwords
+}
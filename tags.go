@@ -3,25 +3,21 @@ package article
 import (
 	"encoding/json"
 	"strings"
+	"unicode/utf8"
 )
 
+// Tags are the unique tags of the article in the canonical form, see CanonicalTag.
+// The tags are compared case-insensitively, e.g. AI and ai are the same tag.
 type Tags struct {
 	tags []string
+	// index is the position of the tag by the key of the tag
+	index map[string]int
 }
 
+// NewTags creates the collection of the tags, see Add.
 func NewTags(tags ...string) *Tags {
-
-	// skip empty tags
-	var validTags []string
-
-	for _, tag := range tags {
-		tag = strings.TrimSpace(tag)
-		if tag != "" && len(tag) <= 255 {
-			validTags = append(validTags, tag)
-		}
-	}
-
-	return &Tags{tags: validTags}
+	list := &Tags{index: map[string]int{}}
+	return list.Add(tags...)
 }
 
 // String returns a comma-separated list of tags
@@ -29,13 +25,31 @@ func (list *Tags) String() string {
 	return strings.Join(list.tags, ",")
 }
 
-// Add adds tags to the collection
+// Add adds tags to the collection in the canonical form, skips the empty, too long and duplicate tags.
 func (list *Tags) Add(tags ...string) *Tags {
+	return list.addWithLimits(nil, tags...)
+}
+
+// addWithLimits adds tags like Add, but skips the tags longer than the Tag limit of the limits, DefaultLimits if nil
+func (list *Tags) addWithLimits(limits *Limits, tags ...string) *Tags {
+
+	if limits == nil {
+		limits = DefaultLimits
+	}
+	limit := limits.resolved().Tag
+
+	// the zero Tags
+	if list.index == nil {
+		list.reindex()
+	}
+
 	for _, tag := range tags {
-		tag = strings.TrimSpace(tag)
-		if tag != "" && len(tag) <= 255 {
-			list.tags = append(list.tags, tag)
+		tag = CanonicalTag(tag)
+		if !validTag(tag, limit) || list.Contains(tag) {
+			continue
 		}
+		list.index[TagKey(tag)] = len(list.tags)
+		list.tags = append(list.tags, tag)
 	}
 	return list
 }
@@ -45,28 +59,97 @@ func (list *Tags) Slice() []string {
 	return list.tags
 }
 
-// Contains returns true if the tag exists in the collection
+// Contains returns true if the tag exists in the collection, the tag is compared by the TagKey of its canonical form
 func (list *Tags) Contains(tag string) bool {
-	for _, match := range list.tags {
-		if match == tag {
-			return true
-		}
+	_, found := list.find(tag)
+	return found
+}
+
+// Get returns the tag of the collection matching the tag, e.g. Artificial Intelligence for ai
+func (list *Tags) Get(tag string) (string, bool) {
+	if idx, found := list.find(tag); found {
+		return list.tags[idx], true
 	}
-	return false
+	return "", false
 }
 
-// Remove removes a tag from the collection
+// Remove removes a tag from the collection, the tag is compared like in Contains
 func (list *Tags) Remove(tag string) *Tags {
-	var filteredTags []string
-	for _, match := range list.tags {
-		if match != tag {
-			filteredTags = append(filteredTags, match)
-		}
+
+	idx, found := list.find(tag)
+	if !found {
+		return list
 	}
-	list.tags = filteredTags
+
+	list.tags = append(list.tags[:idx:idx], list.tags[idx+1:]...)
+	list.reindex()
+
 	return list
 }
 
+// Slugs returns the slugs of the tags, see Slug
+func (list *Tags) Slugs() []string {
+	slugs := make([]string, len(list.tags))
+	for i, tag := range list.tags {
+		slugs[i] = Slug(tag)
+	}
+	return slugs
+}
+
+// find returns the position of the tag, the index is built by the methods changing the tags,
+// so the concurrent reads are safe
+func (list *Tags) find(tag string) (int, bool) {
+	idx, found := list.index[TagKey(CanonicalTag(tag))]
+	return idx, found
+}
+
+// reindex rebuilds the index of the tags
+func (list *Tags) reindex() {
+	list.index = make(map[string]int, len(list.tags))
+	for idx, tag := range list.tags {
+		list.index[TagKey(tag)] = idx
+	}
+}
+
+// normalize brings the tags to the canonical form, removes the duplicates and the tags longer than the limit,
+// and records the changes to the report
+func (list *Tags) normalize(report *NormalizeReport, path string) {
+
+	if list == nil {
+		return
+	}
+
+	limit := report.limits().Tag
+	seen := map[string]bool{}
+
+	var valid []string
+	for idx, tag := range list.tags {
+		if canonical := CanonicalTag(tag); canonical != tag {
+			report.Add(itemPath(path, idx), "tag", tag, ActionCanonicalized)
+			tag = canonical
+		}
+		if !validTag(tag, limit) {
+			report.Add(itemPath(path, idx), "limit", tag, ActionRemoved)
+			continue
+		}
+		key := TagKey(tag)
+		if seen[key] {
+			report.Add(itemPath(path, idx), "unique", tag, ActionRemoved)
+			continue
+		}
+		seen[key] = true
+		valid = append(valid, tag)
+	}
+
+	list.tags = valid
+	list.reindex()
+}
+
+// validTag is true for the non-empty tag not longer than the limit in runes
+func validTag(tag string, limit int) bool {
+	return tag != "" && utf8.RuneCountInString(tag) <= limit
+}
+
 // Len returns the number of tags
 func (list *Tags) Len() int {
 	return len(list.tags)
@@ -81,7 +164,7 @@ func (list *Tags) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	// Create a new Images collection
+	// Create a new Tags collection with the index
 	*list = *NewTags(tags...)
 
 	return nil
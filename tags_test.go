@@ -0,0 +1,102 @@
+package article_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+func TestTags_Dedupe(t *testing.T) {
+
+	tags := article.NewTags("AI", " ai ", "#Robots", "robots", "Straße", "STRASSE", "ｆｕｌｌ　width", "")
+	assert.Equal(t, []string{"AI", "Robots", "Straße", "full width"}, tags.Slice())
+
+	assert.True(t, tags.Contains("ai"))
+	assert.True(t, tags.Contains("strasse"))
+	assert.False(t, tags.Contains("robot"))
+
+	tag, ok := tags.Get("FULL WIDTH")
+	assert.True(t, ok)
+	assert.Equal(t, "full width", tag)
+
+	tags.Remove("robots")
+	assert.Equal(t, []string{"AI", "Straße", "full width"}, tags.Slice())
+	assert.True(t, tags.Contains("full width"), "the index is rebuilt")
+
+	tags.Add("Robots")
+	assert.Equal(t, 4, tags.Len())
+	assert.Equal(t, []string{"ai", "straße", "full-width", "robots"}, tags.Slugs())
+
+	var fromJSON article.Tags
+	require.NoError(t, json.Unmarshal([]byte(`["News","news","NEWS"]`), &fromJSON))
+	assert.Equal(t, []string{"News"}, fromJSON.Slice())
+
+	// the index is built by the constructors, so the concurrent reads do not write
+	done := make(chan bool)
+	for i := 0; i < 4; i++ {
+		go func() {
+			done <- fromJSON.Contains("news")
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		assert.True(t, <-done)
+	}
+
+	var zero article.Tags
+	assert.False(t, zero.Contains("news"))
+	zero.Add("News", "news")
+	assert.Equal(t, []string{"News"}, zero.Slice())
+}
+
+func TestTags_Slash(t *testing.T) {
+
+	// the tag with the separator is the taxonomy path
+	tags := article.NewTags("AC / DC", "24/7")
+	assert.Equal(t, []string{"AC/DC", "24/7"}, tags.Slice())
+	assert.Equal(t, []string{"ac/dc", "24/7"}, tags.Slugs())
+	assert.Equal(t, article.Taxonomy{"AC", "DC"}, tags.Taxonomies()[0])
+	assert.Equal(t, []string{"AC/DC"}, tags.Within("AC"))
+}
+
+func TestTags_Synonyms(t *testing.T) {
+
+	defer func(synonyms article.TagDictionary) { article.TagSynonyms = synonyms }(article.TagSynonyms)
+	article.TagSynonyms = article.NewSynonyms().
+		Add("Artificial Intelligence", "AI", "A.I.").
+		Add("Sports/Football/EPL", "EPL", "Premier League")
+
+	tags := article.NewTags("AI", "artificial intelligence", "Premier League", "Robots")
+	assert.Equal(t, []string{"Artificial Intelligence", "Sports/Football/EPL", "Robots"}, tags.Slice())
+	assert.True(t, tags.Contains("a.i."))
+
+	tags.Remove("ai")
+	assert.Equal(t, []string{"Sports/Football/EPL", "Robots"}, tags.Slice())
+
+	// the tags added before the dictionary are canonicalized by the normalization
+	a := article.NewArticle()
+	a.Title = "Title"
+	a.Text = "Text"
+	a.Markup = "<p>Text</p>"
+	a.Published = time.Now()
+	article.TagSynonyms = nil
+	a.Tags.Add("AI", "Artificial Intelligence", "EPL")
+	assert.Equal(t, 3, a.Tags.Len())
+
+	article.TagSynonyms = article.NewSynonyms().Add("Artificial Intelligence", "AI")
+	report, err := a.NormalizeWithReport()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Artificial Intelligence", "EPL"}, a.Tags.Slice())
+
+	canonicalized := report.Field("Article.Tags[0]")
+	require.Len(t, canonicalized, 1)
+	assert.Equal(t, article.ActionCanonicalized, canonicalized[0].Action)
+
+	removed := report.Field("Article.Tags[1]")
+	require.Len(t, removed, 1)
+	assert.Equal(t, "unique", removed[0].Tag)
+}
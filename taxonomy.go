@@ -0,0 +1,245 @@
+package article
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// TaxonomySeparator separates the levels of the taxonomy path of the tag or the category, e.g. Sports/Football/EPL.
+const TaxonomySeparator = "/"
+
+// TagDictionary maps the tags to their canonical forms, e.g. AI to Artificial Intelligence.
+type TagDictionary interface {
+	// Canonical returns the canonical form of the normalized tag, or the tag itself if unknown.
+	Canonical(tag string) string
+}
+
+// TagSynonyms is the dictionary applied by CanonicalTag to the Tags and the Article.Category.
+// Replace with the own dictionary or set to nil to keep the tags as written.
+var TagSynonyms TagDictionary = NewSynonyms()
+
+// Synonyms is the TagDictionary of the aliases of the canonical tags matched by TagKey.
+// The canonical tag could be the taxonomy path, e.g. EPL to Sports/Football/EPL.
+// The zero value is an empty dictionary ready to use.
+type Synonyms struct {
+	canonical map[string]string
+}
+
+// NewSynonyms returns the empty dictionary.
+func NewSynonyms() *Synonyms {
+	return &Synonyms{canonical: map[string]string{}}
+}
+
+// Add adds the aliases of the canonical tag, e.g. Add("Artificial Intelligence", "AI", "A.I.").
+// The canonical tag in another case is its alias too, e.g. artificial intelligence.
+func (s *Synonyms) Add(canonical string, aliases ...string) *Synonyms {
+
+	canonical = NormalizeTag(canonical)
+	if canonical == "" {
+		return s
+	}
+
+	// the zero Synonyms
+	if s.canonical == nil {
+		s.canonical = map[string]string{}
+	}
+
+	for _, alias := range append([]string{canonical}, aliases...) {
+		if key := TagKey(alias); key != "" {
+			s.canonical[key] = canonical
+		}
+	}
+
+	return s
+}
+
+// Canonical returns the canonical form of the tag, or the tag itself if unknown.
+func (s *Synonyms) Canonical(tag string) string {
+	if canonical, ok := s.canonical[TagKey(tag)]; ok {
+		return canonical
+	}
+	return tag
+}
+
+// Len returns the number of the aliases including the canonical tags.
+func (s *Synonyms) Len() int {
+	return len(s.canonical)
+}
+
+// NormalizeTag returns the tag in the Unicode NFKC form with the collapsed whitespace and without the leading #,
+// the levels of the taxonomy path are trimmed, e.g. " sports /  #Football " is sports/Football.
+// Every tag with the TaxonomySeparator is the path, e.g. AC/DC is DC under AC and 24/7 is 7 under 24.
+// The case is kept, compare the tags by TagKey.
+func NormalizeTag(tag string) string {
+
+	tag = norm.NFKC.String(tag)
+
+	var levels []string
+	for _, level := range strings.Split(tag, TaxonomySeparator) {
+		level = strings.Join(strings.Fields(level), " ")
+		level = strings.TrimLeft(level, "#")
+		level = strings.TrimSpace(level)
+		if level != "" {
+			levels = append(levels, level)
+		}
+	}
+
+	return strings.Join(levels, TaxonomySeparator)
+}
+
+// CanonicalTag returns the normalized tag mapped by the TagSynonyms, see NormalizeTag.
+func CanonicalTag(tag string) string {
+
+	tag = NormalizeTag(tag)
+	if TagSynonyms != nil && tag != "" {
+		tag = NormalizeTag(TagSynonyms.Canonical(tag))
+	}
+
+	return tag
+}
+
+// TagKey returns the case-folded normalized tag, the tags of the same key are the same, e.g. Straße and STRASSE.
+func TagKey(tag string) string {
+	return cases.Fold().String(NormalizeTag(tag))
+}
+
+// Slug returns the URL-friendly form of the tag: lowercase letters and digits without the diacritics
+// separated by hyphens, the levels of the taxonomy path separated by slashes, e.g. Sports/Café Culture is
+// sports/cafe-culture. The letters of the other scripts are kept, e.g. Футбол is футбол.
+func Slug(tag string) string {
+
+	var levels []string
+	for _, level := range strings.Split(NormalizeTag(tag), TaxonomySeparator) {
+
+		var b strings.Builder
+		hyphen := false
+
+		for _, r := range norm.NFD.String(level) {
+			switch {
+			case unicode.Is(unicode.Mn, r):
+				// diacritics
+			case unicode.IsLetter(r) || unicode.IsDigit(r):
+				if hyphen && b.Len() > 0 {
+					b.WriteByte('-')
+				}
+				hyphen = false
+				b.WriteRune(unicode.ToLower(r))
+			default:
+				hyphen = true
+			}
+		}
+
+		if slug := norm.NFC.String(b.String()); slug != "" {
+			levels = append(levels, slug)
+		}
+	}
+
+	return strings.Join(levels, TaxonomySeparator)
+}
+
+// Taxonomy is the hierarchical path of the tag or the category from the root, e.g. [Sports Football EPL].
+type Taxonomy []string
+
+// ParseTaxonomy returns the taxonomy path of the canonical tag, see CanonicalTag.
+func ParseTaxonomy(value string) Taxonomy {
+
+	value = CanonicalTag(value)
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, TaxonomySeparator)
+}
+
+// String returns the path, e.g. Sports/Football/EPL.
+func (t Taxonomy) String() string {
+	return strings.Join(t, TaxonomySeparator)
+}
+
+// Slug returns the slug of the path, e.g. sports/football/epl.
+func (t Taxonomy) Slug() string {
+	return Slug(t.String())
+}
+
+// Leaf returns the last level of the path, e.g. EPL.
+func (t Taxonomy) Leaf() string {
+	if len(t) == 0 {
+		return ""
+	}
+	return t[len(t)-1]
+}
+
+// Parent returns the path without the last level, nil for the root.
+func (t Taxonomy) Parent() Taxonomy {
+	if len(t) < 2 {
+		return nil
+	}
+	return t[:len(t)-1]
+}
+
+// Ancestors returns the paths of the parents from the root, e.g. Sports and Sports/Football.
+func (t Taxonomy) Ancestors() []Taxonomy {
+	var ancestors []Taxonomy
+	for i := 1; i < len(t); i++ {
+		ancestors = append(ancestors, t[:i])
+	}
+	return ancestors
+}
+
+// Within is true if the path equals or descends from the other path, the levels are compared by TagKey,
+// e.g. Sports/Football/EPL is within sports/football.
+func (t Taxonomy) Within(other Taxonomy) bool {
+
+	if len(other) == 0 || len(other) > len(t) {
+		return false
+	}
+
+	for i, level := range other {
+		if TagKey(level) != TagKey(t[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Taxonomies returns the taxonomy paths of the tags, the tag without the separator is the root.
+func (list *Tags) Taxonomies() []Taxonomy {
+	paths := make([]Taxonomy, 0, len(list.tags))
+	for _, tag := range list.tags {
+		paths = append(paths, ParseTaxonomy(tag))
+	}
+	return paths
+}
+
+// Within returns the tags equal to or descending from the path, e.g. Sports/Football/EPL within Sports.
+func (list *Tags) Within(path string) []string {
+
+	parent := ParseTaxonomy(path)
+
+	var tags []string
+	for _, tag := range list.tags {
+		if ParseTaxonomy(tag).Within(parent) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// CategoryPath returns the taxonomy path of the Category, e.g. News/World/Europe.
+func (a *Article) CategoryPath() Taxonomy {
+	return ParseTaxonomy(a.Category)
+}
+
+// categoryField brings the Category to the canonical form, see CanonicalTag
+func (a *Article) categoryField(report *NormalizeReport) {
+
+	if canonical := CanonicalTag(a.Category); canonical != a.Category {
+		report.Add("Article.Category", "tag", a.Category, ActionCanonicalized)
+		a.Category = canonical
+	}
+}
@@ -0,0 +1,114 @@
+package article_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+func TestNormalizeTag(t *testing.T) {
+
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"  Machine   Learning ", "Machine Learning"},
+		{"#golang", "golang"},
+		{" sports /  #Football ", "sports/Football"},
+		{"Sports//EPL/", "Sports/EPL"},
+		{"ﬁnance", "finance"},
+		{" / ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			assert.Equal(t, tt.want, article.NormalizeTag(tt.tag))
+		})
+	}
+}
+
+func TestSynonyms_Zero(t *testing.T) {
+
+	var synonyms article.Synonyms
+	assert.Equal(t, "AI", synonyms.Canonical("AI"))
+
+	require.NotPanics(t, func() {
+		synonyms.Add("Artificial Intelligence", "AI")
+	})
+	assert.Equal(t, "Artificial Intelligence", synonyms.Canonical("ai"))
+	assert.Equal(t, 2, synonyms.Len())
+}
+
+func TestSlug(t *testing.T) {
+
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"Machine Learning", "machine-learning"},
+		{"Sports/Café Culture", "sports/cafe-culture"},
+		{"C++ & Go!", "c-go"},
+		{"Ünïcödé  —  Tags", "unicode-tags"},
+		{"Футбол", "футбол"},
+		{"--", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			assert.Equal(t, tt.want, article.Slug(tt.tag))
+		})
+	}
+}
+
+func TestTaxonomy(t *testing.T) {
+
+	path := article.ParseTaxonomy(" Sports / Football / EPL ")
+	assert.Equal(t, article.Taxonomy{"Sports", "Football", "EPL"}, path)
+	assert.Equal(t, "Sports/Football/EPL", path.String())
+	assert.Equal(t, "sports/football/epl", path.Slug())
+	assert.Equal(t, "EPL", path.Leaf())
+	assert.Equal(t, article.Taxonomy{"Sports", "Football"}, path.Parent())
+	assert.Equal(t, []article.Taxonomy{{"Sports"}, {"Sports", "Football"}}, path.Ancestors())
+
+	assert.True(t, path.Within(article.ParseTaxonomy("sports/football")))
+	assert.True(t, path.Within(path))
+	assert.False(t, path.Within(article.ParseTaxonomy("Sports/Tennis")))
+	assert.False(t, path.Parent().Within(path))
+	assert.Nil(t, article.ParseTaxonomy("").Parent())
+
+	tags := article.NewTags("Sports/Football/EPL", "Sports/Tennis", "Football", "sports/football")
+	assert.Equal(t, []string{"Sports/Football/EPL", "sports/football"}, tags.Within("Sports/Football"))
+	assert.Len(t, tags.Taxonomies(), 4)
+}
+
+func TestArticle_CategoryPath(t *testing.T) {
+
+	defer func(synonyms article.TagDictionary) { article.TagSynonyms = synonyms }(article.TagSynonyms)
+	article.TagSynonyms = article.NewSynonyms().Add("Sports/Football", "Soccer")
+
+	a := article.NewArticle()
+	a.Title = "Title"
+	a.Text = "Text"
+	a.Markup = "<p>Text</p>"
+	a.Published = time.Now()
+	a.Category = " News /  World "
+
+	report, err := a.NormalizeWithReport()
+	require.NoError(t, err)
+	assert.Equal(t, "News/World", a.Category)
+	assert.Equal(t, article.Taxonomy{"News", "World"}, a.CategoryPath())
+	require.Len(t, report.Field("Article.Category"), 1)
+	assert.Equal(t, article.ActionCanonicalized, report.Field("Article.Category")[0].Action)
+
+	a.Category = "soccer"
+	require.NoError(t, a.Normalize())
+	assert.Equal(t, "Sports/Football", a.Category)
+
+	a.Category = " / "
+	require.NoError(t, a.Normalize())
+	assert.Equal(t, "General", a.Category)
+}
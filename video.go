@@ -1,9 +1,10 @@
 package article
 
 import (
-	"github.com/go-playground/validator/v10"
-	"github.com/google/uuid"
 	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
 )
 
 // Video represents a video in the article.
@@ -15,15 +16,31 @@ type Video struct {
 
 	// Title is the title for the video.
 	// This field is optional.
-	Title string `json:"title,omitempty" validate:"max=500"`
+	Title string `json:"title,omitempty" validate:"limit=Caption"`
 
 	// URL is the URL of the video.
 	// This field is required and should be a valid URL.
-	URL string `json:"url" validate:"required,url,max=4096"`
+	URL string `json:"url" validate:"required,url,limit=URL"`
 
 	// Embed is the embed code for the video.
 	// This field is optional.
-	Embed string `json:"embed,omitempty" validate:"max=65000"`
+	Embed string `json:"embed,omitempty" validate:"limit=Embed"`
+
+	// Provider is the video hosting, e.g. youtube, see VideoProviders.
+	// This field is optional and recognized from the URL or the Embed during normalization.
+	Provider string `json:"provider,omitempty" validate:"max=50"`
+
+	// ProviderID is the ID of the video at the provider, e.g. dQw4w9WgXcQ.
+	// This field is optional and recognized with the Provider.
+	ProviderID string `json:"provider_id,omitempty" validate:"limit=Name"`
+
+	// Duration is the length of the video in seconds.
+	// This field is optional.
+	Duration int `json:"duration,omitempty" validate:"min=0"`
+
+	// Thumbnail is the URL of the preview image.
+	// This field is optional and defaults to the provider thumbnail.
+	Thumbnail string `json:"thumbnail,omitempty" validate:"omitempty,url,limit=URL"`
 }
 
 // NewVideo creates a new Video with a random UUID.
@@ -36,47 +53,105 @@ func NewVideo(url string) *Video {
 	}
 }
 
+// GetID returns the ID of the Video.
+func (v *Video) GetID() string {
+	return v.ID
+}
+
 // Normalize validates and trims the fields of the Video.
+// The Video is reset to the zero value if it is invalid.
 func (v *Video) Normalize() {
+	if err := v.normalize(nil, "Video"); err != nil {
+		*v = Video{}
+	}
+}
+
+// normalize trims the fields, records the changes to the report and returns the validation error.
+func (v *Video) normalize(report *NormalizeReport, path string) error {
 
 	if v.ID == "" {
 		v.ID = uuid.New().String()
+		report.fallback(path+".ID", "")
 	}
 
-	v.ID = TrimToMaxLen(v.ID, 36)
-	v.URL = TrimToMaxLen(v.URL, 4096)
-	v.Embed = TrimToMaxLen(v.Embed, 65000)
-	v.Title = TrimToMaxLen(v.Title, 500)
+	v.ID = report.trim(path+".ID", v.ID, 36)
+	v.URL = URLCanonicalizer.asset(report, path+".URL", report.trim(path+".URL", v.URL, report.limits().URL), report.baseURL())
+	v.Embed = EmbedPolicy.sanitize(report, path+".Embed", report.trim(path+".Embed", v.Embed, report.limits().Embed))
+	v.Title = report.trim(path+".Title", v.Title, report.limits().Caption)
+	v.Provider = report.trim(path+".Provider", strings.ToLower(v.Provider), 50)
+	v.ProviderID = report.trim(path+".ProviderID", v.ProviderID, report.limits().Name)
+	v.Thumbnail = URLCanonicalizer.asset(report, path+".Thumbnail", report.trim(path+".Thumbnail", v.Thumbnail, report.limits().URL), report.baseURL())
+
+	if v.detect(report, path) && v.Embed == "" {
+		v.Embed = v.EmbedHTML()
+		report.fallback(path+".Embed", "")
+	}
 
-	err := validate.Struct(v)
+	err := report.validate(v)
 	if err != nil {
-		for _, err := range err.(validator.ValidationErrors) {
-			slog.Debug("Validation error in Video", slog.String("field", err.Namespace()), slog.String("error", err.Tag()))
-			*v = Video{}
-		}
+		slog.Debug("Validation error in Video", slog.String("path", path), slog.String("error", err.Error()))
 	}
+
+	return err
 }
 
 // Map converts the Video struct to a map[string]any.
+// The provider, duration and thumbnail are included only if set.
 func (v *Video) Map() map[string]any {
-	return map[string]any{
+
+	m := map[string]any{
 		"id":    v.ID,
 		"url":   v.URL,
 		"embed": v.Embed,
 		"title": v.Title,
 	}
+
+	if v.Provider != "" {
+		m["provider"] = v.Provider
+		m["provider_id"] = v.ProviderID
+	}
+
+	if v.Duration > 0 {
+		m["duration"] = v.Duration
+	}
+
+	if v.Thumbnail != "" {
+		m["thumbnail"] = v.Thumbnail
+	}
+
+	return m
 }
 
 // NewVideoFromMap creates a Video from a map[string]any, validates it, and returns a pointer to the Video or an error.
 func NewVideoFromMap(m map[string]any) (*Video, error) {
+	return videoFromMap(newMapReader(m))
+}
+
+// NewVideoFromMapStrict creates a Video from a map[string]any like NewVideoFromMap,
+// but fails with MapErrors listing every key holding a value of unexpected type.
+func NewVideoFromMapStrict(m map[string]any) (*Video, error) {
+	return videoFromMap(newStrictMapReader(m))
+}
+
+// videoFromMap reads the Video with the map reader, validates it, and returns a pointer to the Video or an error.
+func videoFromMap(r *mapReader) (*Video, error) {
 	video := &Video{
-		ID:    StringFromMap(m, "id"),
-		URL:   StringFromMap(m, "url"),
-		Embed: StringFromMap(m, "embed"),
-		Title: StringFromMap(m, "title"),
+		ID:    r.string("id"),
+		URL:   r.string("url"),
+		Embed: r.string("embed"),
+		Title: r.string("title"),
+
+		Provider:   r.string("provider"),
+		ProviderID: r.string("provider_id"),
+		Duration:   r.int("duration"),
+		Thumbnail:  r.string("thumbnail"),
+	}
+
+	if err := r.failed(); err != nil {
+		return nil, err
 	}
 
-	err := validate.Struct(video)
+	err := r.validate(video)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,345 @@
+package article
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Names of the built-in video providers.
+const (
+	ProviderYouTube     = "youtube"
+	ProviderVimeo       = "vimeo"
+	ProviderDailymotion = "dailymotion"
+	ProviderTikTok      = "tiktok"
+	ProviderRutube      = "rutube"
+)
+
+// VideoProvider recognizes the videos of the hosting by the URL and generates the canonical URLs.
+// The URL templates contain the {id} placeholder of the video ID, the empty template is not supported.
+type VideoProvider struct {
+	// Name of the provider, e.g. youtube.
+	Name string
+	// Hosts of the provider, subdomains included, e.g. youtube.com.
+	Hosts []string
+	// ID returns the video ID of the URL on the provider host, empty if the URL is not a video, e.g. channel page.
+	ID func(u *url.URL) string
+	// WatchURL is the template of the video page, e.g. https://www.youtube.com/watch?v={id}.
+	WatchURL string
+	// EmbedURL is the template of the player, e.g. https://www.youtube.com/embed/{id}.
+	EmbedURL string
+	// ThumbnailURL is the template of the preview image, e.g. https://i.ytimg.com/vi/{id}/hqdefault.jpg.
+	ThumbnailURL string
+}
+
+// VideoProviders are the providers recognized during Normalize, the first matching provider is used.
+// Append own providers or set to nil to disable the recognition.
+var VideoProviders = DefaultVideoProviders()
+
+// DefaultVideoProviders returns YouTube, Vimeo, Dailymotion, TikTok and Rutube.
+func DefaultVideoProviders() []*VideoProvider {
+	return []*VideoProvider{
+		{
+			Name:         ProviderYouTube,
+			Hosts:        []string{"youtube.com", "youtu.be", "youtube-nocookie.com"},
+			ID:           youtubeID,
+			WatchURL:     "https://www.youtube.com/watch?v={id}",
+			EmbedURL:     "https://www.youtube.com/embed/{id}",
+			ThumbnailURL: "https://i.ytimg.com/vi/{id}/hqdefault.jpg",
+		},
+		{
+			Name:     ProviderVimeo,
+			Hosts:    []string{"vimeo.com"},
+			ID:       segmentID(regexp.MustCompile(`^[0-9]{6,}$`)),
+			WatchURL: "https://vimeo.com/{id}",
+			EmbedURL: "https://player.vimeo.com/video/{id}",
+		},
+		{
+			Name:         ProviderDailymotion,
+			Hosts:        []string{"dailymotion.com", "dai.ly"},
+			ID:           dailymotionID,
+			WatchURL:     "https://www.dailymotion.com/video/{id}",
+			EmbedURL:     "https://www.dailymotion.com/embed/video/{id}",
+			ThumbnailURL: "https://www.dailymotion.com/thumbnail/video/{id}",
+		},
+		{
+			Name:     ProviderTikTok,
+			Hosts:    []string{"tiktok.com"},
+			ID:       segmentID(regexp.MustCompile(`^[0-9]{15,21}$`)),
+			EmbedURL: "https://www.tiktok.com/embed/v2/{id}",
+		},
+		{
+			Name:         ProviderRutube,
+			Hosts:        []string{"rutube.ru"},
+			ID:           segmentID(regexp.MustCompile(`^[0-9a-f]{32}$`)),
+			WatchURL:     "https://rutube.ru/video/{id}/",
+			EmbedURL:     "https://rutube.ru/play/embed/{id}",
+			ThumbnailURL: "https://rutube.ru/api/video/{id}/thumbnail/?redirect=1",
+		},
+	}
+}
+
+// DetectVideo returns the provider and the video ID of the URL, nil if the URL is not a video of the VideoProviders.
+func DetectVideo(raw string) (*VideoProvider, string) {
+
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, ""
+	}
+
+	// protocol-relative embeds, e.g. //www.youtube.com/embed/ID
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "" {
+		return nil, ""
+	}
+
+	for _, provider := range VideoProviders {
+		if provider == nil || provider.ID == nil || !provider.host(host) {
+			continue
+		}
+		if id := provider.ID(u); id != "" {
+			return provider, id
+		}
+	}
+
+	return nil, ""
+}
+
+// VideoProviderByName returns the provider of the VideoProviders by the name, nil if not found.
+func VideoProviderByName(name string) *VideoProvider {
+
+	for _, provider := range VideoProviders {
+		if provider != nil && strings.EqualFold(provider.Name, name) {
+			return provider
+		}
+	}
+
+	return nil
+}
+
+// Watch returns the video page URL of the ID, empty if the template is not set.
+func (p *VideoProvider) Watch(id string) string {
+	return expandID(p.WatchURL, id)
+}
+
+// Embed returns the player URL of the ID, empty if the template is not set.
+func (p *VideoProvider) Embed(id string) string {
+	return expandID(p.EmbedURL, id)
+}
+
+// Thumbnail returns the preview image URL of the ID, empty if the template is not set.
+func (p *VideoProvider) Thumbnail(id string) string {
+	return expandID(p.ThumbnailURL, id)
+}
+
+// host is true if the host is one of the provider hosts or their subdomain
+func (p *VideoProvider) host(host string) bool {
+
+	for _, h := range p.Hosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// expandID replaces the {id} placeholder of the template
+func expandID(template, id string) string {
+
+	if template == "" || id == "" {
+		return ""
+	}
+
+	return strings.ReplaceAll(template, "{id}", url.PathEscape(id))
+}
+
+// Detect recognizes the provider and the video ID from the URL or the iframe of the Embed
+// and fills the empty Thumbnail with the provider thumbnail. Returns false for the unknown video.
+func (v *Video) Detect() bool {
+	return v.detect(nil, "Video")
+}
+
+// detect recognizes the provider and records the filled fields to the report
+func (v *Video) detect(report *NormalizeReport, path string) bool {
+
+	provider, id := DetectVideo(v.URL)
+	if provider == nil {
+		provider, id = DetectVideo(embedSource(v.Embed))
+	}
+
+	if provider == nil {
+		return false
+	}
+
+	if v.Provider != provider.Name || v.ProviderID != id {
+		v.Provider, v.ProviderID = provider.Name, id
+		report.fallback(path+".Provider", v.Provider)
+	}
+
+	if v.Thumbnail == "" {
+		if v.Thumbnail = provider.Thumbnail(id); v.Thumbnail != "" {
+			report.fallback(path+".Thumbnail", v.Thumbnail)
+		}
+	}
+
+	return true
+}
+
+// EmbedHTML returns the canonical iframe of the provider player with the title,
+// empty for the unknown provider, see Detect.
+func (v *Video) EmbedHTML() string {
+
+	provider := VideoProviderByName(v.Provider)
+	if provider == nil {
+		return ""
+	}
+
+	src := provider.Embed(v.ProviderID)
+	if src == "" {
+		return ""
+	}
+
+	width, height := 640, 360
+	if v.Provider == ProviderTikTok {
+		width, height = 325, 580
+	}
+
+	var b strings.Builder
+	b.WriteString(`<iframe src="` + html.EscapeString(src) + `"`)
+	if v.Title != "" {
+		b.WriteString(` title="` + html.EscapeString(v.Title) + `"`)
+	}
+	b.WriteString(` width="` + strconv.Itoa(width) + `" height="` + strconv.Itoa(height) + `"`)
+	b.WriteString(` frameborder="0" allow="autoplay; encrypted-media; picture-in-picture; fullscreen" allowfullscreen="" loading="lazy"></iframe>`)
+
+	return b.String()
+}
+
+// embedSource returns the src of the first iframe or the cite of the blockquote embed, e.g. TikTok
+func embedSource(embed string) string {
+
+	if embed == "" {
+		return ""
+	}
+
+	z := html.NewTokenizer(strings.NewReader(embed))
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return ""
+		}
+
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+
+		token := z.Token()
+		switch token.Data {
+		case "iframe":
+			return tokenAttr(token, "src")
+		case "blockquote":
+			if cite := tokenAttr(token, "cite"); cite != "" {
+				return cite
+			}
+		}
+	}
+}
+
+// tokenAttr returns the attribute value of the token
+func tokenAttr(token html.Token, key string) string {
+
+	for _, a := range token.Attr {
+		if a.Key == key {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+
+	return ""
+}
+
+// pathSegments returns the non-empty segments of the URL path
+func pathSegments(u *url.URL) []string {
+
+	var segments []string
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	return segments
+}
+
+// segmentID returns the first path segment matching the pattern
+func segmentID(pattern *regexp.Regexp) func(u *url.URL) string {
+	return func(u *url.URL) string {
+		for _, segment := range pathSegments(u) {
+			if pattern.MatchString(segment) {
+				return segment
+			}
+		}
+		return ""
+	}
+}
+
+var youtubeIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+// youtubeID supports watch?v=, youtu.be, embed, shorts, live and v URLs
+func youtubeID(u *url.URL) string {
+
+	segments := pathSegments(u)
+
+	var id string
+	switch {
+	case strings.HasSuffix(strings.ToLower(u.Hostname()), "youtu.be") && len(segments) > 0:
+		id = segments[0]
+	case len(segments) > 0 && segments[0] == "watch":
+		id = u.Query().Get("v")
+	case len(segments) > 1:
+		switch segments[0] {
+		case "embed", "shorts", "live", "v", "e":
+			id = segments[1]
+		}
+	}
+
+	if youtubeIDPattern.MatchString(id) {
+		return id
+	}
+
+	return ""
+}
+
+var dailymotionIDPattern = regexp.MustCompile(`^[A-Za-z0-9]{5,}$`)
+
+// dailymotionID supports video, embed, dai.ly and player URLs, the title slug after _ is dropped
+func dailymotionID(u *url.URL) string {
+
+	segments := pathSegments(u)
+
+	var id string
+	switch {
+	case strings.HasSuffix(strings.ToLower(u.Hostname()), "dai.ly") && len(segments) > 0:
+		id = segments[0]
+	case u.Query().Get("video") != "":
+		id = u.Query().Get("video")
+	default:
+		for idx, segment := range segments {
+			if segment == "video" && idx+1 < len(segments) {
+				id = segments[idx+1]
+				break
+			}
+		}
+	}
+
+	id, _, _ = strings.Cut(id, "_")
+	if dailymotionIDPattern.MatchString(id) {
+		return id
+	}
+
+	return ""
+}
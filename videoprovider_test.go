@@ -0,0 +1,170 @@
+package article_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/editorpost/article"
+)
+
+func TestDetectVideo(t *testing.T) {
+
+	tests := []struct {
+		url      string
+		provider string
+		id       string
+	}{
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42s", article.ProviderYouTube, "dQw4w9WgXcQ"},
+		{"https://m.youtube.com/watch?feature=share&v=dQw4w9WgXcQ", article.ProviderYouTube, "dQw4w9WgXcQ"},
+		{"https://youtu.be/dQw4w9WgXcQ?si=abc", article.ProviderYouTube, "dQw4w9WgXcQ"},
+		{"https://www.youtube.com/embed/dQw4w9WgXcQ", article.ProviderYouTube, "dQw4w9WgXcQ"},
+		{"//www.youtube-nocookie.com/embed/dQw4w9WgXcQ?rel=0", article.ProviderYouTube, "dQw4w9WgXcQ"},
+		{"https://www.youtube.com/shorts/aqz-KE-bpKQ", article.ProviderYouTube, "aqz-KE-bpKQ"},
+		{"https://www.youtube.com/live/aqz-KE-bpKQ", article.ProviderYouTube, "aqz-KE-bpKQ"},
+		{"https://vimeo.com/76979871", article.ProviderVimeo, "76979871"},
+		{"https://vimeo.com/channels/staffpicks/76979871", article.ProviderVimeo, "76979871"},
+		{"https://player.vimeo.com/video/76979871?h=8272103f6e", article.ProviderVimeo, "76979871"},
+		{"https://www.dailymotion.com/video/x7tgad0_some-title", article.ProviderDailymotion, "x7tgad0"},
+		{"https://www.dailymotion.com/embed/video/x7tgad0?autoplay=1", article.ProviderDailymotion, "x7tgad0"},
+		{"https://dai.ly/x7tgad0", article.ProviderDailymotion, "x7tgad0"},
+		{"https://geo.dailymotion.com/player/xtv3w.html?video=x7tgad0", article.ProviderDailymotion, "x7tgad0"},
+		{"https://www.tiktok.com/@scout2015/video/6718335390845095173", article.ProviderTikTok, "6718335390845095173"},
+		{"https://www.tiktok.com/embed/v2/6718335390845095173", article.ProviderTikTok, "6718335390845095173"},
+		{"https://rutube.ru/video/c6cc4d620b1d4338901770a44b3e82f4/", article.ProviderRutube, "c6cc4d620b1d4338901770a44b3e82f4"},
+		{"https://rutube.ru/play/embed/c6cc4d620b1d4338901770a44b3e82f4", article.ProviderRutube, "c6cc4d620b1d4338901770a44b3e82f4"},
+		{"https://www.youtube.com/@channel", "", ""},
+		{"https://www.youtube.com/watch?v=short", "", ""},
+		{"https://notyoutube.com/watch?v=dQw4w9WgXcQ", "", ""},
+		{"https://vimeo.com/about", "", ""},
+		{"https://example.com/video.mp4", "", ""},
+		{"not a url", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			provider, id := article.DetectVideo(tt.url)
+			if tt.provider == "" {
+				assert.Nil(t, provider)
+				assert.Empty(t, id)
+				return
+			}
+			require.NotNil(t, provider)
+			assert.Equal(t, tt.provider, provider.Name)
+			assert.Equal(t, tt.id, id)
+		})
+	}
+}
+
+func TestVideo_Detect(t *testing.T) {
+
+	t.Run("url", func(t *testing.T) {
+		video := article.NewVideo("https://youtu.be/dQw4w9WgXcQ")
+		require.True(t, video.Detect())
+		assert.Equal(t, article.ProviderYouTube, video.Provider)
+		assert.Equal(t, "dQw4w9WgXcQ", video.ProviderID)
+		assert.Equal(t, "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg", video.Thumbnail)
+	})
+
+	t.Run("embed", func(t *testing.T) {
+		video := article.NewVideo("https://example.com/news/1")
+		video.Embed = `<div><iframe src="https://player.vimeo.com/video/76979871" width="640"></iframe></div>`
+		require.True(t, video.Detect())
+		assert.Equal(t, article.ProviderVimeo, video.Provider)
+		assert.Equal(t, "76979871", video.ProviderID)
+		assert.Empty(t, video.Thumbnail)
+	})
+
+	t.Run("tiktok blockquote", func(t *testing.T) {
+		video := article.NewVideo("https://example.com/news/1")
+		video.Embed = `<blockquote class="tiktok-embed" cite="https://www.tiktok.com/@scout2015/video/6718335390845095173" data-video-id="6718335390845095173"></blockquote>`
+		require.True(t, video.Detect())
+		assert.Equal(t, article.ProviderTikTok, video.Provider)
+	})
+
+	t.Run("keeps thumbnail", func(t *testing.T) {
+		video := article.NewVideo("https://rutube.ru/video/c6cc4d620b1d4338901770a44b3e82f4/")
+		video.Thumbnail = "https://example.com/preview.jpg"
+		require.True(t, video.Detect())
+		assert.Equal(t, "https://example.com/preview.jpg", video.Thumbnail)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		video := article.NewVideo("https://example.com/video.mp4")
+		assert.False(t, video.Detect())
+		assert.Empty(t, video.Provider)
+		assert.Empty(t, video.EmbedHTML())
+	})
+}
+
+func TestVideo_EmbedHTML(t *testing.T) {
+
+	video := article.NewVideo("https://www.dailymotion.com/video/x7tgad0")
+	video.Title = `Tom & "Jerry"`
+	require.True(t, video.Detect())
+
+	embed := video.EmbedHTML()
+	assert.Equal(t, `<iframe src="https://www.dailymotion.com/embed/video/x7tgad0" title="Tom &amp; &#34;Jerry&#34;" `+
+		`width="640" height="360" frameborder="0" allow="autoplay; encrypted-media; picture-in-picture; fullscreen" `+
+		`allowfullscreen="" loading="lazy"></iframe>`, embed)
+
+	// the canonical embed passes the default embed policy
+	sanitized, removed := article.TrustedEmbedPolicy().Sanitize(embed)
+	assert.Empty(t, removed)
+	assert.Equal(t, embed, sanitized)
+}
+
+func TestVideo_NormalizeProvider(t *testing.T) {
+
+	a := article.NewArticle()
+	a.Title = "Title"
+	a.Text = "Text"
+	a.Markup = "<p>Text</p>"
+	a.Videos.Add(article.NewVideo("https://www.youtube.com/watch?v=dQw4w9WgXcQ&utm_source=x"))
+
+	report, err := a.NormalizeWithReport()
+	require.NoError(t, err)
+
+	video := a.Videos.Slice()[0]
+	assert.Equal(t, article.ProviderYouTube, video.Provider)
+	assert.Equal(t, "dQw4w9WgXcQ", video.ProviderID)
+	assert.Equal(t, "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg", video.Thumbnail)
+	assert.Contains(t, video.Embed, `src="https://www.youtube.com/embed/dQw4w9WgXcQ"`)
+	assert.Len(t, report.Field("Article.Videos[0].Provider"), 1)
+	assert.Len(t, report.Field("Article.Videos[0].Embed"), 1)
+
+	// idempotent
+	report, err = a.NormalizeWithReport()
+	require.NoError(t, err)
+	assert.Empty(t, report.Field("Article.Videos[0]"))
+
+	fromMap, err := article.NewVideoFromMap(video.Map())
+	require.NoError(t, err)
+	assert.Equal(t, video, fromMap)
+}
+
+func TestVideoProviders_Custom(t *testing.T) {
+
+	defer func(providers []*article.VideoProvider) { article.VideoProviders = providers }(article.VideoProviders)
+
+	article.VideoProviders = append(article.VideoProviders, &article.VideoProvider{
+		Name:  "peertube",
+		Hosts: []string{"peertube.example.com"},
+		ID: func(u *url.URL) string {
+			id, _ := strings.CutPrefix(u.Path, "/w/")
+			return id
+		},
+		EmbedURL: "https://peertube.example.com/videos/embed/{id}",
+	})
+
+	video := article.NewVideo("https://peertube.example.com/w/9c9de5e8")
+	require.True(t, video.Detect())
+	assert.Equal(t, "peertube", video.Provider)
+	assert.Contains(t, video.EmbedHTML(), "https://peertube.example.com/videos/embed/9c9de5e8")
+
+	article.VideoProviders = nil
+	assert.False(t, article.NewVideo("https://youtu.be/dQw4w9WgXcQ").Detect())
+}